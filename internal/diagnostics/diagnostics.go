@@ -0,0 +1,215 @@
+// internal/diagnostics/diagnostics.go
+package diagnostics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Severity tells whether a problem breaks the data or only looks suspicious
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Problem is a data problem found while loading or validating a file
+type Problem struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line,omitempty"` // 0 if the whole file is affected
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// String formats the problem as file:line: severity: message
+func (p Problem) String() string {
+	location := p.File
+	if p.Line > 0 {
+		location += ":" + strconv.Itoa(p.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, p.Severity, p.Message)
+}
+
+// Collector gathers problems reported while the data files are loaded
+type Collector struct {
+	mu       sync.Mutex
+	problems []Problem
+}
+
+// Add records a problem, ignoring exact duplicates (caches may reload a file)
+func (c *Collector) Add(problem Problem) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range c.problems {
+		if p == problem {
+			return
+		}
+	}
+	c.problems = append(c.problems, problem)
+}
+
+// Problems returns the problems recorded so far, sorted by file and line
+func (c *Collector) Problems() []Problem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	problems := append([]Problem(nil), c.problems...)
+	Sort(problems)
+	return problems
+}
+
+// Reset clears the recorded problems
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.problems = nil
+}
+
+// startup collects the problems reported by the data loaders
+var startup Collector
+
+// Errorf records an error in a data file
+func Errorf(file string, line int, format string, args ...any) {
+	startup.Add(Problem{File: file, Line: line, Severity: SeverityError, Message: fmt.Sprintf(format, args...)})
+}
+
+// Warnf records a warning about a data file
+func Warnf(file string, line int, format string, args ...any) {
+	startup.Add(Problem{File: file, Line: line, Severity: SeverityWarning, Message: fmt.Sprintf(format, args...)})
+}
+
+// ReportJSONError records a file that failed to parse, at the line of the error
+func ReportJSONError(file string, data []byte, err error) {
+	startup.Add(JSONProblem(file, data, err))
+}
+
+// Problems returns the problems the data loaders reported
+func Problems() []Problem {
+	return startup.Problems()
+}
+
+// Reset clears the problems the data loaders reported
+func Reset() {
+	startup.Reset()
+}
+
+// Errors counts the problems with error severity
+func Errors(problems []Problem) int {
+	count := 0
+	for _, p := range problems {
+		if p.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+// Sort orders problems by file, then line
+func Sort(problems []Problem) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].File != problems[j].File {
+			return problems[i].File < problems[j].File
+		}
+		return problems[i].Line < problems[j].Line
+	})
+}
+
+// JSONProblem converts a JSON decoding error into a problem at the line it occurred
+func JSONProblem(file string, data []byte, err error) Problem {
+	problem := Problem{File: file, Severity: SeverityError, Message: err.Error()}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		problem.Line = LineAt(data, syntaxErr.Offset)
+		problem.Message = "invalid JSON: " + syntaxErr.Error()
+	case errors.As(err, &typeErr):
+		problem.Line = LineAt(data, typeErr.Offset)
+		problem.Message = fmt.Sprintf("%s should be %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value)
+	}
+	return problem
+}
+
+// LineAt returns the 1-based line of a byte offset
+func LineAt(data []byte, offset int64) int {
+	offset = min(max(offset, 0), int64(len(data)))
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// LineIndex maps the JSON paths of a document (e.g. "origins[2].feat") to the
+// line their value starts on, so checks on decoded data can point at the file
+type LineIndex map[string]int
+
+// IndexLines builds the line index of a JSON document; it is empty if the
+// document doesn't parse
+func IndexLines(data []byte) LineIndex {
+	index := LineIndex{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := indexValue(dec, data, "", index); err != nil {
+		return LineIndex{}
+	}
+	return index
+}
+
+// Line returns the line of a path, falling back to its closest indexed parent
+func (idx LineIndex) Line(path string) int {
+	for path != "" {
+		if line, ok := idx[path]; ok {
+			return line
+		}
+		cut := strings.LastIndexAny(path, ".[")
+		if cut <= 0 {
+			break
+		}
+		path = path[:cut]
+	}
+	return idx[""]
+}
+
+// indexValue records the line of the value at path and of everything inside it
+func indexValue(dec *json.Decoder, data []byte, path string, index LineIndex) error {
+	// The decoder's offset is just past the previous token; skip to the value itself
+	offset := dec.InputOffset()
+	for offset < int64(len(data)) && strings.IndexByte(" \t\r\n,:", data[offset]) >= 0 {
+		offset++
+	}
+	index[path] = LineAt(data, offset)
+
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch token {
+	case json.Delim('{'):
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			name, _ := key.(string)
+			child := name
+			if path != "" {
+				child = path + "." + name
+			}
+			if err := indexValue(dec, data, child, index); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err := indexValue(dec, data, fmt.Sprintf("%s[%d]", path, i), index); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	}
+	return err
+}
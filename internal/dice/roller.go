@@ -91,7 +91,7 @@ func Roll(expression string, rollType RollType) (*RollResult, error) {
 	rolls := rollDice(dice, sides, rollType)
 
 	// Calculate total
-	total := sum(rolls) + modifier
+	total := sum(rolls, rollType) + modifier
 
 	return &RollResult{
 		Expression: expression,
@@ -260,12 +260,12 @@ func rollSingleDie(sides int) int {
 }
 
 // sum calculates the sum of rolls (for advantage/disadvantage, only counts first roll)
-func sum(rolls []int) int {
+func sum(rolls []int, rollType RollType) int {
 	if len(rolls) == 0 {
 		return 0
 	}
 	// For advantage/disadvantage with 2 rolls, only count the first (best/worst)
-	if len(rolls) == 2 {
+	if rollType == Advantage || rollType == Disadvantage {
 		return rolls[0]
 	}
 	// For normal rolls, sum all
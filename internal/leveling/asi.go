@@ -0,0 +1,148 @@
+// internal/leveling/asi.go
+package leveling
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// MaxAbilityScore is the highest score an Ability Score Improvement can reach
+const MaxAbilityScore = models.AbilityScoreMax
+
+// asiSourceType is the benefit source type of Ability Score Improvements
+const asiSourceType = "asi"
+
+// standardASILevels are used for classes without level_progression data
+var standardASILevels = []int{4, 8, 12, 16, 19}
+
+// AbilityScoreImprovementSource returns the benefit source of the ASI taken at a level, e.g. asi:level-8
+func AbilityScoreImprovementSource(level int) models.BenefitSource {
+	return models.BenefitSource{Type: asiSourceType, Name: fmt.Sprintf("level-%d", level)}
+}
+
+// CanIncreaseAbilityScores checks the class's ability_score_improvement flag for a level
+// (Fighters also gain one at 6 and 14, Rogues at 10)
+func CanIncreaseAbilityScores(class string, newLevel int) bool {
+	if classData := models.GetClassByName(class); classData != nil {
+		if entry := classData.GetLevel(newLevel); entry != nil {
+			return entry.AbilityScoreImprovement
+		}
+	}
+	return slices.Contains(standardASILevels, newLevel)
+}
+
+// ValidateAbilityIncreases ensures ability increases spend exactly 2 points
+// (+2 to one ability or +1 to two) without raising a score above 20
+func ValidateAbilityIncreases(scores models.AbilityScores, increases map[models.AbilityType]int) error {
+	total := 0
+	for ability, increase := range increases {
+		if increase < 0 {
+			return fmt.Errorf("%s can't be decreased", ability.FullName())
+		}
+		if score := scores.GetScore(ability); score+increase > MaxAbilityScore {
+			return fmt.Errorf("%s would rise above %d", ability.FullName(), MaxAbilityScore)
+		}
+		total += increase
+	}
+	// Standard ASI gives 2 points total
+	if total != 2 {
+		return fmt.Errorf("an ability score improvement grants 2 points, got %d", total)
+	}
+	return nil
+}
+
+// ApplyAbilityScoreImprovement applies the increases for the ASI gained at a level.
+// They are tracked under the level's asi source so they can be undone.
+func ApplyAbilityScoreImprovement(char *models.Character, level int, increases map[models.AbilityType]int) error {
+	if err := ValidateAbilityIncreases(char.AbilityScores, increases); err != nil {
+		return err
+	}
+
+	source := AbilityScoreImprovementSource(level)
+	applier := models.NewBenefitApplier(char)
+	recorded := map[string]int{}
+	for ability, increase := range increases {
+		if increase > 0 {
+			applier.AddAbilityScore(source, ability.FullName(), increase)
+			recorded[ability.FullName()] = increase
+		}
+	}
+	if record := char.GetLevelRecord(level); record != nil {
+		record.AbilityIncrease = recorded
+		record.Feat, record.FeatAbility = "", ""
+	}
+	char.UpdateDerivedStats()
+	return nil
+}
+
+// CheckFeatForASI reports whether a feat can replace the Ability Score Improvement at a
+// character level: a General feat, or from level 19 an Epic Boon
+func CheckFeatForASI(feat models.Feat, level int) error {
+	if feat.Category == "General" {
+		return nil
+	}
+	if level >= models.EpicBoonLevel {
+		if feat.Category == models.FeatCategoryEpicBoon {
+			return nil
+		}
+		return fmt.Errorf("%s (%s) can't replace the Ability Score Improvement, take a General feat or an Epic Boon", feat.Name, feat.Category)
+	}
+	return fmt.Errorf("%s (%s) can't replace the Ability Score Improvement, take a General feat", feat.Name, feat.Category)
+}
+
+// RecordFeatForASI records a General feat (and the ability chosen for its increase)
+// taken in place of the ASI at a level
+func RecordFeatForASI(char *models.Character, level int, featName, chosenAbility string) {
+	models.NewBenefitApplier(char).AddFeat(AbilityScoreImprovementSource(level), featName)
+	if record := char.GetLevelRecord(level); record != nil {
+		record.AbilityIncrease = nil
+		record.Feat, record.FeatAbility = featName, chosenAbility
+	}
+}
+
+// PendingAbilityScoreImprovements returns the character levels whose class level granted
+// an ASI without a recorded ability increase or feat
+func PendingAbilityScoreImprovements(char *models.Character) []int {
+	var pending []int
+	for level := 1; level <= char.Level; level++ {
+		className, classLevel := char.ClassAtLevel(level)
+		if CanIncreaseAbilityScores(className, classLevel) && !hasAbilityScoreImprovement(char, level) {
+			pending = append(pending, level)
+		}
+	}
+	return pending
+}
+
+// LastAbilityScoreImprovement returns the highest level with a recorded ASI choice, or 0
+func LastAbilityScoreImprovement(char *models.Character) int {
+	for level := char.Level; level >= 1; level-- {
+		if hasAbilityScoreImprovement(char, level) {
+			return level
+		}
+	}
+	return 0
+}
+
+// UndoAbilityScoreImprovement removes the ability increases or feat chosen at an ASI level
+func UndoAbilityScoreImprovement(char *models.Character, level int) error {
+	if !hasAbilityScoreImprovement(char, level) {
+		return fmt.Errorf("no ability score improvement recorded at level %d", level)
+	}
+	if record := char.GetLevelRecord(level); record != nil {
+		record.AbilityIncrease = nil
+		record.Feat, record.FeatAbility = "", ""
+	}
+	source := AbilityScoreImprovementSource(level)
+	return models.NewBenefitRemover(char).RemoveAllBenefits(source.Type, source.Name)
+}
+
+// hasAbilityScoreImprovement checks whether a choice is recorded for the ASI at a level
+func hasAbilityScoreImprovement(char *models.Character, level int) bool {
+	if char.BenefitTracker == nil {
+		return false
+	}
+	source := AbilityScoreImprovementSource(level)
+	return len(char.BenefitTracker.GetBenefitsBySource(source.Type, source.Name)) > 0
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
 )
 
 // LevelInfo contains all information about what's gained at a specific level
@@ -52,6 +54,7 @@ func LoadLevelingGuide() (*LevelingGuide, error) {
 
 	var guide LevelingGuide
 	if err := json.Unmarshal(file, &guide); err != nil {
+		diagnostics.ReportJSONError("data/leveling_guide.json", file, err)
 		return nil, fmt.Errorf("failed to parse leveling guide: %w", err)
 	}
 
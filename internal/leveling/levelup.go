@@ -2,27 +2,43 @@
 package leveling
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/marcozingoni/lazydndplayer/internal/models"
 )
 
 // LevelUpOptions represents choices during level up
 type LevelUpOptions struct {
-	HPIncrease       int                   // HP gained this level
-	AbilityIncrease  map[models.AbilityType]int // Ability score improvements
-	NewSpellSlots    models.SpellSlots     // New spell slots gained
-	ClassFeatures    []string              // New class features gained
+	Class           string                     // Class gaining the level; empty for the starting class, a new class multiclasses
+	Skills          []string                   // Skill proficiencies chosen when multiclassing (Bard, Ranger, Rogue)
+	HPRoll          int                        // Hit die result; 0 takes the fixed value
+	AbilityIncrease map[models.AbilityType]int // Ability score improvements
+	Feat            string                     // General feat taken instead of the ability increase
+	FeatAbility     string                     // Ability chosen for the feat's increase
+	Subclass        string                     // Subclass chosen at the subclass_choice level
+	FeatureChoices  map[string]string          // Feature name -> chosen option (Fighting Style, Pact Boon)
+	BookSpells      []string                   // Spells added to the spellbook (Wizard)
 }
 
 // CalculateHPOptions returns options for HP increase
 func CalculateHPOptions(class string, conMod int) (rolled int, average int) {
 	hitDie := GetHitDie(class)
-	average = (hitDie / 2) + 1 + conMod
+	average = models.FixedHitPoints(hitDie) + conMod
 	// For actual rolled value, this would be done by the UI with dice roller
 	return hitDie + conMod, average
 }
 
-// GetHitDie returns the hit die for a class
+// GetHitDie returns the hit die for a class, preferring the class data file
 func GetHitDie(class string) int {
+	if classData := models.GetClassByName(class); classData != nil && classData.HitDie > 0 {
+		return classData.HitDie
+	}
+	return DefaultHitDie(class)
+}
+
+// DefaultHitDie returns the built-in hit die used when a class has no data file
+func DefaultHitDie(class string) int {
 	hitDice := map[string]int{
 		"Barbarian": 12,
 		"Fighter":   10,
@@ -44,166 +60,302 @@ func GetHitDie(class string) int {
 	return 8 // Default
 }
 
-// CanIncreaseAbilityScores checks if character can increase ability scores
-func CanIncreaseAbilityScores(newLevel int) bool {
-	// Every 4 levels (4, 8, 12, 16, 19 for fighters, 20 for rogues)
-	return newLevel%4 == 0
+// GetNextLevel returns the level_progression entry the character reaches on their next
+// level in a class (the starting class if className is empty). A class the character has
+// no levels in starts at level 1.
+func GetNextLevel(char *models.Character, className string) (*models.ClassLevel, error) {
+	if className == "" {
+		className = char.Class
+	}
+	class := models.GetClassByName(className)
+	if class == nil {
+		return nil, fmt.Errorf("class %s not found", className)
+	}
+	nextLevel := char.ClassLevel(className) + 1
+	entry := class.GetLevel(nextLevel)
+	if entry == nil {
+		return nil, fmt.Errorf("%s has no level %d in its progression", className, nextLevel)
+	}
+	return entry, nil
 }
 
-// GetSpellSlotsForLevel returns spell slots for a given class and level
+// GetSpellSlotsForLevel returns spell slots for a given class and level from its spellcasting_info
 func GetSpellSlotsForLevel(class string, level int) models.SpellSlots {
-	// Simplified spell slot progression for full casters
-	// This is for Wizard/Cleric/Druid/Bard/Sorcerer
-	fullCasterSlots := map[int]models.SpellSlots{
-		1: {Level1: models.SpellSlot{Maximum: 2, Current: 2}},
-		2: {Level1: models.SpellSlot{Maximum: 3, Current: 3}},
-		3: {
-			Level1: models.SpellSlot{Maximum: 4, Current: 4},
-			Level2: models.SpellSlot{Maximum: 2, Current: 2},
-		},
-		4: {
-			Level1: models.SpellSlot{Maximum: 4, Current: 4},
-			Level2: models.SpellSlot{Maximum: 3, Current: 3},
-		},
-		5: {
-			Level1: models.SpellSlot{Maximum: 4, Current: 4},
-			Level2: models.SpellSlot{Maximum: 3, Current: 3},
-			Level3: models.SpellSlot{Maximum: 2, Current: 2},
-		},
-		6: {
-			Level1: models.SpellSlot{Maximum: 4, Current: 4},
-			Level2: models.SpellSlot{Maximum: 3, Current: 3},
-			Level3: models.SpellSlot{Maximum: 3, Current: 3},
-		},
-		7: {
-			Level1: models.SpellSlot{Maximum: 4, Current: 4},
-			Level2: models.SpellSlot{Maximum: 3, Current: 3},
-			Level3: models.SpellSlot{Maximum: 3, Current: 3},
-			Level4: models.SpellSlot{Maximum: 1, Current: 1},
-		},
-		8: {
-			Level1: models.SpellSlot{Maximum: 4, Current: 4},
-			Level2: models.SpellSlot{Maximum: 3, Current: 3},
-			Level3: models.SpellSlot{Maximum: 3, Current: 3},
-			Level4: models.SpellSlot{Maximum: 2, Current: 2},
-		},
-		9: {
-			Level1: models.SpellSlot{Maximum: 4, Current: 4},
-			Level2: models.SpellSlot{Maximum: 3, Current: 3},
-			Level3: models.SpellSlot{Maximum: 3, Current: 3},
-			Level4: models.SpellSlot{Maximum: 3, Current: 3},
-			Level5: models.SpellSlot{Maximum: 1, Current: 1},
-		},
-		10: {
-			Level1: models.SpellSlot{Maximum: 4, Current: 4},
-			Level2: models.SpellSlot{Maximum: 3, Current: 3},
-			Level3: models.SpellSlot{Maximum: 3, Current: 3},
-			Level4: models.SpellSlot{Maximum: 3, Current: 3},
-			Level5: models.SpellSlot{Maximum: 2, Current: 2},
-		},
-		11: {
-			Level1: models.SpellSlot{Maximum: 4, Current: 4},
-			Level2: models.SpellSlot{Maximum: 3, Current: 3},
-			Level3: models.SpellSlot{Maximum: 3, Current: 3},
-			Level4: models.SpellSlot{Maximum: 3, Current: 3},
-			Level5: models.SpellSlot{Maximum: 2, Current: 2},
-			Level6: models.SpellSlot{Maximum: 1, Current: 1},
-		},
-		// Continue for levels 12-20...
-	}
-
-	// Check if class is a full caster
-	fullCasters := map[string]bool{
-		"Wizard":   true,
-		"Cleric":   true,
-		"Druid":    true,
-		"Bard":     true,
-		"Sorcerer": true,
-	}
-
-	if fullCasters[class] {
-		if slots, ok := fullCasterSlots[level]; ok {
-			return slots
-		}
-	}
-
-	// Return empty slots for non-casters or levels not defined
-	return models.SpellSlots{}
+	var book models.SpellBook
+	classData := models.GetClassByName(class)
+	if classData == nil {
+		return book.Slots
+	}
+	entry := classData.GetLevel(level)
+	if entry == nil || entry.SpellcastingInfo == nil {
+		return book.Slots
+	}
+
+	for slotLevel, count := range entry.SpellcastingInfo.Slots() {
+		if slot := book.GetSlotByLevel(slotLevel); slot != nil {
+			slot.Maximum = count
+			slot.Current = count
+		}
+	}
+	return book.Slots
 }
 
 // GetClassFeatures returns class features gained at a specific level
-func GetClassFeatures(class string, level int) []string {
-	// Simplified class features
-	features := map[string]map[int][]string{
-		"Fighter": {
-			1: {"Fighting Style", "Second Wind"},
-			2: {"Action Surge"},
-			3: {"Martial Archetype"},
-			5: {"Extra Attack"},
-			9: {"Indomitable"},
-		},
-		"Wizard": {
-			1: {"Spellcasting", "Arcane Recovery"},
-			2: {"Arcane Tradition"},
-			6: {"Arcane Tradition Feature"},
-			10: {"Arcane Tradition Feature"},
-		},
-		"Rogue": {
-			1: {"Expertise", "Sneak Attack", "Thieves' Cant"},
-			2: {"Cunning Action"},
-			3: {"Roguish Archetype"},
-			5: {"Uncanny Dodge"},
-			7: {"Evasion"},
-		},
-	}
-
-	if classFeatures, ok := features[class]; ok {
-		if levelFeatures, ok := classFeatures[level]; ok {
-			return levelFeatures
-		}
-	}
-
-	return []string{}
+func GetClassFeatures(class string, level int) []models.ClassFeature {
+	classData := models.GetClassByName(class)
+	if classData == nil {
+		return []models.ClassFeature{}
+	}
+	if entry := classData.GetLevel(level); entry != nil {
+		return entry.Features
+	}
+	return []models.ClassFeature{}
 }
 
-// PerformLevelUp applies level up changes to character
-func PerformLevelUp(char *models.Character, options LevelUpOptions) {
-	// Increase level
+// PerformLevelUp applies the next level of a class (options.Class, or the starting class).
+// A class the character has no levels in is a multiclass: its ability prerequisites must
+// be met and only its restricted proficiencies are granted. Every change is recorded in the
+// BenefitTracker under the class level's source (class:Wizard:5), except the Ability Score
+// Improvement or feat, which use the level's asi source, and the subclass grants. The
+// choices are kept in the level log so LevelDown and Respec can roll the level back.
+// Every choice is checked before anything changes, so a refused level up leaves the
+// character as it was.
+func PerformLevelUp(char *models.Character, options LevelUpOptions) error {
+	className := options.Class
+	if className == "" {
+		className = char.Class
+	}
+	models.EnsureClassLevels(char)
+	multiclassing := char.GetClassLevels(className) == nil
+	if multiclassing {
+		if err := models.CheckMulticlassPrerequisites(char, className); err != nil {
+			return err
+		}
+	}
+
+	entry, err := GetNextLevel(char, className)
+	if err != nil {
+		return err
+	}
+
+	// Validate the Ability Score Improvement before changing anything
+	if len(options.AbilityIncrease) > 0 {
+		if !entry.AbilityScoreImprovement {
+			return fmt.Errorf("%s level %d has no ability score improvement", className, entry.Level)
+		}
+		if err := ValidateAbilityIncreases(char.AbilityScores, options.AbilityIncrease); err != nil {
+			return err
+		}
+	}
+	var feat *models.Feat
+	if options.Feat != "" {
+		if !entry.AbilityScoreImprovement {
+			return fmt.Errorf("%s level %d has no ability score improvement", className, entry.Level)
+		}
+		if feat = models.GetFeatByName(options.Feat); feat == nil {
+			return fmt.Errorf("feat %s not found", options.Feat)
+		}
+		if err := CheckFeatForASI(*feat, char.Level+1); err != nil {
+			return err
+		}
+		if unmet := models.UnmetFeatPrerequisites(char, *feat, char.Level+1); len(unmet) > 0 {
+			return fmt.Errorf("can't take the feat %s: missing %s", feat.Name, strings.Join(unmet, ", "))
+		}
+		if models.HasFeat(char, feat.Name) && !feat.Repeatable {
+			return fmt.Errorf("can't take the feat %s again", feat.Name)
+		}
+		if options.FeatAbility != "" && models.HasAbilityChoice(*feat) {
+			allowed := false
+			for _, choice := range models.GetAbilityChoices(*feat) {
+				allowed = allowed || strings.EqualFold(choice, options.FeatAbility)
+			}
+			if !allowed {
+				return fmt.Errorf("the feat %s can't increase %s", feat.Name, options.FeatAbility)
+			}
+		}
+	}
+
+	// Validate the subclass: one of the class's catalog subclasses, or any name for a
+	// class without any in the catalog
+	if options.Subclass != "" {
+		if chosen := char.GetClassLevels(className); chosen != nil && chosen.Subclass != "" {
+			return fmt.Errorf("%s already has the subclass %s", className, chosen.Subclass)
+		}
+		if models.GetSubclass(className, options.Subclass) == nil && len(models.GetSubclassesForClass(className)) > 0 {
+			return fmt.Errorf("subclass %s not found for %s", options.Subclass, className)
+		}
+	}
+
+	bookSpells, err := models.CheckBookSpells(char, className, entry.Level, options.BookSpells)
+	if err != nil {
+		return err
+	}
+
+	hpEntry, err := models.NewHPEntry(char.Level+1, className, GetHitDie(className), options.HPRoll)
+	if err != nil {
+		return err
+	}
+
+	source := models.LevelSource(className, entry.Level)
+	applier := models.NewBenefitApplier(char)
+
+	// Backfill the HP history of earlier levels for older saves
+	models.EnsureHPHistory(char)
+
+	// Increase the character level and the class level; the proficiency
+	// bonus follows the total level (UpdateDerivedStats)
 	char.Level++
+	if multiclassing {
+		char.Classes = append(char.Classes, models.ClassLevels{Class: className, Level: entry.Level})
+		models.ApplyMulticlassProficiencies(char, models.GetClassByName(className), options.Skills)
+	} else {
+		char.GetClassLevels(className).Level = entry.Level
+	}
+
+	// Recalculate species HP bonus (e.g., Dwarven Toughness grows with level) before the
+	// level's hit points, so the full heal includes the Dwarf's extra hit point
+	if species := models.GetSpeciesByName(char.Race); species != nil {
+		models.ApplySpeciesHPBonus(char, species)
+	}
+
+	// Record the level's hit points (fully heals on level up)
+	applier.AddLevelHP(source, hpEntry)
+
+	// Record the choices, then apply the Ability Score Improvement or feat under the asi source
+	char.SetLevelRecord(recordFromOptions(char.Level, className, options))
+	if len(options.AbilityIncrease) > 0 {
+		ApplyAbilityScoreImprovement(char, char.Level, options.AbilityIncrease)
+	} else if feat != nil {
+		if err := models.AddFeatToCharacter(char, feat.Name); err != nil {
+			return err
+		}
+		if err := models.ApplyFeatBenefits(char, *feat, options.FeatAbility); err != nil {
+			return err
+		}
+		RecordFeatForASI(char, char.Level, feat.Name, options.FeatAbility)
+	}
+
+	// The subclass already chosen in this class replaces its generic subclass features
+	var subclass *models.Subclass
+	if chosen := char.GetClassLevels(className).Subclass; chosen != "" {
+		subclass = models.GetSubclass(className, chosen)
+	}
 
-	// Increase HP
-	char.MaxHP += options.HPIncrease
-	char.CurrentHP = char.MaxHP // Fully heal on level up
+	// Grant features; choices are recorded in the feature name
+	for _, feature := range entry.Features {
+		if entry.AbilityScoreImprovement && (feature.Name == "Ability Score Improvement" || feature.Name == "Epic Boon") {
+			continue // Applied through AbilityIncrease or a feat
+		}
+		if feature.SubclassFeature && subclass != nil && subclass.HasSubclassFeatures(entry.Level) {
+			continue // Granted by ApplySubclassLevel below
+		}
 
-	// Apply ability score increases
-	for ability, increase := range options.AbilityIncrease {
-		currentScore := char.AbilityScores.GetScore(ability)
-		char.AbilityScores.SetScore(ability, currentScore+increase)
+		def := feature.ToFeatureDefinition()
+		if feature.SubclassChoice && options.Subclass != "" {
+			def.Name = fmt.Sprintf("%s: %s", feature.Name, options.Subclass)
+		} else if choice := options.FeatureChoices[feature.Name]; choice != "" {
+			def.Name = fmt.Sprintf("%s: %s", feature.Name, choice)
+		}
+		applier.AddFeature(source, def)
 	}
 
-	// Update spell slots
-	char.SpellBook.Slots = options.NewSpellSlots
+	// Grant the subclass: everything up to this level when chosen now, else this level's grants
+	if options.Subclass != "" && subclass == nil {
+		if err := models.ChooseSubclass(char, className, options.Subclass); err != nil {
+			return err
+		}
+	} else if subclass != nil {
+		models.ApplySubclassLevel(char, subclass, entry.Level)
+	}
+
+	if entry.SpellcastingInfo != nil || char.IsMulticlassed() {
+		syncSpellSlots(char)
+		syncSpellsKnown(char)
+	}
+	for _, spell := range bookSpells {
+		char.SpellBook.AddToBook(spell)
+	}
+
+	// A first spellcasting class gained by multiclassing sets the spellcasting ability
+	if class := models.GetClassByName(className); class != nil && class.Spellcasting != nil && char.SpellBook.SpellcastingMod == "" {
+		if ability, ok := models.ParseAbilityType(class.Spellcasting.Ability); ok {
+			char.SpellBook.SpellcastingMod = ability
+		}
+	}
+
+	// Grow class resource pools
+	models.SyncClassResources(char)
+
+	// Species and feat spells unlocked at the new level (Drow Magic at 3 and 5)
+	models.SyncSpellGrants(char)
 
 	// Update derived stats
 	char.UpdateDerivedStats()
+	return nil
+}
 
-	// Recalculate species HP bonus (e.g., Dwarven Toughness grows with level)
-	// This adds +1 HP for Dwarves at each level
-	species := models.GetSpeciesByName(char.Race)
-	if species != nil {
-		models.ApplySpeciesHPBonus(char, species)
+// syncSpellSlots recomputes spell slots from the class levels, keeping slots already spent.
+// Slots are recomputed for all classes, so levels no longer granted drop to 0. Pact Magic
+// slots are their own pool, moving up to their new slot level.
+func syncSpellSlots(char *models.Character) {
+	slots := models.CalculateSpellSlots(char)
+	for slotLevel := 1; slotLevel <= 9; slotLevel++ {
+		slot := char.SpellBook.GetSlotByLevel(slotLevel)
+		slot.Current = max(slot.Current+slots[slotLevel]-slot.Maximum, 0)
+		slot.Maximum = slots[slotLevel]
 	}
+
+	pact := &char.SpellBook.PactSlots
+	count, level := models.CalculatePactSlots(char)
+	pact.Current = max(pact.Current+count-pact.Maximum, 0)
+	pact.Maximum = count
+	pact.Level = level
 }
 
-// ValidateAbilityIncreases ensures ability increases are valid
-func ValidateAbilityIncreases(increases map[models.AbilityType]int) bool {
-	total := 0
-	for _, increase := range increases {
-		total += increase
-		if increase < 0 {
-			return false
+// syncSpellsKnown adds up the cantrips and spells known of every spellcasting class at
+// the character's level in it
+func syncSpellsKnown(char *models.Character) {
+	cantrips, spells := 0, 0
+	for _, cl := range char.GetClasses() {
+		class := models.GetClassByName(cl.Class)
+		if class == nil {
+			continue
+		}
+		if entry := class.GetLevel(cl.Level); entry != nil && entry.SpellcastingInfo != nil {
+			cantrips += entry.SpellcastingInfo.CantripsKnown
+			spells += entry.SpellcastingInfo.SpellsKnown
+		}
+	}
+	char.SpellBook.CantripsKnown = cantrips
+	char.SpellBook.SpellsKnown = spells
+}
+
+// DescribeLevel summarizes what a level_progression entry grants, one line per item
+func DescribeLevel(entry *models.ClassLevel) []string {
+	var lines []string
+	for _, feature := range entry.Features {
+		line := feature.Name
+		if feature.Uses != nil && feature.Uses.Max != "" {
+			line += fmt.Sprintf(" (%s/%s)", feature.Uses.Max, strings.ReplaceAll(feature.Uses.Recharge, "_", " "))
+		}
+		lines = append(lines, line)
+	}
+	for _, res := range entry.Resources {
+		lines = append(lines, fmt.Sprintf("%s pool (max %s)", res.Name, res.Max))
+	}
+	if entry.SpellcastingInfo != nil {
+		var slots []string
+		for level := 1; level <= 9; level++ {
+			if count := entry.SpellcastingInfo.Slots()[level]; count > 0 {
+				slots = append(slots, fmt.Sprintf("L%d×%d", level, count))
+			}
+		}
+		if len(slots) > 0 {
+			lines = append(lines, "Spell slots: "+strings.Join(slots, " "))
+		}
+		if entry.SpellcastingInfo.CantripsKnown > 0 {
+			lines = append(lines, fmt.Sprintf("Cantrips known: %d", entry.SpellcastingInfo.CantripsKnown))
 		}
 	}
-	// Standard ASI gives 2 points total
-	return total == 2
+	return lines
 }
@@ -0,0 +1,198 @@
+// internal/leveling/respec.go
+package leveling
+
+import (
+	"fmt"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// recordFromOptions converts level-up choices to the record kept in the level log
+func recordFromOptions(level int, className string, options LevelUpOptions) models.LevelRecord {
+	record := models.LevelRecord{
+		Level:          level,
+		Class:          className,
+		HPRoll:         options.HPRoll,
+		Feat:           options.Feat,
+		FeatAbility:    options.FeatAbility,
+		Subclass:       options.Subclass,
+		FeatureChoices: options.FeatureChoices,
+		Skills:         options.Skills,
+		BookSpells:     options.BookSpells,
+	}
+	for ability, increase := range options.AbilityIncrease {
+		if increase > 0 {
+			if record.AbilityIncrease == nil {
+				record.AbilityIncrease = map[string]int{}
+			}
+			record.AbilityIncrease[ability.FullName()] = increase
+		}
+	}
+	if len(record.FeatureChoices) == 0 {
+		record.FeatureChoices = nil
+	}
+	return record
+}
+
+// RecordForLevel returns the record of the choices made at a character level.
+// Levels gained before the level log existed are rebuilt from the HP history and the ASI.
+func RecordForLevel(char *models.Character, level int) models.LevelRecord {
+	if record := char.GetLevelRecord(level); record != nil {
+		return *record
+	}
+	className, _ := char.ClassAtLevel(level)
+	record := models.LevelRecord{Level: level, Class: className}
+	if entry := char.GetHPEntry(level); entry != nil && entry.Method == models.HPMethodRolled {
+		record.HPRoll = entry.Result
+	}
+	if char.BenefitTracker != nil {
+		source := AbilityScoreImprovementSource(level)
+		for _, benefit := range char.BenefitTracker.GetBenefitsBySource(source.Type, source.Name) {
+			switch benefit.Type {
+			case models.BenefitAbilityScore:
+				if record.AbilityIncrease == nil {
+					record.AbilityIncrease = map[string]int{}
+				}
+				record.AbilityIncrease[benefit.Target] += benefit.Value
+			case models.BenefitFeat:
+				record.Feat = benefit.Target
+			}
+		}
+	}
+	return record
+}
+
+// OptionsForLevel returns the choices made at a character level as level-up options
+func OptionsForLevel(char *models.Character, level int) LevelUpOptions {
+	record := RecordForLevel(char, level)
+
+	options := LevelUpOptions{
+		Class:           record.Class,
+		Skills:          record.Skills,
+		HPRoll:          record.HPRoll,
+		AbilityIncrease: map[models.AbilityType]int{},
+		Feat:            record.Feat,
+		FeatAbility:     record.FeatAbility,
+		Subclass:        record.Subclass,
+		FeatureChoices:  map[string]string{},
+		BookSpells:      record.BookSpells,
+	}
+	for name, increase := range record.AbilityIncrease {
+		if ability, ok := models.ParseAbilityType(name); ok {
+			options.AbilityIncrease[ability] = increase
+		}
+	}
+	for name, choice := range record.FeatureChoices {
+		options.FeatureChoices[name] = choice
+	}
+	return options
+}
+
+// LevelDown removes the character's last level: its hit points, features and
+// proficiencies (the class:Wizard:5 source), its Ability Score Improvement or feat,
+// the subclass grants of that class level, the spells it added to the spellbook, the
+// species and feat spells unlocked at that level and the spell slots it added.
+// Levels gained before per-level sources only lose their hit points and slots.
+func LevelDown(char *models.Character) error {
+	if char.Level <= 1 {
+		return fmt.Errorf("level 1 can't be removed")
+	}
+	models.EnsureClassLevels(char)
+	models.EnsureHPHistory(char)
+
+	level := char.Level
+	className, classLevel := char.ClassAtLevel(level)
+	classLevels := char.GetClassLevels(className)
+	if classLevels == nil {
+		return fmt.Errorf("no levels in %s", className)
+	}
+	wasMulticlassed := char.IsMulticlassed()
+	remover := models.NewBenefitRemover(char)
+
+	if hasAbilityScoreImprovement(char, level) {
+		if err := UndoAbilityScoreImprovement(char, level); err != nil {
+			return err
+		}
+	}
+	source := models.LevelSource(className, classLevel)
+	if err := remover.RemoveAllBenefits(source.Type, source.Name); err != nil {
+		return err
+	}
+	char.RemoveHPEntry(level)
+	if record := char.GetLevelRecord(level); record != nil {
+		for _, name := range record.BookSpells {
+			char.SpellBook.RemoveFromBook(name)
+		}
+	}
+	char.RemoveLevelRecord(level)
+
+	// Subclass grants are replayed up to the remaining class level; removing the
+	// subclass choice level removes the subclass
+	class := models.GetClassByName(className)
+	if subclassName := classLevels.Subclass; subclassName != "" {
+		remover.RemoveAllBenefits(models.SubclassSourceType, subclassName)
+		if class != nil && classLevel <= models.SubclassChoiceLevel(class) {
+			classLevels.Subclass = ""
+		} else if subclass := models.GetSubclass(className, subclassName); subclass != nil {
+			for lvl := 1; lvl < classLevel; lvl++ {
+				models.ApplySubclassLevel(char, subclass, lvl)
+			}
+		}
+	}
+
+	char.Level--
+	classLevels.Level = classLevel - 1
+	if classLevels.Level == 0 {
+		var kept []models.ClassLevels
+		for _, cl := range char.Classes {
+			if cl.Class != className {
+				kept = append(kept, cl)
+			}
+		}
+		char.Classes = kept
+	}
+
+	// Spellcasting of the remaining levels
+	if (class != nil && class.Spellcasting != nil) || wasMulticlassed {
+		syncSpellSlots(char)
+		syncSpellsKnown(char)
+	}
+
+	models.SyncClassResources(char)
+	models.SyncSpellGrants(char)
+	char.UpdateDerivedStats()
+	if species := models.GetSpeciesByName(char.Race); species != nil {
+		models.ApplySpeciesHPBonus(char, species)
+	}
+	return nil
+}
+
+// Respec rolls the character back to just before a level and returns the choices of
+// that level and every later one, in order, so they can be changed and replayed
+func Respec(char *models.Character, fromLevel int) ([]LevelUpOptions, error) {
+	if fromLevel < 2 || fromLevel > char.Level {
+		return nil, fmt.Errorf("respec from a level between 2 and %d", char.Level)
+	}
+
+	var replay []LevelUpOptions
+	for level := fromLevel; level <= char.Level; level++ {
+		replay = append(replay, OptionsForLevel(char, level))
+	}
+	for char.Level >= fromLevel {
+		if err := LevelDown(char); err != nil {
+			return nil, err
+		}
+	}
+	return replay, nil
+}
+
+// ReplayLevelUps performs recorded level ups in order, stopping at the first one that
+// no longer applies (e.g. an ASI that would now exceed 20)
+func ReplayLevelUps(char *models.Character, replay []LevelUpOptions) error {
+	for _, options := range replay {
+		if err := PerformLevelUp(char, options); err != nil {
+			return fmt.Errorf("level %d (%s): %w", char.Level+1, options.Class, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// internal/models/acformulas.go
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ACFormula is an alternative base AC used while not wearing armor, declared by a class
+// or subclass feature, a species trait or a spell:
+//
+//	"ac_formula": {"base": 10, "abilities": ["Dexterity", "Wisdom"], "no_shield": true}
+type ACFormula struct {
+	Base      int      `json:"base"`
+	Abilities []string `json:"abilities,omitempty"` // Ability modifiers added to the base
+	NoShield  bool     `json:"no_shield,omitempty"` // Lost while a shield is equipped (Monk)
+}
+
+// Value returns the AC the formula gives the character
+func (f ACFormula) Value(char *Character) int {
+	ac := f.Base
+	for _, name := range f.Abilities {
+		ac += char.AbilityScores.GetModifier(name)
+	}
+	return ac
+}
+
+// Describe shows how the formula adds up, e.g. "10 + Dex +2 + Wis +3"
+func (f ACFormula) Describe(char *Character) string {
+	parts := []string{fmt.Sprint(f.Base)}
+	for _, name := range f.Abilities {
+		label := name
+		if ability, ok := ParseAbilityType(name); ok {
+			label = ability.FullName()[:3]
+		}
+		parts = append(parts, fmt.Sprintf("%s %+d", label, char.AbilityScores.GetModifier(name)))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// SourcedACFormula is an AC formula with the feature, trait or spell granting it
+type SourcedACFormula struct {
+	ACFormula
+	Source string // e.g. "Barbarian: Unarmored Defense"
+}
+
+// CharacterACFormulas collects the AC formulas of the character's class and subclass
+// features, species traits and active effects (e.g. Mage Armor)
+func CharacterACFormulas(char *Character) []SourcedACFormula {
+	var formulas []SourcedACFormula
+	add := func(formula *ACFormula, source string) {
+		if formula != nil {
+			formulas = append(formulas, SourcedACFormula{ACFormula: *formula, Source: source})
+		}
+	}
+
+	for _, cl := range char.GetClasses() {
+		if class := GetClassByName(cl.Class); class != nil {
+			for _, entry := range class.LevelProgression {
+				if entry.Level > cl.Level {
+					continue
+				}
+				for _, feature := range entry.Features {
+					add(feature.ACFormula, class.Name+": "+feature.Name)
+				}
+			}
+		}
+		if subclass := GetSubclass(cl.Class, cl.Subclass); subclass != nil {
+			for _, entry := range subclass.Levels {
+				if entry.Level > cl.Level {
+					continue
+				}
+				for _, feature := range entry.Features {
+					add(feature.ACFormula, subclass.Name+": "+feature.Name)
+				}
+			}
+		}
+	}
+
+	for _, trait := range char.SpeciesTraits {
+		add(trait.ACFormula, char.Race+": "+trait.Name)
+	}
+
+	for _, effect := range char.Effects {
+		source := effect.Source
+		if source == "" {
+			source = "Effect: " + effect.Name
+		}
+		add(effect.ACFormula, source)
+	}
+
+	return formulas
+}
+
+// SpellACFormula returns the AC formula of a spell, looked up in the spell catalog for
+// spells saved before formulas existed
+func SpellACFormula(spell *Spell) *ACFormula {
+	if spell.ACFormula != nil {
+		return spell.ACFormula
+	}
+	if catalog := GetSpellByName(spell.Name); catalog != nil {
+		return catalog.ACFormula
+	}
+	return nil
+}
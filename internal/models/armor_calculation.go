@@ -17,75 +17,10 @@ const (
 	ArmorTypeShield
 )
 
-// CalculateAC calculates the character's Armor Class based on equipped armor and shield
+// CalculateAC calculates the character's Armor Class based on equipped armor and shield,
+// plus AC bonuses from feats, items and effects
 func CalculateAC(char *Character) int {
-	dexMod := char.AbilityScores.GetModifier("Dexterity")
-
-	// Find equipped armor and shield
-	var equippedArmor *Item
-	var equippedShield *Item
-
-	for i := range char.Inventory.Items {
-		item := &char.Inventory.Items[i]
-		if !item.Equipped {
-			continue
-		}
-
-		// Check if it's armor or shield
-		if item.Type == Armor {
-			itemDef := GetItemDefinitionByName(item.Name)
-			if itemDef != nil {
-				if strings.ToLower(itemDef.Subcategory) == "shield" {
-					equippedShield = item
-				} else {
-					equippedArmor = item
-				}
-			}
-		}
-	}
-
-	// Calculate base AC
-	baseAC := 10
-
-	if equippedArmor != nil {
-		// Get armor definition to determine AC and type
-		armorDef := GetItemDefinitionByName(equippedArmor.Name)
-		if armorDef != nil {
-			armorType := getArmorType(armorDef.Subcategory)
-			armorBaseAC := parseArmorAC(armorDef.AC)
-
-			switch armorType {
-			case ArmorTypeLight:
-				// Light armor: Base AC + full Dex modifier
-				baseAC = armorBaseAC + dexMod
-
-			case ArmorTypeMedium:
-				// Medium armor: Base AC + Dex modifier (max +2)
-				dexBonus := dexMod
-				if dexBonus > 2 {
-					dexBonus = 2
-				}
-				baseAC = armorBaseAC + dexBonus
-
-			case ArmorTypeHeavy:
-				// Heavy armor: Base AC only (no Dex modifier)
-				baseAC = armorBaseAC
-			}
-		}
-	} else {
-		// Unarmored: 10 + Dex modifier
-		baseAC = 10 + dexMod
-	}
-
-	// Add shield bonus (+2)
-	if equippedShield != nil {
-		baseAC += 2
-	}
-
-	// Add any AC bonuses from feats/magic items
-	baseAC += char.ACBonus
-
-	return baseAC
+	return char.Breakdown(TargetAC).Total
 }
 
 // getArmorType determines the armor type from subcategory
@@ -17,44 +17,62 @@ func NewBenefitApplier(char *Character) *BenefitApplier {
 	if char.BenefitTracker == nil {
 		char.BenefitTracker = NewBenefitTracker()
 	}
+	EnsureBaseSpeed(char)
 	return &BenefitApplier{char: char}
 }
 
-// AddAbilityScore increases an ability score and tracks it
+// AbilityScoreMax is the highest score ability increases can normally reach
+const AbilityScoreMax = 20
+
+// EpicAbilityScoreMax is the highest score any source can raise an ability to (Epic Boons)
+const EpicAbilityScoreMax = 30
+
+// AddAbilityScore increases an ability score (up to 20) and tracks it
 func (ba *BenefitApplier) AddAbilityScore(source BenefitSource, ability string, increase int) error {
+	return ba.AddAbilityScoreUpTo(source, ability, increase, AbilityScoreMax)
+}
+
+// AddAbilityScoreUpTo increases an ability score without raising it above the source's
+// maximum and tracks the points actually gained, so removing the benefit restores the
+// previous score. A score already above the maximum is left as it is.
+func (ba *BenefitApplier) AddAbilityScoreUpTo(source BenefitSource, ability string, increase, maxScore int) error {
 	abilityLower := strings.ToLower(ability)
 
-	// Apply the increase
+	var score *int
 	switch {
 	case strings.Contains(abilityLower, "strength"):
-		ba.char.AbilityScores.Strength = min(ba.char.AbilityScores.Strength+increase, 20)
+		score = &ba.char.AbilityScores.Strength
 		ability = "Strength"
 	case strings.Contains(abilityLower, "dexterity"):
-		ba.char.AbilityScores.Dexterity = min(ba.char.AbilityScores.Dexterity+increase, 20)
+		score = &ba.char.AbilityScores.Dexterity
 		ability = "Dexterity"
 	case strings.Contains(abilityLower, "constitution"):
-		ba.char.AbilityScores.Constitution = min(ba.char.AbilityScores.Constitution+increase, 20)
+		score = &ba.char.AbilityScores.Constitution
 		ability = "Constitution"
 	case strings.Contains(abilityLower, "intelligence"):
-		ba.char.AbilityScores.Intelligence = min(ba.char.AbilityScores.Intelligence+increase, 20)
+		score = &ba.char.AbilityScores.Intelligence
 		ability = "Intelligence"
 	case strings.Contains(abilityLower, "wisdom"):
-		ba.char.AbilityScores.Wisdom = min(ba.char.AbilityScores.Wisdom+increase, 20)
+		score = &ba.char.AbilityScores.Wisdom
 		ability = "Wisdom"
 	case strings.Contains(abilityLower, "charisma"):
-		ba.char.AbilityScores.Charisma = min(ba.char.AbilityScores.Charisma+increase, 20)
+		score = &ba.char.AbilityScores.Charisma
 		ability = "Charisma"
 	default:
 		return fmt.Errorf("unknown ability: %s", ability)
 	}
 
+	// Apply the increase
+	gained := min(increase, max(min(maxScore, EpicAbilityScoreMax)-*score, 0))
+	*score += gained
+
 	// Track the benefit
 	ba.char.BenefitTracker.AddBenefit(GrantedBenefit{
 		Source:      source,
 		Type:        BenefitAbilityScore,
 		Target:      ability,
-		Value:       increase,
-		Description: fmt.Sprintf("+%d %s", increase, ability),
+		Value:       gained,
+		Description: fmt.Sprintf("+%d %s", gained, ability),
 	})
 
 	return nil
@@ -149,10 +167,8 @@ func (ba *BenefitApplier) AddResistance(source BenefitSource, damageType string)
 	return nil
 }
 
-// AddSpeed increases speed and tracks it
+// AddSpeed tracks a speed increase; the speed is evaluated by UpdateDerivedStats
 func (ba *BenefitApplier) AddSpeed(source BenefitSource, increase int) error {
-	ba.char.Speed += increase
-
 	// Track the benefit
 	ba.char.BenefitTracker.AddBenefit(GrantedBenefit{
 		Source:      source,
@@ -182,20 +198,74 @@ func (ba *BenefitApplier) AddHP(source BenefitSource, increase int) error {
 	return nil
 }
 
+// AddLevelHP records the hit points gained on a level up in the HP history and tracks them.
+// Max HP is recomputed from the history, so CalculateMaxHP doesn't add them again.
+func (ba *BenefitApplier) AddLevelHP(source BenefitSource, entry HPEntry) error {
+	ba.char.SetHPEntry(entry)
+	ba.char.RecalculateMaxHP()
+	ba.char.CurrentHP = ba.char.MaxHP
+
+	// Track the benefit
+	ba.char.BenefitTracker.AddBenefit(GrantedBenefit{
+		Source:      source,
+		Type:        BenefitLevelHP,
+		Target:      fmt.Sprintf("Level %d", entry.Level),
+		Value:       entry.Result,
+		Description: entry.String(),
+	})
+
+	return nil
+}
+
+// AddFeat tracks a feat taken through another source, such as an Ability Score
+// Improvement. The feat itself is added with AddFeatToCharacter and ApplyFeatBenefits;
+// removing the source removes the feat too.
+func (ba *BenefitApplier) AddFeat(source BenefitSource, featName string) error {
+	ba.char.BenefitTracker.AddBenefit(GrantedBenefit{
+		Source:      source,
+		Type:        BenefitFeat,
+		Target:      featName,
+		Description: fmt.Sprintf("Feat: %s", featName),
+	})
+
+	return nil
+}
+
 // AddSpell adds a spell and tracks it
 func (ba *BenefitApplier) AddSpell(source BenefitSource, spellName string) error {
+	return ba.AddSpellGrant(source, SpellGrant{SpellName: spellName})
+}
+
+// AddSpellGrant adds a catalog spell (and its free casts feature) and tracks it
+func (ba *BenefitApplier) AddSpellGrant(source BenefitSource, grant SpellGrant) error {
+	spell, ok := ResolveSpellGrant(grant)
+	if !ok {
+		return fmt.Errorf("spell not found in catalog: %s", grant.SpellName)
+	}
+	if hasSpell(ba.char, spell.Name) {
+		return nil
+	}
+
+	ba.char.SpellBook.AddSpell(spell)
+
 	// Add to species spells tracking
-	ba.char.SpeciesSpells = append(ba.char.SpeciesSpells, spellName)
+	if source.Type == "species" {
+		ba.char.SpeciesSpells = append(ba.char.SpeciesSpells, spell.Name)
+	}
 
 	// Track the benefit
 	ba.char.BenefitTracker.AddBenefit(GrantedBenefit{
 		Source:      source,
 		Type:        BenefitSpell,
-		Target:      spellName,
+		Target:      spell.Name,
 		Value:       1,
-		Description: fmt.Sprintf("%s spell", spellName),
+		Description: fmt.Sprintf("%s spell", spell.Name),
 	})
 
+	if grant.FreeCasts > 0 {
+		ba.AddFeature(source, grant.FreeCastFeature())
+	}
+
 	return nil
 }
 
@@ -219,10 +289,8 @@ func (ba *BenefitApplier) AddFeature(source BenefitSource, featureDef FeatureDef
 	return nil
 }
 
-// AddInitiative adds an initiative bonus and tracks it
+// AddInitiative tracks an initiative bonus; initiative is evaluated by UpdateDerivedStats
 func (ba *BenefitApplier) AddInitiative(source BenefitSource, bonus int) error {
-	ba.char.InitiativeBonus += bonus
-
 	ba.char.BenefitTracker.AddBenefit(GrantedBenefit{
 		Source:      source,
 		Type:        BenefitInitiative,
@@ -234,10 +302,8 @@ func (ba *BenefitApplier) AddInitiative(source BenefitSource, bonus int) error {
 	return nil
 }
 
-// AddACBonus adds an AC bonus and tracks it
+// AddACBonus tracks an AC bonus; AC is evaluated by UpdateDerivedStats
 func (ba *BenefitApplier) AddACBonus(source BenefitSource, bonus int) error {
-	ba.char.ACBonus += bonus
-
 	ba.char.BenefitTracker.AddBenefit(GrantedBenefit{
 		Source:      source,
 		Type:        BenefitAC,
@@ -249,17 +315,9 @@ func (ba *BenefitApplier) AddACBonus(source BenefitSource, bonus int) error {
 	return nil
 }
 
-// AddPassiveBonus adds a bonus to passive skills and tracks it
+// AddPassiveBonus tracks a bonus to a passive skill; passive scores are evaluated by
+// UpdateDerivedStats
 func (ba *BenefitApplier) AddPassiveBonus(source BenefitSource, skillName string, bonus int) error {
-	switch skillName {
-	case "Perception":
-		ba.char.PassivePerceptionBonus += bonus
-	case "Investigation":
-		ba.char.PassiveInvestigationBonus += bonus
-	case "Insight":
-		ba.char.PassiveInsightBonus += bonus
-	}
-
 	ba.char.BenefitTracker.AddBenefit(GrantedBenefit{
 		Source:      source,
 		Type:        BenefitPassive,
@@ -271,6 +329,46 @@ func (ba *BenefitApplier) AddPassiveBonus(source BenefitSource, skillName string
 	return nil
 }
 
+// AddArmorProficiency adds an armor proficiency and tracks it
+func (ba *BenefitApplier) AddArmorProficiency(source BenefitSource, armor string) error {
+	for _, existing := range ba.char.ArmorProficiencies {
+		if strings.EqualFold(existing, armor) {
+			return nil
+		}
+	}
+	ba.char.ArmorProficiencies = append(ba.char.ArmorProficiencies, armor)
+
+	ba.char.BenefitTracker.AddBenefit(GrantedBenefit{
+		Source:      source,
+		Type:        BenefitArmor,
+		Target:      armor,
+		Value:       1,
+		Description: fmt.Sprintf("Armor proficiency: %s", armor),
+	})
+
+	return nil
+}
+
+// AddWeaponProficiency adds a weapon proficiency and tracks it
+func (ba *BenefitApplier) AddWeaponProficiency(source BenefitSource, weapon string) error {
+	for _, existing := range ba.char.WeaponProficiencies {
+		if strings.EqualFold(existing, weapon) {
+			return nil
+		}
+	}
+	ba.char.WeaponProficiencies = append(ba.char.WeaponProficiencies, weapon)
+
+	ba.char.BenefitTracker.AddBenefit(GrantedBenefit{
+		Source:      source,
+		Type:        BenefitWeapon,
+		Target:      weapon,
+		Value:       1,
+		Description: fmt.Sprintf("Weapon proficiency: %s", weapon),
+	})
+
+	return nil
+}
+
 // AddToolProficiency adds a tool proficiency and tracks it
 func (ba *BenefitApplier) AddToolProficiency(source BenefitSource, toolName string) error {
 	// Check if already proficient
@@ -304,7 +402,7 @@ func (ba *BenefitApplier) AddItem(source BenefitSource, itemName string, quantit
 		amount := 0
 		fmt.Sscanf(itemName, "%d", &amount)
 		if amount > 0 {
-			ba.char.Inventory.Gold += amount
+			ba.char.Inventory.Receive(Coins{Gold: amount}, "Starting gold ("+source.Label()+")")
 
 			// Track the benefit
 			ba.char.BenefitTracker.AddBenefit(GrantedBenefit{
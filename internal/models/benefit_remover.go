@@ -17,6 +17,7 @@ func NewBenefitRemover(char *Character) *BenefitRemover {
 	if char.BenefitTracker == nil {
 		char.BenefitTracker = NewBenefitTracker()
 	}
+	EnsureBaseSpeed(char)
 	return &BenefitRemover{char: char}
 }
 
@@ -35,31 +36,53 @@ func (br *BenefitRemover) RemoveAllBenefits(sourceType, sourceName string) error
 			br.removeLanguage(benefit)
 		case BenefitResistance:
 			br.removeResistance(benefit)
-		case BenefitSpeed:
-			br.removeSpeed(benefit)
 		case BenefitHP:
 			br.removeHP(benefit)
+		case BenefitLevelHP:
+			br.removeLevelHP(benefit)
+		case BenefitFeat:
+			br.removeFeat(benefit)
 		case BenefitSpell:
 			br.removeSpell(benefit)
 		case BenefitFeature:
 			br.removeFeature(benefit)
-		case BenefitInitiative:
-			br.removeInitiative(benefit)
-		case BenefitAC:
-			br.removeACBonus(benefit)
-		case BenefitPassive:
-			br.removePassiveBonus(benefit)
 		case BenefitTool:
 			br.removeToolProficiency(benefit)
+		case BenefitArmor:
+			br.char.ArmorProficiencies = removeProficiency(br.char.ArmorProficiencies, benefit.Target)
+		case BenefitWeapon:
+			br.char.WeaponProficiencies = removeProficiency(br.char.WeaponProficiencies, benefit.Target)
 		case BenefitItem:
 			br.removeItem(benefit)
 		}
 	}
 
+	// Speed, initiative, AC and passive bonuses are evaluated from the remaining benefits
 	br.char.UpdateDerivedStats()
 	return nil
 }
 
+// RemoveSpellGrant removes a spell granted by the source and the feature tracking its
+// free casts, leaving the source's other benefits
+func (br *BenefitRemover) RemoveSpellGrant(source BenefitSource, spellName string) {
+	kept := []GrantedBenefit{}
+	for _, benefit := range br.char.BenefitTracker.Benefits {
+		if benefit.Source != source || !strings.EqualFold(benefit.Target, spellName) {
+			kept = append(kept, benefit)
+			continue
+		}
+		switch benefit.Type {
+		case BenefitSpell:
+			br.removeSpell(benefit)
+		case BenefitFeature:
+			br.removeFeature(benefit)
+		default:
+			kept = append(kept, benefit)
+		}
+	}
+	br.char.BenefitTracker.Benefits = kept
+}
+
 func (br *BenefitRemover) removeAbilityScore(benefit GrantedBenefit) {
 	abilityLower := strings.ToLower(benefit.Target)
 
@@ -127,13 +150,6 @@ func (br *BenefitRemover) removeResistance(benefit GrantedBenefit) {
 	}
 }
 
-func (br *BenefitRemover) removeSpeed(benefit GrantedBenefit) {
-	br.char.Speed -= benefit.Value
-	if br.char.Speed < 0 {
-		br.char.Speed = 0
-	}
-}
-
 func (br *BenefitRemover) removeHP(benefit GrantedBenefit) {
 	br.char.MaxHP -= benefit.Value
 	if br.char.MaxHP < 1 {
@@ -144,6 +160,26 @@ func (br *BenefitRemover) removeHP(benefit GrantedBenefit) {
 	}
 }
 
+func (br *BenefitRemover) removeLevelHP(benefit GrantedBenefit) {
+	var level int
+	if _, err := fmt.Sscanf(benefit.Target, "Level %d", &level); err == nil {
+		br.char.RemoveHPEntry(level)
+	}
+	// Max HP is recomputed from the history by UpdateDerivedStats
+}
+
+func (br *BenefitRemover) removeFeat(benefit GrantedBenefit) {
+	for i, feat := range br.char.Feats {
+		if strings.EqualFold(feat, benefit.Target) {
+			br.char.Feats = append(br.char.Feats[:i], br.char.Feats[i+1:]...)
+			break
+		}
+	}
+	if feat := GetFeatByName(benefit.Target); feat != nil {
+		RemoveFeatBenefits(br.char, *feat)
+	}
+}
+
 func (br *BenefitRemover) removeSpell(benefit GrantedBenefit) {
 	// Remove from species spells tracking
 	for i, spell := range br.char.SpeciesSpells {
@@ -175,40 +211,6 @@ func (br *BenefitRemover) removeFeature(benefit GrantedBenefit) {
 	}
 }
 
-func (br *BenefitRemover) removeInitiative(benefit GrantedBenefit) {
-	br.char.InitiativeBonus -= benefit.Value
-	if br.char.InitiativeBonus < 0 {
-		br.char.InitiativeBonus = 0
-	}
-}
-
-func (br *BenefitRemover) removeACBonus(benefit GrantedBenefit) {
-	br.char.ACBonus -= benefit.Value
-	if br.char.ACBonus < 0 {
-		br.char.ACBonus = 0
-	}
-}
-
-func (br *BenefitRemover) removePassiveBonus(benefit GrantedBenefit) {
-	switch benefit.Target {
-	case "Perception":
-		br.char.PassivePerceptionBonus -= benefit.Value
-		if br.char.PassivePerceptionBonus < 0 {
-			br.char.PassivePerceptionBonus = 0
-		}
-	case "Investigation":
-		br.char.PassiveInvestigationBonus -= benefit.Value
-		if br.char.PassiveInvestigationBonus < 0 {
-			br.char.PassiveInvestigationBonus = 0
-		}
-	case "Insight":
-		br.char.PassiveInsightBonus -= benefit.Value
-		if br.char.PassiveInsightBonus < 0 {
-			br.char.PassiveInsightBonus = 0
-		}
-	}
-}
-
 func (br *BenefitRemover) removeToolProficiency(benefit GrantedBenefit) {
 	// Check if any other source also grants this tool proficiency
 	remainingSources := 0
@@ -229,14 +231,25 @@ func (br *BenefitRemover) removeToolProficiency(benefit GrantedBenefit) {
 	}
 }
 
+// removeProficiency removes an armor or weapon proficiency from a list
+func removeProficiency(list []string, name string) []string {
+	for i, existing := range list {
+		if strings.EqualFold(existing, name) {
+			return append(list[:i], list[i+1:]...)
+		}
+	}
+	return list
+}
+
 func (br *BenefitRemover) removeItem(benefit GrantedBenefit) {
 	itemName := benefit.Target
 
 	// Check if it's gold
 	if strings.Contains(strings.ToLower(itemName), " gp") || strings.Contains(strings.ToLower(itemName), "gold") {
-		br.char.Inventory.Gold -= benefit.Value
-		if br.char.Inventory.Gold < 0 {
-			br.char.Inventory.Gold = 0
+		// Gold already spent can't be taken back
+		amount := min(benefit.Value*CopperPerGold, br.char.Inventory.Coins.Value())
+		if amount > 0 {
+			br.char.Inventory.Pay(amount, "Starting gold removed ("+benefit.Source.Label()+")")
 		}
 		return
 	}
@@ -250,10 +263,7 @@ func (br *BenefitRemover) removeItem(benefit GrantedBenefit) {
 
 			// If quantity is 0 or less, remove the item entirely
 			if item.Quantity <= 0 {
-				br.char.Inventory.Items = append(
-					br.char.Inventory.Items[:i],
-					br.char.Inventory.Items[i+1:]...,
-				)
+				br.char.Inventory.RemoveAt(i)
 			}
 			break
 		}
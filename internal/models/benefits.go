@@ -1,6 +1,8 @@
 // internal/models/benefits.go
 package models
 
+import "strings"
+
 // BenefitType represents the type of benefit granted
 type BenefitType string
 
@@ -16,17 +18,35 @@ const (
 	BenefitFeature      BenefitType = "feature"
 	BenefitInitiative   BenefitType = "initiative"
 	BenefitAC           BenefitType = "ac"
-	BenefitPassive      BenefitType = "passive" // Passive Perception, Investigation, Insight
-	BenefitTool         BenefitType = "tool"    // Tool proficiencies
-	BenefitItem         BenefitType = "item"    // Inventory items
+	BenefitPassive      BenefitType = "passive"  // Passive Perception, Investigation, Insight
+	BenefitTool         BenefitType = "tool"     // Tool proficiencies
+	BenefitItem         BenefitType = "item"     // Inventory items
+	BenefitLevelHP      BenefitType = "level_hp" // HP gained from a class level's hit die
+	BenefitFeat         BenefitType = "feat"     // A feat taken in place of another benefit (e.g. an ASI)
+	BenefitArmor        BenefitType = "armor"    // Armor proficiencies
+	BenefitWeapon       BenefitType = "weapon"   // Weapon proficiencies
 )
 
 // BenefitSource represents where a benefit came from
 type BenefitSource struct {
-	Type string `json:"type"` // "feat", "species", "class", "background"
+	Type string `json:"type"` // "feat", "species", "class", "Subclass", "background"
 	Name string `json:"name"` // Name of the feat/species/etc
 }
 
+// Label describes the source for display, e.g. "Feat: Alert", "Wizard 5" or
+// "Ability Score Improvement (level 4)"
+func (s BenefitSource) Label() string {
+	switch s.Type {
+	case "":
+		return s.Name
+	case "class":
+		return strings.Replace(s.Name, ":", " ", 1)
+	case "asi":
+		return "Ability Score Improvement (" + strings.Replace(s.Name, "-", " ", 1) + ")"
+	}
+	return strings.ToUpper(s.Type[:1]) + s.Type[1:] + ": " + s.Name
+}
+
 // GrantedBenefit tracks a single benefit and its source
 type GrantedBenefit struct {
 	Source      BenefitSource `json:"source"`
@@ -7,25 +7,32 @@ type Character struct {
 	Name       string `json:"name"`
 	Race       string `json:"race"`
 	Subtype    string `json:"subtype,omitempty"` // For species with subtypes (Elf, Tiefling, Dragonborn)
-	Class      string `json:"class"`
+	Class      string `json:"class"`                  // Starting class
+	Classes    []ClassLevels `json:"classes,omitempty"` // Levels and subclass in each class (multiclassing)
 	Background string `json:"background"`
 	Origin     string `json:"origin"`     // Character origin (2024 rules)
 	Alignment  string `json:"alignment"`
 
 	// Level & Experience
-	Level      int `json:"level"`
+	Level      int `json:"level"` // Total character level across all classes
 	Experience int `json:"experience"`
+	XPLog      []XPAward `json:"xp_log,omitempty"` // Ledger of XP awards and milestone levels
+	MilestoneLeveling bool `json:"milestone_leveling,omitempty"` // The DM grants levels instead of XP
+	MilestoneLevel    int  `json:"milestone_level,omitempty"`    // Highest level granted in milestone mode
 
 	// Hit Points
 	MaxHP           int `json:"max_hp"`
 	CurrentHP       int `json:"current_hp"`
 	TempHP          int `json:"temp_hp"`
 	SpeciesHPBonus  int `json:"species_hp_bonus"` // HP bonus from species (e.g., Dwarven Toughness)
+	HPHistory       []HPEntry `json:"hp_history,omitempty"` // Hit points gained at each level
+	LevelLog        []LevelRecord `json:"level_log,omitempty"` // Choices made at each level up, for respecs
 
 	// Armor Class & Speed
 	ArmorClass int `json:"armor_class"`
 	AC         int `json:"ac"` // Calculated AC (kept for compatibility)
 	Speed      int `json:"speed"`
+	BaseSpeed  int `json:"base_speed,omitempty"` // Species speed, before bonuses
 
 	// Core Stats
 	AbilityScores AbilityScores `json:"ability_scores"`
@@ -48,9 +55,11 @@ type Character struct {
 	PassiveInsightBonus      int  `json:"passive_insight_bonus"`       // Bonus to passive Insight
 	Actions          ActionList  `json:"actions"`
 	Features         FeatureList `json:"features"`
+	Resources        []Resource  `json:"resources,omitempty"` // Class resource pools (Sorcery Points, Ki, ...)
 
 	// Equipment & Inventory
 	Inventory Inventory `json:"inventory"`
+	CatalogSellRate *float64 `json:"catalog_sell_rate,omitempty"` // Share of the price the item catalog pays for items sold; 0.5 if unset
 
 	// Magic
 	SpellBook SpellBook `json:"spellbook"`
@@ -65,9 +74,15 @@ type Character struct {
 	SpeciesSkills       []SkillType    `json:"species_skills"` // Track which skills came from species
 	SpeciesSpells       []string       `json:"species_spells"` // Track which spells came from species
 
+	// Active effects (spells, conditions) contributing modifiers
+	Effects []Effect `json:"effects,omitempty"`
+
 	// Inspiration
 	Inspiration bool `json:"inspiration"` // Can be used to gain advantage on rolls
 
+	// Downtime
+	Downtime []DowntimeEntry `json:"downtime,omitempty"` // Log of downtime activities (scribing, etc.)
+
 	// Misc
 	Notes string `json:"notes"`
 }
@@ -84,6 +99,7 @@ func NewCharacter() *Character {
 		CurrentHP:  10,
 		ArmorClass: 10,
 		Speed:      30,
+		BaseSpeed:  30,
 		AbilityScores: AbilityScores{
 			Strength:     10,
 			Dexterity:    10,
@@ -121,29 +137,56 @@ func NewCharacter() *Character {
 	return char
 }
 
-// UpdateDerivedStats updates calculated values based on ability scores
+// UpdateDerivedStats updates calculated values based on ability scores. Initiative,
+// speed, passive scores, spellcasting and AC are evaluated from the character's
+// modifiers (see Modifiers); the bonus fields are kept for older saves and displays.
 func (c *Character) UpdateDerivedStats() {
-	// Update initiative (DEX modifier + bonus from feats)
-	c.Initiative = c.AbilityScores.GetModifier(Dexterity) + c.InitiativeBonus
-
 	// Update carry capacity
 	c.Inventory.CarryCapacity = CalculateCarryCapacity(c.AbilityScores.Strength)
 
 	// Update proficiency bonus based on level
 	c.ProficiencyBonus = CalculateProficiencyBonus(c.Level)
 
+	// Recompute max HP from the HP history (a CON change applies to every level)
+	c.RecalculateMaxHP()
+
+	mods := c.Modifiers()
+	total := func(target string) int { return EvaluateModifiers(target, mods).Total }
+
+	// Initiative: DEX modifier + bonuses from feats and effects
+	c.Initiative = total(TargetInitiative)
+	c.InitiativeBonus = c.Initiative - c.AbilityScores.GetModifier(Dexterity)
+
+	c.Speed = total(TargetSpeed)
+
+	c.PassivePerceptionBonus = total(TargetPassivePerception) - EvaluateModifiers(TargetPassivePerception, c.passiveModifiers(TargetPassivePerception)).Total
+	c.PassiveInvestigationBonus = total(TargetPassiveInvestigation) - EvaluateModifiers(TargetPassiveInvestigation, c.passiveModifiers(TargetPassiveInvestigation)).Total
+	c.PassiveInsightBonus = total(TargetPassiveInsight) - EvaluateModifiers(TargetPassiveInsight, c.passiveModifiers(TargetPassiveInsight)).Total
+
 	// Update spell save DC and attack bonus if spellcaster
 	if c.SpellBook.SpellcastingMod != "" {
-		mod := c.AbilityScores.GetModifier(c.SpellBook.SpellcastingMod)
-		c.SpellBook.SpellSaveDC = 8 + c.ProficiencyBonus + mod
-		c.SpellBook.SpellAttackBonus = c.ProficiencyBonus + mod
+		c.SpellBook.SpellSaveDC = total(TargetSpellSaveDC)
+		c.SpellBook.SpellAttackBonus = total(TargetSpellAttack)
 	}
 
-	// Update AC based on equipped armor and shield
-	c.AC = CalculateAC(c)
+	// Update AC based on equipped armor, shield and bonuses
+	c.AC = total(TargetAC)
+	c.ACBonus = c.AC - EvaluateModifiers(TargetAC, armorModifiers(c)).Total
 	c.ArmorClass = c.AC // Keep both for compatibility
 }
 
+// SpellcastingFor returns the spellcasting modifier, save DC and attack bonus for a spell.
+// Spells granted by species or feats may use their own ability instead of the spellbook's.
+func (c *Character) SpellcastingFor(spell *Spell) (mod, saveDC, attackBonus int) {
+	if spell == nil || spell.SpellcastingAbility == "" {
+		mod = c.AbilityScores.GetModifier(c.SpellBook.SpellcastingMod)
+		return mod, c.SpellBook.SpellSaveDC, c.SpellBook.SpellAttackBonus
+	}
+
+	mod = c.AbilityScores.GetModifier(spell.SpellcastingAbility)
+	return mod, 8 + c.ProficiencyBonus + mod, c.ProficiencyBonus + mod
+}
+
 // CalculateProficiencyBonus returns proficiency bonus for a given level
 func CalculateProficiencyBonus(level int) int {
 	if level <= 4 {
@@ -188,7 +231,9 @@ func (c *Character) Heal(amount int) {
 // ShortRest performs a short rest
 func (c *Character) ShortRest() {
 	c.Actions.ShortRest()
+	c.SpellBook.ShortRest()
 	c.Features.ShortRestRecover()
+	c.ShortRestRecoverResources()
 }
 
 // LongRest performs a long rest
@@ -198,6 +243,10 @@ func (c *Character) LongRest() {
 	c.Actions.LongRest()
 	c.SpellBook.LongRest()
 	c.Features.LongRestRecover()
+	c.LongRestRecoverResources()
+
+	// Active effects (Mage Armor and the like) have run out by now
+	c.EndEffects()
 
 	// Humans regain Inspiration on long rest (Resourceful trait)
 	if c.Race == "Human" {
@@ -229,11 +278,15 @@ var ExperienceThresholds = []int{
 	355000, // Level 20
 }
 
-// CanLevelUp checks if character has enough XP to level up
+// CanLevelUp checks if character has enough XP to level up, or in milestone
+// mode if the DM granted the next level
 func (c *Character) CanLevelUp() bool {
 	if c.Level >= 20 {
 		return false
 	}
+	if c.MilestoneLeveling {
+		return c.MilestoneLevel > c.Level
+	}
 	return c.Experience >= ExperienceThresholds[c.Level]
 }
 
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
 )
 
 // Class represents a D&D 5e class
@@ -21,8 +24,133 @@ type Class struct {
 	ToolProficiencies    []string            `json:"tool_proficiencies"`
 	SkillChoices         *SkillChoiceInfo    `json:"skill_choices"`
 	StartingEquipment    []string            `json:"starting_equipment"`
+	StartingGold         int                 `json:"starting_gold,omitempty"` // Gold taken instead of the starting equipment
 	Spellcasting         *SpellcastingInfo   `json:"spellcasting"`
 	Level1Features       []FeatureDefinition `json:"level_1_features"`
+	LevelProgression     []ClassLevel        `json:"level_progression"`
+	Multiclass           *MulticlassInfo     `json:"multiclass,omitempty"` // Prerequisites and proficiencies when multiclassing
+}
+
+// ClassLevel is one entry of a class's level_progression
+type ClassLevel struct {
+	Level                   int                     `json:"level"`
+	ProficiencyBonus        int                     `json:"proficiency_bonus"`
+	Features                []ClassFeature          `json:"features"`
+	SpellcastingInfo        *ClassLevelSpellcasting `json:"spellcasting_info,omitempty"`
+	Resources               []ResourceDefinition    `json:"resources,omitempty"` // Pools gained or changed at this level
+	AbilityScoreImprovement bool                    `json:"ability_score_improvement"`
+}
+
+// ClassFeature is a feature gained at a class level
+type ClassFeature struct {
+	Name            string                 `json:"name"`
+	Description     string                 `json:"description"`
+	Uses            *ClassFeatureUses      `json:"uses,omitempty"`
+	Mechanics       map[string]interface{} `json:"mechanics,omitempty"`
+	Choices         []string               `json:"choices,omitempty"` // Options to pick from (Fighting Style, Pact Boon)
+	SubclassChoice  bool                   `json:"subclass_choice,omitempty"`
+	SubclassFeature bool                   `json:"subclass_feature,omitempty"`
+	ACFormula       *ACFormula             `json:"ac_formula,omitempty"` // Base AC while unarmored (Unarmored Defense)
+}
+
+// ClassFeatureUses describes how often a class feature can be used
+type ClassFeatureUses struct {
+	Max      UsesValue `json:"max"`      // Number or formula ("proficiency_bonus", "1 + Cha mod", "unlimited")
+	Recharge string    `json:"recharge"` // "short_rest" or "long_rest"
+}
+
+// UsesValue is a uses count that may be written in JSON as a number or a formula string
+type UsesValue string
+
+// UnmarshalJSON accepts both 2 and "proficiency_bonus"
+func (u *UsesValue) UnmarshalJSON(data []byte) error {
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err == nil {
+		*u = UsesValue(number.String())
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	*u = UsesValue(text)
+	return nil
+}
+
+// ClassLevelSpellcasting is the spellcasting_info of a class level
+type ClassLevelSpellcasting struct {
+	CantripsKnown  int             `json:"cantrips_known"`
+	SpellsKnown    int             `json:"spells_known,omitempty"`
+	SpellsPrepared string          `json:"spells_prepared,omitempty"`
+	SpellSlots     json.RawMessage `json:"spell_slots,omitempty"` // {"1": 4, "2": 3}, or the number of Pact Magic slots
+	SlotLevel      int             `json:"slot_level,omitempty"`  // Pact Magic slot level (Warlock)
+
+	// Class table columns shown for reference; the pools themselves are class resources
+	SorceryPoints    int `json:"sorcery_points,omitempty"`    // Sorcerer
+	InvocationsKnown int `json:"invocations_known,omitempty"` // Warlock
+}
+
+// Slots returns the number of slots per spell level
+func (sc *ClassLevelSpellcasting) Slots() map[int]int {
+	slots := map[int]int{}
+	if len(sc.SpellSlots) == 0 {
+		return slots
+	}
+
+	// Pact Magic: all slots are of the same level
+	var count int
+	if err := json.Unmarshal(sc.SpellSlots, &count); err == nil {
+		if sc.SlotLevel > 0 {
+			slots[sc.SlotLevel] = count
+		}
+		return slots
+	}
+
+	var byLevel map[string]int
+	if err := json.Unmarshal(sc.SpellSlots, &byLevel); err != nil {
+		return slots
+	}
+	for key, count := range byLevel {
+		if level, err := strconv.Atoi(key); err == nil {
+			slots[level] = count
+		}
+	}
+	return slots
+}
+
+// GetLevel returns the level_progression entry for a level, or nil if the class doesn't define it
+func (c *Class) GetLevel(level int) *ClassLevel {
+	for i := range c.LevelProgression {
+		if c.LevelProgression[i].Level == level {
+			return &c.LevelProgression[i]
+		}
+	}
+	return nil
+}
+
+// ToFeatureDefinition converts a class feature to a definition that BenefitApplier can grant.
+// Features without uses (or with unlimited uses) become passive features.
+func (cf *ClassFeature) ToFeatureDefinition() FeatureDefinition {
+	def := FeatureDefinition{
+		Name:        cf.Name,
+		Description: cf.Description,
+		RestType:    None,
+	}
+	if cf.Uses == nil {
+		return def
+	}
+
+	formula := strings.ToLower(strings.TrimSpace(string(cf.Uses.Max)))
+	switch formula {
+	case "", "unlimited":
+		return def
+	case "proficiency_bonus":
+		def.UsesFormula = "proficiency"
+	default:
+		def.UsesFormula = formula
+	}
+	def.RestType = ParseRecharge(cf.Uses.Recharge)
+	return def
 }
 
 // SkillChoiceInfo defines how many skills to choose and from which list
@@ -38,6 +166,10 @@ type SpellcastingInfo struct {
 	SpellsKnown    int            `json:"spells_known,omitempty"`
 	SpellsPrepared string         `json:"spells_prepared,omitempty"`
 	SpellSlots     map[string]int `json:"spell_slots"`
+	RitualCasting  bool           `json:"ritual_casting,omitempty"`
+	Spellbook      bool           `json:"spellbook,omitempty"` // Prepares spells from a spellbook (Wizard)
+	HalfCaster     bool           `json:"half_caster,omitempty"` // Counts half its levels for multiclass slots (Paladin, Ranger)
+	PactMagic      bool           `json:"pact_magic,omitempty"`  // Pact Magic slots are a pool of their own, recovered on a short rest (Warlock)
 }
 
 // ClassesData represents the structure of classes.json
@@ -67,13 +199,13 @@ func LoadClassesFromJSON(dirpath string) (*ClassesData, error) {
 		filePath := filepath.Join(dirpath, file.Name())
 		data, err := os.ReadFile(filePath)
 		if err != nil {
-			fmt.Printf("Warning: failed to read class file %s: %v\n", file.Name(), err)
+			diagnostics.Errorf(filePath, 0, "failed to read class file: %v", err)
 			continue
 		}
 
 		var class Class
 		if err := json.Unmarshal(data, &class); err != nil {
-			fmt.Printf("Warning: failed to parse class file %s: %v\n", file.Name(), err)
+			diagnostics.ReportJSONError(filePath, data, err)
 			continue
 		}
 
@@ -90,7 +222,7 @@ func GetAllClasses() []Class {
 	if cachedClasses == nil {
 		_, err := LoadClassesFromJSON("data/classes")
 		if err != nil {
-			fmt.Printf("Error loading classes: %v\n", err)
+			diagnostics.Errorf("data/classes", 0, "%v", err)
 			return []Class{}
 		}
 	}
@@ -109,48 +241,16 @@ func GetClassByName(name string) *Class {
 }
 
 // CalculateMaxHP calculates the maximum HP for a character
-// Formula: (Hit Die * Level) + (CON modifier * Level) + bonuses
-// At level 1, use maximum hit die value instead of rolling
+// Formula: sum over levels of (hit die result + CON modifier, minimum 1) + bonuses
+// The hit die results come from the character's HP history
 func CalculateMaxHP(char *Character, class *Class) int {
 	if class == nil {
 		return char.MaxHP // Return current if class not found
 	}
 
-	level := char.Level
-	if level < 1 {
-		level = 1
-	}
-
-	// Base HP calculation
-	var baseHP int
-	if level == 1 {
-		// Level 1: Always use maximum hit die value
-		baseHP = class.HitDie
-	} else {
-		// Higher levels: Hit die per level (simplified - should track actual rolls)
-		// For now, use average: (HitDie/2 + 1) per level after 1st
-		baseHP = class.HitDie // First level (max)
-		averagePerLevel := (class.HitDie / 2) + 1
-		baseHP += averagePerLevel * (level - 1)
-	}
-
-	// Add Constitution modifier per level
-	conModifier := char.AbilityScores.GetModifier("Constitution")
-	totalConBonus := conModifier * level
-
-	// Add any HP bonuses from feats, species, etc.
-	// These are tracked separately and added to base HP
-	bonusHP := char.SpeciesHPBonus
-
-	// Check benefit tracker for HP bonuses
-	if char.BenefitTracker != nil {
-		hpBenefits := char.BenefitTracker.GetBenefitsByType(BenefitHP)
-		for _, benefit := range hpBenefits {
-			bonusHP += benefit.Value
-		}
-	}
-
-	totalHP := baseHP + totalConBonus + bonusHP
+	// Hit points per level from the HP history, plus bonuses from feats, species, etc.
+	mods := append(hitPointModifiers(char, class), char.grantedModifiers()...)
+	totalHP := EvaluateModifiers(TargetMaxHP, mods).Total
 
 	// Minimum 1 HP
 	if totalHP < 1 {
@@ -167,8 +267,17 @@ func ApplyClassToCharacter(char *Character, className string) error {
 		return fmt.Errorf("class %s not found", className)
 	}
 
+	// Changing class starts over as a single class without a subclass
+	for _, cl := range char.Classes {
+		if cl.Subclass != "" {
+			NewBenefitRemover(char).RemoveAllBenefits(SubclassSourceType, cl.Subclass)
+		}
+	}
+	RemoveClassEquipment(char)
+
 	// Update class name
 	char.Class = className
+	char.Classes = []ClassLevels{{Class: className, Level: max(char.Level, 1)}}
 
 	// Apply armor proficiencies
 	char.ArmorProficiencies = make([]string, len(class.ArmorProficiencies))
@@ -182,6 +291,24 @@ func ApplyClassToCharacter(char *Character, className string) error {
 	char.SavingThrowProficiencies = make([]string, len(class.SavingThrows))
 	copy(char.SavingThrowProficiencies, class.SavingThrows)
 
+	// Record HP for the class's levels, dropping entries from a previous class
+	var history []HPEntry
+	for _, entry := range char.HPHistory {
+		if entry.Class == class.Name {
+			history = append(history, entry)
+		}
+	}
+	char.HPHistory = history
+	EnsureHPHistory(char)
+
+	var log []LevelRecord
+	for _, record := range char.LevelLog {
+		if record.Class == class.Name {
+			log = append(log, record)
+		}
+	}
+	char.LevelLog = log
+
 	// Calculate and set HP
 	newMaxHP := CalculateMaxHP(char, class)
 
@@ -199,6 +326,9 @@ func ApplyClassToCharacter(char *Character, className string) error {
 
 	char.MaxHP = newMaxHP
 
+	// Class resource pools (Sorcery Points, Ki, ...)
+	SyncClassResources(char)
+
 	// Update derived stats
 	char.UpdateDerivedStats()
 
@@ -0,0 +1,97 @@
+// internal/models/consumables.go
+package models
+
+import "fmt"
+
+// ItemUse is what happens when a consumable is used; one of the item is used up:
+//
+//	"use": {"heal": "2d4+2"}
+//	"use": {"effect": {"name": "Fire Resistance", "resistances": ["Fire"], "duration": "1 hour"}}
+//	"use": {"spell": "Fireball", "spell_level": 3}
+//	"use": {"message": "Eat a day's worth of food"}
+type ItemUse struct {
+	Heal       string  `json:"heal,omitempty"`        // Hit points regained, e.g. "2d4+2"
+	Effect     *Effect `json:"effect,omitempty"`      // Temporary effect, such as a resistance
+	Spell      string  `json:"spell,omitempty"`       // Spell cast from a scroll
+	SpellLevel int     `json:"spell_level,omitempty"` // Level the scroll's spell is cast at (default its own level)
+	Message    string  `json:"message,omitempty"`     // Shown when the item is used
+}
+
+// String describes the use, e.g. "Regain 2d4+2 HP" or "Fire Resistance for 1 hour"
+func (u ItemUse) String() string {
+	switch {
+	case u.Heal != "":
+		return fmt.Sprintf("Regain %s HP", u.Heal)
+	case u.Effect != nil && u.Effect.Duration != "":
+		return fmt.Sprintf("%s for %s", u.Effect.Name, u.Effect.Duration)
+	case u.Effect != nil:
+		return u.Effect.Name
+	case u.Spell != "":
+		return "Cast " + u.Spell
+	}
+	return u.Message
+}
+
+// ItemUseOf returns how an item is used, or nil if it isn't a consumable
+func ItemUseOf(item *Item) *ItemUse {
+	if def := GetItemDefinitionByName(item.Name); def != nil {
+		return def.Use
+	}
+	return nil
+}
+
+// ScrollSpellStats returns the spell save DC and attack bonus of a spell scroll of a level
+func ScrollSpellStats(level int) (saveDC, attackBonus int) {
+	switch {
+	case level <= 2:
+		return 13, 5
+	case level <= 4:
+		return 15, 7
+	case level <= 6:
+		return 17, 9
+	case level <= 8:
+		return 18, 10
+	}
+	return 19, 11
+}
+
+// UseItem uses up one of a consumable and applies its healing or effect. healed is the
+// result of the use's heal expression, rolled by the caller through the dice roller; a
+// scroll's spell is cast by the caller as well. It returns a description of the result.
+func (c *Character) UseItem(item *Item, healed int) (string, error) {
+	use := ItemUseOf(item)
+	if use == nil {
+		return "", fmt.Errorf("%s can't be used", item.Name)
+	}
+	if item.Quantity < 1 {
+		return "", fmt.Errorf("no %s left", item.Name)
+	}
+
+	name := item.Name
+	result := "Used " + name
+	switch {
+	case use.Heal != "":
+		before := c.CurrentHP
+		c.Heal(healed)
+		result = fmt.Sprintf("%s: regained %d HP (%d/%d)", result, c.CurrentHP-before, c.CurrentHP, c.MaxHP)
+	case use.Effect != nil:
+		effect := *use.Effect
+		if effect.Name == "" {
+			effect.Name = name
+		}
+		effect.Source = "Item: " + name
+		c.AddEffect(effect)
+		result = fmt.Sprintf("%s: %s", result, effect.Name)
+		if effect.Duration != "" {
+			result += " for " + effect.Duration
+		}
+	case use.Spell != "":
+		result = fmt.Sprintf("Read %s", name)
+	}
+	if use.Message != "" {
+		result += " - " + use.Message
+	}
+
+	c.Inventory.RemoveOne(item)
+	return result, nil
+}
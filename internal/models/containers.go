@@ -0,0 +1,145 @@
+// internal/models/containers.go
+package models
+
+import "fmt"
+
+// ContainerSpec makes an item a container that can hold other items:
+//
+//	"container": {"capacity": 500, "weightless": true}
+type ContainerSpec struct {
+	Capacity   float64 `json:"capacity"`             // Most weight it holds, in lbs
+	Weightless bool    `json:"weightless,omitempty"` // Contents don't count toward the carried weight (Bag of Holding)
+}
+
+// ContainerSpecOf returns the container spec of an item, or nil if it isn't a container
+func ContainerSpecOf(item *Item) *ContainerSpec {
+	if def := GetItemDefinitionByName(item.Name); def != nil {
+		return def.Container
+	}
+	return nil
+}
+
+// IsContainer reports whether an item can hold other items
+func IsContainer(item *Item) bool {
+	return ContainerSpecOf(item) != nil
+}
+
+// nextID returns an unused container ID
+func (inv *Inventory) nextID() int {
+	id := 0
+	for _, item := range inv.Items {
+		id = max(id, item.ID)
+	}
+	return id + 1
+}
+
+// EnsureContainerIDs gives containers saved before containers held items an ID
+func (inv *Inventory) EnsureContainerIDs() {
+	for i := range inv.Items {
+		if inv.Items[i].ID == 0 && IsContainer(&inv.Items[i]) {
+			inv.Items[i].ID = inv.nextID()
+		}
+	}
+}
+
+// ContainerOf returns the container an item is in, or nil if it is carried directly
+func (inv *Inventory) ContainerOf(item *Item) *Item {
+	if item.ContainerID == 0 {
+		return nil
+	}
+	for i := range inv.Items {
+		if inv.Items[i].ID == item.ContainerID && &inv.Items[i] != item {
+			return &inv.Items[i]
+		}
+	}
+	return nil
+}
+
+// Contents returns the indices of the items directly inside a container
+func (inv *Inventory) Contents(container *Item) []int {
+	var indices []int
+	if container.ID == 0 {
+		return indices
+	}
+	for i := range inv.Items {
+		if inv.Items[i].ContainerID == container.ID && &inv.Items[i] != container {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// ContentsWeight returns the weight of everything inside a container, including what is
+// inside the containers it holds
+func (inv *Inventory) ContentsWeight(container *Item) float64 {
+	total := 0.0
+	for _, i := range inv.Contents(container) {
+		total += inv.CarriedWeight(&inv.Items[i])
+	}
+	return total
+}
+
+// CarriedWeight returns the weight of an item stack with its contents, unless the
+// container doesn't weigh them
+func (inv *Inventory) CarriedWeight(item *Item) float64 {
+	weight := item.TotalWeight()
+	if spec := ContainerSpecOf(item); spec != nil && !spec.Weightless {
+		weight += inv.ContentsWeight(item)
+	}
+	return weight
+}
+
+// IsInside reports whether an item is inside a container, directly or in a nested one
+func (inv *Inventory) IsInside(item, container *Item) bool {
+	for parent := inv.ContainerOf(item); parent != nil; parent = inv.ContainerOf(parent) {
+		if parent == container {
+			return true
+		}
+	}
+	return false
+}
+
+// MoveToContainer puts an item stack into a container, or takes it out to be carried
+// directly if the container is nil. Items in a container are unequipped; an item stacks
+// with the same item already in the container.
+func (inv *Inventory) MoveToContainer(item, container *Item) error {
+	containerID := 0
+	if container != nil {
+		spec := ContainerSpecOf(container)
+		if spec == nil {
+			return fmt.Errorf("%s is not a container", container.Name)
+		}
+		if container == item || inv.IsInside(container, item) {
+			return fmt.Errorf("can't put %s inside itself", item.Name)
+		}
+		if container.ID == 0 {
+			container.ID = inv.nextID()
+		}
+		if inv.ContainerOf(item) == container {
+			return fmt.Errorf("%s is already in %s", item.Name, container.Name)
+		}
+		contents, weight := inv.ContentsWeight(container), inv.CarriedWeight(item)
+		if contents+weight > spec.Capacity {
+			return fmt.Errorf("%s holds %.0f lbs: %.1f lbs inside, %s weighs %.1f lbs",
+				container.Name, spec.Capacity, contents, item.Name, weight)
+		}
+		containerID = container.ID
+	} else if inv.ContainerOf(item) == nil {
+		return fmt.Errorf("%s is not in a container", item.Name)
+	}
+
+	item.Equipped = false
+	item.ContainerID = containerID
+	if IsContainer(item) {
+		return nil
+	}
+	for i := range inv.Items {
+		other := &inv.Items[i]
+		if other != item && other.Name == item.Name && other.Type == item.Type && other.ContainerID == containerID {
+			other.Quantity += item.Quantity
+			inv.RemoveAt(inv.indexOf(item))
+			return nil
+		}
+	}
+	return nil
+}
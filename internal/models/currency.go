@@ -0,0 +1,243 @@
+// internal/models/currency.go
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Coin values in copper pieces, and how many coins weigh a pound
+const (
+	CopperPerSilver   = 10
+	CopperPerElectrum = 50
+	CopperPerGold     = 100
+	CopperPerPlatinum = 1000
+	CoinsPerPound     = 50
+)
+
+// Coins is an amount of money in the five coin types
+type Coins struct {
+	Platinum int `json:"platinum"`
+	Gold     int `json:"gold"`
+	Electrum int `json:"electrum"`
+	Silver   int `json:"silver"`
+	Copper   int `json:"copper"`
+}
+
+// denominations lists the coin types from most to least valuable
+func (c *Coins) denominations() []struct {
+	count *int
+	value int
+	abbr  string
+} {
+	return []struct {
+		count *int
+		value int
+		abbr  string
+	}{
+		{&c.Platinum, CopperPerPlatinum, "pp"},
+		{&c.Gold, CopperPerGold, "gp"},
+		{&c.Electrum, CopperPerElectrum, "ep"},
+		{&c.Silver, CopperPerSilver, "sp"},
+		{&c.Copper, 1, "cp"},
+	}
+}
+
+// Value returns the total worth of the coins in copper pieces
+func (c Coins) Value() int {
+	total := 0
+	for _, d := range c.denominations() {
+		total += *d.count * d.value
+	}
+	return total
+}
+
+// Count returns the number of coins
+func (c Coins) Count() int {
+	return c.Platinum + c.Gold + c.Electrum + c.Silver + c.Copper
+}
+
+// Weight returns the weight of the coins in lbs (50 coins weigh a pound)
+func (c Coins) Weight() float64 {
+	return float64(c.Count()) / CoinsPerPound
+}
+
+// IsZero reports whether there are no coins
+func (c Coins) IsZero() bool {
+	return c == Coins{}
+}
+
+// Add adds other coins to these
+func (c *Coins) Add(other Coins) {
+	c.Platinum += other.Platinum
+	c.Gold += other.Gold
+	c.Electrum += other.Electrum
+	c.Silver += other.Silver
+	c.Copper += other.Copper
+}
+
+// String lists the coins, e.g. "12 gp 5 sp"
+func (c Coins) String() string {
+	var parts []string
+	for _, d := range c.denominations() {
+		if *d.count != 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", *d.count, d.abbr))
+		}
+	}
+	if len(parts) == 0 {
+		return "0 gp"
+	}
+	return strings.Join(parts, " ")
+}
+
+// CoinsFromCopper makes an amount in copper pieces out of gold, silver and copper,
+// the coins merchants hand out as change
+func CoinsFromCopper(copper int) Coins {
+	return Coins{
+		Gold:   copper / CopperPerGold,
+		Silver: copper % CopperPerGold / CopperPerSilver,
+		Copper: copper % CopperPerSilver,
+	}
+}
+
+// FormatCopper formats an amount in copper pieces, e.g. "2 gp 5 sp"
+func FormatCopper(copper int) string {
+	if copper < 0 {
+		return "-" + CoinsFromCopper(-copper).String()
+	}
+	return CoinsFromCopper(copper).String()
+}
+
+var coinPattern = regexp.MustCompile(`(\d+)\s*(pp|gp|ep|sp|cp)?`)
+
+// ParseCoins reads an amount such as "15 gp", "2gp 5sp" or "3 pp, 10 ep". A number
+// without a coin type is in gold pieces.
+func ParseCoins(text string) (Coins, error) {
+	var coins Coins
+	text = strings.ToLower(strings.TrimSpace(text))
+	matches := coinPattern.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 || strings.Trim(coinPattern.ReplaceAllString(text, ""), " ,") != "" {
+		return coins, fmt.Errorf("enter an amount, e.g. 15 gp or 2 gp 5 sp")
+	}
+
+	for _, match := range matches {
+		amount, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "pp":
+			coins.Platinum += amount
+		case "ep":
+			coins.Electrum += amount
+		case "sp":
+			coins.Silver += amount
+		case "cp":
+			coins.Copper += amount
+		default:
+			coins.Gold += amount
+		}
+	}
+	return coins, nil
+}
+
+// Transaction is one entry of the purse ledger: money received or spent
+type Transaction struct {
+	Amount int    `json:"amount"` // Copper pieces; negative for expenses
+	Reason string `json:"reason"`
+	Date   string `json:"date"` // YYYY-MM-DD
+}
+
+// String formats the entry for the ledger
+func (t Transaction) String() string {
+	sign := "+"
+	if t.Amount < 0 {
+		sign = ""
+	}
+	return fmt.Sprintf("%s  %s%s  %s", t.Date, sign, FormatCopper(t.Amount), t.Reason)
+}
+
+func (inv *Inventory) record(amount int, reason string) {
+	inv.Ledger = append(inv.Ledger, Transaction{
+		Amount: amount,
+		Reason: reason,
+		Date:   time.Now().Format("2006-01-02"),
+	})
+}
+
+// Receive adds coins to the purse and records the income in the ledger
+func (inv *Inventory) Receive(coins Coins, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("enter a reason for the income")
+	}
+	if coins.Value() <= 0 || coins.Platinum < 0 || coins.Gold < 0 || coins.Electrum < 0 ||
+		coins.Silver < 0 || coins.Copper < 0 {
+		return fmt.Errorf("receive at least 1 cp")
+	}
+
+	inv.Coins.Add(coins)
+	inv.record(coins.Value(), reason)
+	return nil
+}
+
+// Pay spends an amount in copper pieces and records the expense in the ledger. Coins
+// are taken from the most valuable down without overpaying; if that isn't exact, the
+// smallest coin left is broken and the change comes back as gold, silver and copper.
+// Nothing is paid when the purse is short.
+func (inv *Inventory) Pay(cost int, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("enter a reason for the expense")
+	}
+	if cost <= 0 {
+		return fmt.Errorf("pay at least 1 cp")
+	}
+	if have := inv.Coins.Value(); have < cost {
+		return fmt.Errorf("that costs %s, you have %s", FormatCopper(cost), FormatCopper(have))
+	}
+
+	remaining := cost
+	denominations := inv.Coins.denominations()
+	for _, d := range denominations {
+		used := min(*d.count, remaining/d.value)
+		*d.count -= used
+		remaining -= used * d.value
+	}
+	// Every coin left is worth more than what is still owed: break the smallest
+	for i := len(denominations) - 1; remaining > 0 && i >= 0; i-- {
+		if d := denominations[i]; *d.count > 0 {
+			*d.count--
+			inv.Coins.Add(CoinsFromCopper(d.value - remaining))
+			remaining = 0
+		}
+	}
+
+	inv.record(-cost, reason)
+	return nil
+}
+
+// SplitLoot divides loot evenly among the party members. The loot goes into the purse,
+// then every other member's share is paid out, each recorded in the ledger. Copper
+// that doesn't divide evenly stays with the character. Returns the value of a share
+// in copper pieces.
+func (inv *Inventory) SplitLoot(loot Coins, members int, reason string) (int, error) {
+	if members < 1 {
+		return 0, fmt.Errorf("split among at least 1 member")
+	}
+	if err := inv.Receive(loot, reason); err != nil {
+		return 0, err
+	}
+	reason = strings.TrimSpace(reason)
+
+	share := loot.Value() / members
+	if share == 0 {
+		return 0, nil
+	}
+	for i := 2; i <= members; i++ {
+		if err := inv.Pay(share, fmt.Sprintf("%s: share %d/%d", reason, i, members)); err != nil {
+			return 0, err
+		}
+	}
+	return share, nil
+}
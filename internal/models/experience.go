@@ -0,0 +1,88 @@
+// internal/models/experience.go
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// XPAward is one entry of the experience ledger: an XP award, or a level granted
+// by the DM in milestone mode
+type XPAward struct {
+	Amount    int    `json:"amount,omitempty"` // XP awarded (negative for corrections)
+	Milestone bool   `json:"milestone,omitempty"`
+	Reason    string `json:"reason"`
+	Date      string `json:"date"` // YYYY-MM-DD
+}
+
+// String formats the entry for the ledger
+func (a XPAward) String() string {
+	if a.Milestone {
+		return fmt.Sprintf("%s  Milestone level  %s", a.Date, a.Reason)
+	}
+	return fmt.Sprintf("%s  %+d XP  %s", a.Date, a.Amount, a.Reason)
+}
+
+// AwardXP adds experience with a reason and records it in the ledger. Experience
+// never drops below 0. Characters using milestone levelling don't track XP.
+func (c *Character) AwardXP(amount int, reason string) error {
+	if c.MilestoneLeveling {
+		return fmt.Errorf("%s uses milestone levelling", c.Name)
+	}
+	if amount == 0 {
+		return fmt.Errorf("award at least 1 XP")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("enter a reason for the award")
+	}
+	amount = max(amount, -c.Experience)
+
+	c.Experience += amount
+	c.XPLog = append(c.XPLog, XPAward{
+		Amount: amount,
+		Reason: reason,
+		Date:   time.Now().Format("2006-01-02"),
+	})
+	return nil
+}
+
+// GrantMilestone lets a character using milestone levelling take the next level
+func (c *Character) GrantMilestone(reason string) error {
+	if !c.MilestoneLeveling {
+		return fmt.Errorf("%s levels with XP", c.Name)
+	}
+	if c.Level >= 20 || c.MilestoneLevel > c.Level {
+		return fmt.Errorf("level %d is already available", max(c.MilestoneLevel, c.Level))
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("enter a reason for the milestone")
+	}
+
+	c.MilestoneLevel = c.Level + 1
+	c.XPLog = append(c.XPLog, XPAward{
+		Milestone: true,
+		Reason:    reason,
+		Date:      time.Now().Format("2006-01-02"),
+	})
+	return nil
+}
+
+// SetMilestoneLeveling switches between XP and milestone levelling. Levels already
+// reached are kept either way.
+func (c *Character) SetMilestoneLeveling(milestone bool) {
+	c.MilestoneLeveling = milestone
+	c.MilestoneLevel = c.Level
+}
+
+// XPProgress returns the XP earned since the current level and the XP the next
+// level takes from there
+func (c *Character) XPProgress() (earned, needed int) {
+	if c.Level >= 20 {
+		return 0, 0
+	}
+	floor := ExperienceThresholds[max(c.Level, 1)-1]
+	return c.Experience - floor, c.GetNextLevelXP() - floor
+}
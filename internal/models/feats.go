@@ -6,28 +6,58 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
 )
 
+// FeatCategoryEpicBoon is the category of the feats gained with the level 19 Epic Boon
+const FeatCategoryEpicBoon = "Epic Boon"
+
+// EpicBoonLevel is the character level Epic Boon feats require
+const EpicBoonLevel = 19
+
 // FeatAbilityIncrease represents ability score increases from a feat
 type FeatAbilityIncrease struct {
 	Ability string   `json:"ability,omitempty"` // Single ability (e.g., "Charisma")
 	Choices []string `json:"choices,omitempty"` // Multiple choice (e.g., ["Strength", "Dexterity"])
 	Amount  int      `json:"amount"`            // Amount to increase
+	Max     int      `json:"max,omitempty"`     // Highest score the increase can reach (20 if unset, up to 30)
+}
+
+// MaxScore returns the highest score the increase can reach
+func (a *FeatAbilityIncrease) MaxScore() int {
+	if a.Max <= 0 {
+		return AbilityScoreMax
+	}
+	return min(a.Max, EpicAbilityScoreMax)
+}
+
+// FeatBonuses are flat bonuses a feat grants
+type FeatBonuses struct {
+	Initiative           int `json:"initiative,omitempty"`            // Alert
+	AC                   int `json:"ac,omitempty"`                    // Dual Wielder
+	PassivePerception    int `json:"passive_perception,omitempty"`    // Observant
+	PassiveInvestigation int `json:"passive_investigation,omitempty"` // Observant
 }
 
 // Feat represents a character feat from D&D 5e 2024
 type Feat struct {
 	Name               string                `json:"name"`
 	Category           string                `json:"category"`
-	Prerequisite       string                `json:"prerequisite"`
+	Prerequisite       string                `json:"prerequisite"`           // As printed, e.g. "Strength 13 or higher"
+	Requires           *Requirement          `json:"requires,omitempty"`     // Checked form of the prerequisite
 	Repeatable         bool                  `json:"repeatable"`
 	Benefits           []string              `json:"benefits"`
 	Description        string                `json:"description"`
 	AbilityIncreases   *FeatAbilityIncrease  `json:"ability_increases,omitempty"`
+	HPBonus            int                   `json:"hp_bonus,omitempty"`    // Hit point maximum increase (Boon of Fortitude)
+	SpeedBonus         int                   `json:"speed_bonus,omitempty"` // Speed increase in feet (Boon of Speed)
+	GrantsBonus        *FeatBonuses          `json:"grants_bonus,omitempty"`
 	SkillProficiencies []string              `json:"skill_proficiencies,omitempty"`
 	Languages          []string              `json:"languages,omitempty"`
 	Features           []FeatureDefinition   `json:"features,omitempty"`           // Limited-use features granted
-	GrantsSpells       []string              `json:"grants_spells,omitempty"`
+	GrantsSpells       []string              `json:"grants_spells,omitempty"`    // Spell choices, described in prose
+	Spells             []SpellGrant          `json:"spells,omitempty"`           // Fixed spells resolved from the catalog
 	Note               string                `json:"note,omitempty"`
 }
 
@@ -51,6 +81,7 @@ func LoadFeatsFromJSON() (*FeatsData, error) {
 
 	var data FeatsData
 	if err := json.Unmarshal(file, &data); err != nil {
+		diagnostics.ReportJSONError("data/feats.json", file, err)
 		return nil, fmt.Errorf("failed to parse feats.json: %w", err)
 	}
 
@@ -62,7 +93,7 @@ func LoadFeatsFromJSON() (*FeatsData, error) {
 func GetAllFeats() []Feat {
 	data, err := LoadFeatsFromJSON()
 	if err != nil {
-		fmt.Printf("Error loading feats: %v\n", err)
+		diagnostics.Errorf("data/feats.json", 0, "%v", err)
 		return []Feat{}
 	}
 	return data.Feats
@@ -104,58 +135,32 @@ func GetFeatsForCharacter(char *Character) []Feat {
 	return availableFeats
 }
 
+// FeatLevelPrerequisite returns the character level a feat requires, or 0
+func FeatLevelPrerequisite(feat Feat) int {
+	if feat.Requires == nil {
+		return 0
+	}
+	return feat.Requires.MinLevel()
+}
+
 // CanTakeFeat checks if a character meets the prerequisites for a feat
 func CanTakeFeat(char *Character, feat Feat) bool {
-	prereq := strings.ToLower(feat.Prerequisite)
-
-	// No prerequisite
-	if prereq == "none" || prereq == "" {
-		return true
-	}
+	return CanTakeFeatAtLevel(char, feat, char.Level)
+}
 
-	// Check for ability score requirements
-	if strings.Contains(prereq, "strength") {
-		if strings.Contains(prereq, "13") && char.AbilityScores.Strength < 13 {
-			return false
-		}
-	}
-	if strings.Contains(prereq, "dexterity") {
-		if strings.Contains(prereq, "13") && char.AbilityScores.Dexterity < 13 {
-			return false
-		}
-	}
-	if strings.Contains(prereq, "intelligence") {
-		if strings.Contains(prereq, "13") && char.AbilityScores.Intelligence < 13 {
-			return false
-		}
-	}
-	if strings.Contains(prereq, "wisdom") {
-		if strings.Contains(prereq, "13") && char.AbilityScores.Wisdom < 13 {
-			return false
-		}
-	}
-	if strings.Contains(prereq, "charisma") {
-		if strings.Contains(prereq, "13") && char.AbilityScores.Charisma < 13 {
-			return false
-		}
-	}
+// CanTakeFeatAtLevel checks the prerequisites for a feat taken at a character level,
+// e.g. while levelling up to it
+func CanTakeFeatAtLevel(char *Character, feat Feat, level int) bool {
+	return len(UnmetFeatPrerequisites(char, feat, level)) == 0
+}
 
-	// Check for spellcasting requirement
-	if strings.Contains(prereq, "cast at least one spell") {
-		// Check if character has any spells or is a spellcasting class
-		hasSpells := len(char.SpellBook.Spells) > 0
-		isSpellcaster := char.Class == "Wizard" || char.Class == "Cleric" ||
-		                char.Class == "Druid" || char.Class == "Bard" ||
-		                char.Class == "Sorcerer" || char.Class == "Warlock"
-		if !hasSpells && !isSpellcaster {
-			return false
-		}
+// UnmetFeatPrerequisites describes each prerequisite of a feat the character doesn't
+// meet at a character level
+func UnmetFeatPrerequisites(char *Character, feat Feat, level int) []string {
+	if feat.Requires == nil {
+		return nil
 	}
-
-	// Check for armor proficiency requirements
-	// TODO: Implement armor proficiency tracking in character model
-
-	return true
+	return feat.Requires.Unmet(char, level)
 }
 
 // HasFeat checks if a character already has a specific feat
@@ -177,8 +182,8 @@ func AddFeatToCharacter(char *Character, featName string) error {
 	}
 
 	// Check if character can take this feat
-	if !CanTakeFeat(char, *feat) {
-		return fmt.Errorf("character does not meet prerequisites for %s", featName)
+	if unmet := UnmetFeatPrerequisites(char, *feat, char.Level); len(unmet) > 0 {
+		return fmt.Errorf("character does not meet prerequisites for %s: %s", featName, strings.Join(unmet, ", "))
 	}
 
 	// Check if feat is already taken and not repeatable
@@ -220,11 +225,33 @@ func ApplyFeatBenefits(char *Character, feat Feat, chosenAbility string) error {
 		if len(feat.AbilityIncreases.Choices) > 0 {
 			// Multiple choice - use chosenAbility
 			if chosenAbility != "" {
-				applier.AddAbilityScore(source, chosenAbility, feat.AbilityIncreases.Amount)
+				applier.AddAbilityScoreUpTo(source, chosenAbility, feat.AbilityIncreases.Amount, feat.AbilityIncreases.MaxScore())
 			}
 		} else if feat.AbilityIncreases.Ability != "" {
 			// Single ability
-			applier.AddAbilityScore(source, feat.AbilityIncreases.Ability, feat.AbilityIncreases.Amount)
+			applier.AddAbilityScoreUpTo(source, feat.AbilityIncreases.Ability, feat.AbilityIncreases.Amount, feat.AbilityIncreases.MaxScore())
+		}
+	}
+
+	// Apply flat hit point, speed and other bonuses
+	if feat.HPBonus != 0 {
+		applier.AddHP(source, feat.HPBonus)
+	}
+	if feat.SpeedBonus != 0 {
+		applier.AddSpeed(source, feat.SpeedBonus)
+	}
+	if bonus := feat.GrantsBonus; bonus != nil {
+		if bonus.Initiative != 0 {
+			applier.AddInitiative(source, bonus.Initiative)
+		}
+		if bonus.AC != 0 {
+			applier.AddACBonus(source, bonus.AC)
+		}
+		if bonus.PassivePerception != 0 {
+			applier.AddPassiveBonus(source, "Perception", bonus.PassivePerception)
+		}
+		if bonus.PassiveInvestigation != 0 {
+			applier.AddPassiveBonus(source, "Investigation", bonus.PassiveInvestigation)
 		}
 	}
 
@@ -243,6 +270,17 @@ func ApplyFeatBenefits(char *Character, feat Feat, chosenAbility string) error {
 		applier.AddFeature(source, featureDef)
 	}
 
+	// Apply spells; without an explicit ability they use the one this feat increased
+	for _, grant := range feat.Spells {
+		if char.Level < grant.UnlockLevel() {
+			continue
+		}
+		if grant.Ability == "" {
+			grant.Ability = chosenAbility
+		}
+		applier.AddSpellGrant(source, grant)
+	}
+
 	// Apply special benefits
 	featNameLower := strings.ToLower(feat.Name)
 
@@ -256,22 +294,6 @@ func ApplyFeatBenefits(char *Character, feat Feat, chosenAbility string) error {
 		applier.AddSpeed(source, 10)
 	}
 
-	// Alert feat: +5 initiative
-	if featNameLower == "alert" {
-		applier.AddInitiative(source, 5)
-	}
-
-	// Dual Wielder feat: +1 AC (when wielding two weapons)
-	if featNameLower == "dual wielder" {
-		applier.AddACBonus(source, 1)
-	}
-
-	// Observant feat: +5 passive Perception and Investigation
-	if featNameLower == "observant" {
-		applier.AddPassiveBonus(source, "Perception", 5)
-		applier.AddPassiveBonus(source, "Investigation", 5)
-	}
-
 	// Update derived stats after applying benefits
 	char.UpdateDerivedStats()
 	return nil
@@ -280,6 +302,16 @@ func ApplyFeatBenefits(char *Character, feat Feat, chosenAbility string) error {
 // RemoveFeatBenefits removes the mechanical benefits of a feat from a character
 func RemoveFeatBenefits(char *Character, feat Feat) error {
 	remover := NewBenefitRemover(char)
+
+	// The feat no longer fills the choice it was taken for (e.g. an ASI)
+	kept := []GrantedBenefit{}
+	for _, b := range char.BenefitTracker.Benefits {
+		if b.Type != BenefitFeat || !strings.EqualFold(b.Target, feat.Name) {
+			kept = append(kept, b)
+		}
+	}
+	char.BenefitTracker.Benefits = kept
+
 	return remover.RemoveAllBenefits("feat", feat.Name)
 }
 
@@ -336,8 +368,15 @@ func FormatFeatForDisplay(feat Feat) string {
 		sb.WriteString("\n")
 	}
 
-	if len(feat.GrantsSpells) > 0 {
+	if len(feat.Spells) > 0 || len(feat.GrantsSpells) > 0 {
 		sb.WriteString("Grants Spells:\n")
+		for _, grant := range feat.Spells {
+			line := grant.SpellName
+			if grant.FreeCasts > 0 {
+				line += fmt.Sprintf(" (%d/long rest free)", grant.FreeCasts)
+			}
+			sb.WriteString(fmt.Sprintf("  • %s\n", line))
+		}
 		for _, spell := range feat.GrantsSpells {
 			sb.WriteString(fmt.Sprintf("  • %s\n", spell))
 		}
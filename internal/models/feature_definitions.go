@@ -1,7 +1,12 @@
 // internal/models/feature_definitions.go
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
 
 // FeatureDefinition defines a limited-use feature that can be granted by feats or species
 type FeatureDefinition struct {
@@ -30,6 +35,13 @@ func (fd *FeatureDefinition) CalculateMaxUses(char *Character) int {
 		}
 		return 1
 	default:
+		// Ability modifier formulas such as "1 + cha mod" or "cha mod (min 1)"
+		if m := abilityModFormula.FindStringSubmatch(strings.ToLower(fd.UsesFormula)); m != nil {
+			if ability, ok := ParseAbilityType(m[2]); ok {
+				base, _ := strconv.Atoi(m[1])
+				return max(base+char.AbilityScores.GetModifier(ability), 1)
+			}
+		}
 		// Try to parse as a number
 		var uses int
 		if _, err := fmt.Sscanf(fd.UsesFormula, "%d", &uses); err == nil {
@@ -39,6 +51,8 @@ func (fd *FeatureDefinition) CalculateMaxUses(char *Character) int {
 	}
 }
 
+var abilityModFormula = regexp.MustCompile(`^(?:(\d+)\s*\+\s*)?(str|dex|con|int|wis|cha)\w*\s+mod`)
+
 // ToFeature converts a definition to an actual Feature instance
 func (fd *FeatureDefinition) ToFeature(char *Character, source string) Feature {
 	maxUses := fd.CalculateMaxUses(char)
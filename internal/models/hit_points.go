@@ -0,0 +1,125 @@
+// internal/models/hit_points.go
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// HP gain methods recorded in the HP history
+const (
+	HPMethodMax    = "max"    // 1st level: the full hit die
+	HPMethodFixed  = "fixed"  // The fixed value: half the hit die + 1
+	HPMethodRolled = "rolled" // A hit die roll
+)
+
+// HPEntry records the hit points gained at one character level.
+// Result is the die value before the Constitution modifier, so max HP can be
+// recomputed when CON changes.
+type HPEntry struct {
+	Level  int    `json:"level"`
+	Class  string `json:"class"`
+	Method string `json:"method"` // "max", "fixed" or "rolled"
+	Die    int    `json:"die"`    // Hit die size, e.g. 8 for a d8
+	Result int    `json:"result"` // Hit die result before the CON modifier
+}
+
+// FixedHitPoints returns the fixed hit point value of a hit die (PHB p.15)
+func FixedHitPoints(hitDie int) int {
+	return hitDie/2 + 1
+}
+
+// NewHPEntry creates the HP entry for a level. A roll of 0 takes the fixed value;
+// 1st level always takes the maximum.
+func NewHPEntry(level int, class string, hitDie, roll int) (HPEntry, error) {
+	entry := HPEntry{Level: level, Class: class, Die: hitDie}
+	switch {
+	case level == 1:
+		entry.Method = HPMethodMax
+		entry.Result = hitDie
+	case roll == 0:
+		entry.Method = HPMethodFixed
+		entry.Result = FixedHitPoints(hitDie)
+	case roll < 1 || roll > hitDie:
+		return entry, fmt.Errorf("a d%d can't roll %d", hitDie, roll)
+	default:
+		entry.Method = HPMethodRolled
+		entry.Result = roll
+	}
+	return entry, nil
+}
+
+// HitPoints returns the hit points the entry grants with a Constitution modifier (minimum 1)
+func (e HPEntry) HitPoints(conMod int) int {
+	return max(e.Result+conMod, 1)
+}
+
+// String formats the entry for display, e.g. "Level 3 Wizard: rolled 4 on d6"
+func (e HPEntry) String() string {
+	if e.Method == HPMethodRolled {
+		return fmt.Sprintf("Level %d %s: rolled %d on d%d", e.Level, e.Class, e.Result, e.Die)
+	}
+	return fmt.Sprintf("Level %d %s: %s %d (d%d)", e.Level, e.Class, e.Method, e.Result, e.Die)
+}
+
+// GetHPEntry returns the HP entry for a character level, or nil if none was recorded
+func (c *Character) GetHPEntry(level int) *HPEntry {
+	for i := range c.HPHistory {
+		if c.HPHistory[i].Level == level {
+			return &c.HPHistory[i]
+		}
+	}
+	return nil
+}
+
+// SetHPEntry records the HP entry for its level, replacing any previous one
+func (c *Character) SetHPEntry(entry HPEntry) {
+	c.RemoveHPEntry(entry.Level)
+	c.HPHistory = append(c.HPHistory, entry)
+	sort.Slice(c.HPHistory, func(i, j int) bool {
+		return c.HPHistory[i].Level < c.HPHistory[j].Level
+	})
+}
+
+// RemoveHPEntry removes the HP entry for a character level
+func (c *Character) RemoveHPEntry(level int) {
+	for i := range c.HPHistory {
+		if c.HPHistory[i].Level == level {
+			c.HPHistory = append(c.HPHistory[:i], c.HPHistory[i+1:]...)
+			return
+		}
+	}
+}
+
+// EnsureHPHistory fills in HP entries for levels that have none, taking the
+// maximum at 1st level and the fixed value after. Older saves predate the history.
+func EnsureHPHistory(char *Character) {
+	class := GetClassByName(char.Class)
+	if class == nil {
+		return
+	}
+	for level := 1; level <= max(char.Level, 1); level++ {
+		if char.GetHPEntry(level) == nil {
+			entry, _ := NewHPEntry(level, class.Name, class.HitDie, 0)
+			char.SetHPEntry(entry)
+		}
+	}
+}
+
+// RecalculateMaxHP recomputes max HP from the HP history, so a Constitution change
+// applies to every level. A character at full health stays at full health.
+func (c *Character) RecalculateMaxHP() {
+	if len(c.HPHistory) == 0 {
+		return
+	}
+	class := GetClassByName(c.Class)
+	if class == nil {
+		return
+	}
+
+	newMaxHP := CalculateMaxHP(c, class)
+	if c.CurrentHP >= c.MaxHP || c.CurrentHP > newMaxHP {
+		c.CurrentHP = newMaxHP
+	}
+	c.MaxHP = newMaxHP
+}
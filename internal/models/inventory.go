@@ -22,7 +22,12 @@ type Item struct {
 	Weight      float64  `json:"weight"` // Weight per item in lbs
 	Description string   `json:"description,omitempty"`
 	Equipped    bool     `json:"equipped"`
-	Value       int      `json:"value"` // Value in gold pieces
+	Attuned     bool     `json:"attuned,omitempty"`
+	Value       int      `json:"value"`                  // Value in gold pieces
+	Charges     int      `json:"charges,omitempty"`      // Charges left (wands, staffs, rings)
+	MaxCharges  int      `json:"max_charges,omitempty"`  // Charges when full; 0 for items without charges
+	ID          int      `json:"id,omitempty"`           // Containers only: what their contents refer to
+	ContainerID int      `json:"container_id,omitempty"` // Container the item is in; 0 if carried directly
 }
 
 // TotalWeight calculates the total weight of the item stack
@@ -32,17 +37,29 @@ func (i *Item) TotalWeight() float64 {
 
 // Inventory represents the character's inventory
 type Inventory struct {
-	Items         []Item  `json:"items"`
-	Gold          int     `json:"gold"`
-	Silver        int     `json:"silver"`
-	Copper        int     `json:"copper"`
-	CarryCapacity float64 `json:"carry_capacity"` // Based on STR score
+	Items []Item `json:"items"`
+	Coins
+	Ledger        []Transaction `json:"ledger,omitempty"`         // Every income and expense
+	Loadouts      []Loadout     `json:"loadouts,omitempty"`       // Named sets of equipment to swap between
+	ActiveLoadout string        `json:"active_loadout,omitempty"` // Loadout last swapped to
+	CarryCapacity float64       `json:"carry_capacity"`           // Based on STR score
 }
 
-// AddItem adds an item to inventory or increases quantity if it exists
+// AddItem adds an item to inventory or increases quantity if it exists in the same
+// container. Containers are never stacked: each gets its own entry to hold items.
 func (inv *Inventory) AddItem(item Item) {
+	if IsContainer(&item) {
+		count := max(item.Quantity, 1)
+		for range count {
+			container := item
+			container.Quantity = 1
+			container.ID = inv.nextID()
+			inv.Items = append(inv.Items, container)
+		}
+		return
+	}
 	for i := range inv.Items {
-		if inv.Items[i].Name == item.Name && inv.Items[i].Type == item.Type {
+		if inv.Items[i].Name == item.Name && inv.Items[i].Type == item.Type && inv.Items[i].ContainerID == item.ContainerID {
 			inv.Items[i].Quantity += item.Quantity
 			return
 		}
@@ -55,7 +72,7 @@ func (inv *Inventory) RemoveItem(name string, quantity int) bool {
 	for i := range inv.Items {
 		if inv.Items[i].Name == name {
 			if inv.Items[i].Quantity <= quantity {
-				inv.Items = append(inv.Items[:i], inv.Items[i+1:]...)
+				inv.RemoveAt(i)
 			} else {
 				inv.Items[i].Quantity -= quantity
 			}
@@ -65,11 +82,55 @@ func (inv *Inventory) RemoveItem(name string, quantity int) bool {
 	return false
 }
 
-// TotalWeight calculates the total weight of all items
+// RemoveAt removes the item stack at an index; the contents of a removed container are
+// left where the container was
+func (inv *Inventory) RemoveAt(index int) {
+	if index < 0 || index >= len(inv.Items) {
+		return
+	}
+	removed := inv.Items[index]
+	inv.Items = append(inv.Items[:index], inv.Items[index+1:]...)
+	if removed.ID == 0 {
+		return
+	}
+	for i := range inv.Items {
+		if inv.Items[i].ContainerID == removed.ID {
+			inv.Items[i].ContainerID = removed.ContainerID
+		}
+	}
+}
+
+// RemoveOne uses up one of an item stack, removing the stack with its last item
+func (inv *Inventory) RemoveOne(item *Item) {
+	index := inv.indexOf(item)
+	if index < 0 {
+		return
+	}
+	if inv.Items[index].Quantity > 1 {
+		inv.Items[index].Quantity--
+		return
+	}
+	inv.RemoveAt(index)
+}
+
+// indexOf returns the index of an item of the inventory, or -1
+func (inv *Inventory) indexOf(item *Item) int {
+	for i := range inv.Items {
+		if &inv.Items[i] == item {
+			return i
+		}
+	}
+	return -1
+}
+
+// TotalWeight calculates the total weight of all items and coins carried; what is
+// inside a container that doesn't weigh its contents (Bag of Holding) doesn't count
 func (inv *Inventory) TotalWeight() float64 {
-	total := 0.0
-	for _, item := range inv.Items {
-		total += item.TotalWeight()
+	total := inv.Coins.Weight()
+	for i := range inv.Items {
+		if inv.ContainerOf(&inv.Items[i]) == nil {
+			total += inv.CarriedWeight(&inv.Items[i])
+		}
 	}
 	return total
 }
@@ -0,0 +1,71 @@
+// internal/models/item_charges.go
+package models
+
+import "fmt"
+
+// HasCharges reports whether the item is a charged item such as a wand
+func (i *Item) HasCharges() bool {
+	return i.MaxCharges > 0
+}
+
+// UseCharge spends charges of a charged item, reporting whether its last charge was spent
+func (i *Item) UseCharge(count int) (empty bool, err error) {
+	if !i.HasCharges() {
+		return false, fmt.Errorf("%s has no charges", i.Name)
+	}
+	if i.Charges < count {
+		return false, fmt.Errorf("%s has only %d charge(s) left", i.Name, i.Charges)
+	}
+	i.Charges -= count
+	return i.Charges == 0, nil
+}
+
+// Recharge regains a rolled number of charges, up to the maximum, and returns how many
+// were regained
+func (i *Item) Recharge(rolled int) int {
+	gained := max(min(rolled, i.MaxCharges-i.Charges), 0)
+	i.Charges += gained
+	return gained
+}
+
+// RechargeExpression returns the dice expression of the charges an item regains at dawn,
+// e.g. "1d6+1", or "" if it doesn't recharge
+func RechargeExpression(item *Item) string {
+	if def := GetItemDefinitionByName(item.Name); def != nil && def.Effects != nil {
+		return def.Effects.Recharge
+	}
+	return ""
+}
+
+// DestroyOn returns the d20 result at or below which an item is destroyed when its last
+// charge is spent, or 0 if it can't be destroyed that way
+func DestroyOn(item *Item) int {
+	if def := GetItemDefinitionByName(item.Name); def != nil && def.Effects != nil {
+		return def.Effects.DestroyOn
+	}
+	return 0
+}
+
+// ItemsToRecharge returns the charged items that regain charges at dawn and are missing some
+func ItemsToRecharge(char *Character) []*Item {
+	var items []*Item
+	for i := range char.Inventory.Items {
+		item := &char.Inventory.Items[i]
+		if item.HasCharges() && item.Charges < item.MaxCharges && RechargeExpression(item) != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// EnsureItemCharges gives charged items saved before charges were tracked their full charges
+func EnsureItemCharges(char *Character) {
+	for i := range char.Inventory.Items {
+		item := &char.Inventory.Items[i]
+		def := GetItemDefinitionByName(item.Name)
+		if item.MaxCharges == 0 && def != nil && def.Effects != nil && def.Effects.Charges > 0 {
+			item.MaxCharges = def.Effects.Charges
+			item.Charges = item.MaxCharges
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"os"
 	"strings"
+
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
 )
 
 // ItemDefinition represents an item template from the items database
@@ -22,6 +24,11 @@ type ItemDefinition struct {
 	StealthDisadvantage  bool     `json:"stealth_disadvantage,omitempty"`
 	StrengthReq          int      `json:"strength_req,omitempty"`
 	Equippable           bool     `json:"equippable,omitempty"`
+	RequiresAttunement   bool         `json:"requires_attunement,omitempty"`
+	Effects              *ItemEffects `json:"effects,omitempty"`
+	Use                  *ItemUse     `json:"use,omitempty"` // Consumables: what using one does
+	Container            *ContainerSpec `json:"container,omitempty"` // Containers: what they hold
+	Contents             []string       `json:"contents,omitempty"`  // Packs: the items a pack unpacks into, e.g. "10 Torches"
 }
 
 // ItemsDatabase holds all item definitions
@@ -44,7 +51,11 @@ func LoadItemsFromJSON(filePath string) error {
 	}
 
 	itemsDB = &ItemsDatabase{}
-	return json.Unmarshal(data, itemsDB)
+	if err := json.Unmarshal(data, itemsDB); err != nil {
+		diagnostics.ReportJSONError(filePath, data, err)
+		return err
+	}
+	return nil
 }
 
 // GetAllItemDefinitions returns all item definitions as a flat list
@@ -207,7 +218,7 @@ func ConvertToInventoryItem(def ItemDefinition, quantity int) Item {
 		description += strings.Join(def.Properties, ", ")
 	}
 
-	return Item{
+	item := Item{
 		Name:        def.Name,
 		Type:        itemType,
 		Quantity:    quantity,
@@ -216,4 +227,11 @@ func ConvertToInventoryItem(def ItemDefinition, quantity int) Item {
 		Equipped:    false,
 		Value:       int(def.PriceGP),
 	}
+
+	// Charged items start full
+	if def.Effects != nil && def.Effects.Charges > 0 {
+		item.MaxCharges = def.Effects.Charges
+		item.Charges = item.MaxCharges
+	}
+	return item
 }
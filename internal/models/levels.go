@@ -0,0 +1,87 @@
+// internal/models/levels.go
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LevelRecord records the choices made when a character level was gained, so the
+// level can be rolled back and its choices replayed by a respec
+type LevelRecord struct {
+	Level           int               `json:"level"` // Character level
+	Class           string            `json:"class"`
+	HPRoll          int               `json:"hp_roll,omitempty"`          // 0 took the fixed value
+	AbilityIncrease map[string]int    `json:"ability_increase,omitempty"` // Ability name -> points of the ASI
+	Feat            string            `json:"feat,omitempty"`             // General feat taken instead of the ASI
+	FeatAbility     string            `json:"feat_ability,omitempty"`     // Ability chosen for the feat's increase
+	Subclass        string            `json:"subclass,omitempty"`
+	FeatureChoices  map[string]string `json:"feature_choices,omitempty"`
+	Skills          []string          `json:"skills,omitempty"`      // Multiclass skill proficiencies
+	BookSpells      []string          `json:"book_spells,omitempty"` // Spells added to the spellbook
+}
+
+// Summary describes the record in one line, e.g. "Wizard: rolled 4 HP, +2 Intelligence"
+func (r LevelRecord) Summary() string {
+	var parts []string
+	if r.HPRoll > 0 {
+		parts = append(parts, fmt.Sprintf("rolled %d HP", r.HPRoll))
+	} else {
+		parts = append(parts, "fixed HP")
+	}
+	var increases []string
+	for ability, points := range r.AbilityIncrease {
+		increases = append(increases, fmt.Sprintf("+%d %s", points, ability))
+	}
+	sort.Strings(increases)
+	parts = append(parts, increases...)
+	if r.Feat != "" {
+		parts = append(parts, "feat "+r.Feat)
+	}
+	if r.Subclass != "" {
+		parts = append(parts, r.Subclass)
+	}
+	for name, choice := range r.FeatureChoices {
+		parts = append(parts, fmt.Sprintf("%s: %s", name, choice))
+	}
+	parts = append(parts, r.Skills...)
+	if len(r.BookSpells) > 0 {
+		parts = append(parts, "spellbook: "+strings.Join(r.BookSpells, ", "))
+	}
+	return fmt.Sprintf("%s: %s", r.Class, strings.Join(parts, ", "))
+}
+
+// LevelSource returns the benefit source of a class level, e.g. class:Wizard:5.
+// Everything a level up grants is tracked under it so the level can be removed.
+func LevelSource(className string, classLevel int) BenefitSource {
+	return BenefitSource{Type: "class", Name: fmt.Sprintf("%s:%d", className, classLevel)}
+}
+
+// GetLevelRecord returns the record of a character level, or nil
+func (c *Character) GetLevelRecord(level int) *LevelRecord {
+	for i := range c.LevelLog {
+		if c.LevelLog[i].Level == level {
+			return &c.LevelLog[i]
+		}
+	}
+	return nil
+}
+
+// SetLevelRecord adds or replaces the record of a character level
+func (c *Character) SetLevelRecord(record LevelRecord) {
+	c.RemoveLevelRecord(record.Level)
+	c.LevelLog = append(c.LevelLog, record)
+	sort.Slice(c.LevelLog, func(i, j int) bool { return c.LevelLog[i].Level < c.LevelLog[j].Level })
+}
+
+// RemoveLevelRecord drops the record of a character level
+func (c *Character) RemoveLevelRecord(level int) {
+	var kept []LevelRecord
+	for _, record := range c.LevelLog {
+		if record.Level != level {
+			kept = append(kept, record)
+		}
+	}
+	c.LevelLog = kept
+}
@@ -0,0 +1,265 @@
+// internal/models/loadouts.go
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Loadout is a named set of equipped weapons, armor, shield and attuned items to swap
+// to at once, e.g. "Sword and Board" or "Longbow"
+type Loadout struct {
+	Name     string   `json:"name"`
+	Equipped []string `json:"equipped"`
+	Attuned  []string `json:"attuned,omitempty"`
+}
+
+// IsShield reports whether an item is a shield
+func IsShield(item *Item) bool {
+	def := GetItemDefinitionByName(item.Name)
+	return def != nil && strings.EqualFold(def.Subcategory, "shield")
+}
+
+// weaponProperty returns a weapon's property by name, e.g. "Versatile (1d8)" for
+// "Versatile", or "" if it doesn't have it
+func weaponProperty(item *Item, property string) string {
+	def := GetItemDefinitionByName(item.Name)
+	if def == nil {
+		return ""
+	}
+	for _, p := range def.Properties {
+		if name, _, _ := strings.Cut(p, " ("); strings.EqualFold(name, property) {
+			return p
+		}
+	}
+	return ""
+}
+
+// Hands returns how many hands an item takes to hold: two for a two-handed weapon, one
+// for other weapons and shields, none for anything else
+func Hands(item *Item) int {
+	switch {
+	case IsShield(item):
+		return 1
+	case item.Type != Weapon:
+		return 0
+	case weaponProperty(item, "Two-handed") != "":
+		return 2
+	default:
+		return 1
+	}
+}
+
+// HeldItems returns the equipped weapons and shields
+func (inv *Inventory) HeldItems() []*Item {
+	var held []*Item
+	for i := range inv.Items {
+		if inv.Items[i].Equipped && Hands(&inv.Items[i]) > 0 {
+			held = append(held, &inv.Items[i])
+		}
+	}
+	return held
+}
+
+// CheckHands checks that the weapons and shield held fit in two hands: a two-handed
+// weapon takes both, and two weapons must both be Light for two-weapon fighting unless
+// the character has the Dual Wielder feat. It returns a note when a versatile weapon is
+// held beside a shield, as it can only be wielded one-handed.
+func CheckHands(char *Character, held []*Item) (string, error) {
+	hands := 0
+	var names, weapons []string
+	var versatile, shield *Item
+	for _, item := range held {
+		n := Hands(item)
+		if n == 0 {
+			continue
+		}
+		hands += n
+		names = append(names, item.Name)
+		switch {
+		case IsShield(item):
+			shield = item
+		case n == 2 && len(held) > 1:
+			return "", fmt.Errorf("%s needs both hands", item.Name)
+		default:
+			weapons = append(weapons, item.Name)
+			if weaponProperty(item, "Versatile") != "" {
+				versatile = item
+			}
+			if len(weapons) == 2 && !HasFeat(char, "Dual Wielder") {
+				for _, other := range held {
+					if other.Type == Weapon && weaponProperty(other, "Light") == "" {
+						return "", fmt.Errorf("two-weapon fighting needs Light weapons, %s isn't (Dual Wielder lifts this)", other.Name)
+					}
+				}
+			}
+		}
+	}
+	if hands > 2 {
+		return "", fmt.Errorf("only two hands to hold %s", strings.Join(names, ", "))
+	}
+	if versatile != nil && shield != nil {
+		return fmt.Sprintf("%s is wielded one-handed beside the %s, without its %s damage",
+			versatile.Name, shield.Name, strings.ToLower(weaponProperty(versatile, "Versatile"))), nil
+	}
+	return "", nil
+}
+
+// CanHold checks that an item can be equipped beside the weapons and shield already
+// held; a new shield replaces the old one
+func (c *Character) CanHold(item *Item) (string, error) {
+	if Hands(item) == 0 {
+		return "", nil
+	}
+	held := []*Item{item}
+	for _, other := range c.Inventory.HeldItems() {
+		if other != item && !(IsShield(item) && IsShield(other)) {
+			held = append(held, other)
+		}
+	}
+	return CheckHands(c, held)
+}
+
+// FindLoadout returns the loadout with a name, or nil
+func (inv *Inventory) FindLoadout(name string) *Loadout {
+	for i := range inv.Loadouts {
+		if strings.EqualFold(inv.Loadouts[i].Name, name) {
+			return &inv.Loadouts[i]
+		}
+	}
+	return nil
+}
+
+// SaveLoadout records what is equipped and attuned under a name, replacing the loadout
+// of the same name
+func (c *Character) SaveLoadout(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("name the loadout")
+	}
+	if _, err := CheckHands(c, c.Inventory.HeldItems()); err != nil {
+		return err
+	}
+
+	loadout := Loadout{Name: name}
+	for _, item := range c.Inventory.Items {
+		if item.Equipped {
+			loadout.Equipped = append(loadout.Equipped, item.Name)
+		}
+		if item.Attuned {
+			loadout.Attuned = append(loadout.Attuned, item.Name)
+		}
+	}
+	if existing := c.Inventory.FindLoadout(name); existing != nil {
+		*existing = loadout
+	} else {
+		c.Inventory.Loadouts = append(c.Inventory.Loadouts, loadout)
+	}
+	c.Inventory.ActiveLoadout = name
+	return nil
+}
+
+// DeleteLoadout removes a loadout
+func (c *Character) DeleteLoadout(name string) error {
+	for i := range c.Inventory.Loadouts {
+		if strings.EqualFold(c.Inventory.Loadouts[i].Name, name) {
+			c.Inventory.Loadouts = append(c.Inventory.Loadouts[:i], c.Inventory.Loadouts[i+1:]...)
+			if strings.EqualFold(c.Inventory.ActiveLoadout, name) {
+				c.Inventory.ActiveLoadout = ""
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("no loadout named %s", name)
+}
+
+// ApplyLoadout equips exactly the items of a loadout and attunes to exactly its attuned
+// items. Nothing changes if the held items break the hand rules; items no longer in the
+// inventory are skipped and returned.
+func (c *Character) ApplyLoadout(name string) ([]string, error) {
+	loadout := c.Inventory.FindLoadout(name)
+	if loadout == nil {
+		return nil, fmt.Errorf("no loadout named %s", name)
+	}
+	inv := &c.Inventory
+
+	equip := map[*Item]bool{}
+	var missing []string
+	var held []*Item
+	for _, itemName := range loadout.Equipped {
+		item := inv.findUnpicked(itemName, equip)
+		if item == nil {
+			missing = append(missing, itemName)
+			continue
+		}
+		equip[item] = true
+		if Hands(item) > 0 {
+			held = append(held, item)
+		}
+	}
+	if _, err := CheckHands(c, held); err != nil {
+		return nil, fmt.Errorf("%s: %w", loadout.Name, err)
+	}
+	attune := map[*Item]bool{}
+	for _, itemName := range loadout.Attuned {
+		if item := inv.findUnpicked(itemName, attune); item != nil && RequiresAttunement(item) {
+			attune[item] = true
+		} else if item == nil {
+			missing = append(missing, itemName)
+		}
+	}
+
+	for i := range inv.Items {
+		item := &inv.Items[i]
+		item.Equipped = equip[item]
+		// Equipped items are worn or held, not packed away
+		if item.Equipped {
+			item.ContainerID = 0
+		}
+		if RequiresAttunement(item) {
+			item.Attuned = attune[item]
+		}
+	}
+	inv.ActiveLoadout = loadout.Name
+	SyncItemEffects(c)
+	return missing, nil
+}
+
+// NextLoadout swaps to the loadout after the active one, wrapping around to the first
+func (c *Character) NextLoadout() (*Loadout, []string, error) {
+	loadouts := c.Inventory.Loadouts
+	if len(loadouts) == 0 {
+		return nil, nil, fmt.Errorf("no loadouts saved")
+	}
+	next := 0
+	for i := range loadouts {
+		if strings.EqualFold(loadouts[i].Name, c.Inventory.ActiveLoadout) {
+			next = (i + 1) % len(loadouts)
+			break
+		}
+	}
+	missing, err := c.ApplyLoadout(loadouts[next].Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &c.Inventory.Loadouts[next], missing, nil
+}
+
+// findUnpicked returns the first item with a name that isn't picked yet, preferring
+// one carried directly over one packed in a container
+func (inv *Inventory) findUnpicked(name string, picked map[*Item]bool) *Item {
+	var found *Item
+	for i := range inv.Items {
+		item := &inv.Items[i]
+		if item.Name != name || picked[item] {
+			continue
+		}
+		if item.ContainerID == 0 {
+			return item
+		}
+		if found == nil {
+			found = item
+		}
+	}
+	return found
+}
@@ -0,0 +1,216 @@
+// internal/models/magic_items.go
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxAttunedItems is the number of magic items a character can be attuned to at once
+const MaxAttunedItems = 3
+
+// itemSourceType is the benefit source type of ability scores and resistances granted
+// by magic items
+const itemSourceType = "item"
+
+// ItemEffects are the mechanical effects of a magic item, applied while the item is
+// equipped (if it can be) and attuned (if it requires attunement):
+//
+//	"effects": {"ac": 1, "saves": 1}
+//	"effects": {"abilities": {"Constitution": {"set": 19}}}
+type ItemEffects struct {
+	AC          int                      `json:"ac,omitempty"`          // Bonus to AC
+	Saves       int                      `json:"saves,omitempty"`       // Bonus to every saving throw
+	Abilities   map[string]AbilityEffect `json:"abilities,omitempty"`   // Ability scores set or increased
+	Resistances []string                 `json:"resistances,omitempty"` // Damage resistances, e.g. "Fire"
+	Speed       int                      `json:"speed,omitempty"`       // Bonus to walking speed
+	Charges     int                      `json:"charges,omitempty"`     // Maximum charges
+	Recharge    string                   `json:"recharge,omitempty"`    // Charges regained at dawn, e.g. "1d6+1"
+	DestroyOn   int                      `json:"destroy_on,omitempty"`  // d20 result at or below which spending the last charge destroys the item
+}
+
+// AbilityEffect sets an ability score (it has no effect if the score is already as high)
+// or increases it, up to Max (default 20)
+type AbilityEffect struct {
+	Set   int `json:"set,omitempty"`
+	Bonus int `json:"bonus,omitempty"`
+	Max   int `json:"max,omitempty"`
+}
+
+// String describes the effects, e.g. "+1 AC, +1 saving throws"
+func (e ItemEffects) String() string {
+	var parts []string
+	if e.AC != 0 {
+		parts = append(parts, fmt.Sprintf("%+d AC", e.AC))
+	}
+	if e.Saves != 0 {
+		parts = append(parts, fmt.Sprintf("%+d saving throws", e.Saves))
+	}
+	for _, name := range sortedAbilities(e.Abilities) {
+		effect := e.Abilities[name]
+		if effect.Set > 0 {
+			parts = append(parts, fmt.Sprintf("%s becomes %d", name, effect.Set))
+		}
+		if effect.Bonus != 0 {
+			parts = append(parts, fmt.Sprintf("%+d %s", effect.Bonus, name))
+		}
+	}
+	for _, resistance := range e.Resistances {
+		parts = append(parts, resistance+" resistance")
+	}
+	if e.Speed != 0 {
+		parts = append(parts, fmt.Sprintf("%+d ft. speed", e.Speed))
+	}
+	if e.Charges > 0 {
+		charges := fmt.Sprintf("%d charges", e.Charges)
+		if e.Recharge != "" {
+			charges += fmt.Sprintf(" (regains %s at dawn)", e.Recharge)
+		}
+		parts = append(parts, charges)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sortedAbilities returns the ability names of an effects map in a stable order
+func sortedAbilities(abilities map[string]AbilityEffect) []string {
+	names := make([]string, 0, len(abilities))
+	for name := range abilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RequiresAttunement reports whether an inventory item must be attuned to work
+func RequiresAttunement(item *Item) bool {
+	def := GetItemDefinitionByName(item.Name)
+	return def != nil && def.RequiresAttunement
+}
+
+// ItemEffectsActive reports whether an item's effects apply: it must be equipped if it
+// can be, and attuned if it requires attunement
+func ItemEffectsActive(item *Item) bool {
+	def := GetItemDefinitionByName(item.Name)
+	if def == nil || def.Effects == nil {
+		return false
+	}
+	if IsEquippable(*def) && !item.Equipped {
+		return false
+	}
+	return !def.RequiresAttunement || item.Attuned
+}
+
+// AttunedItems returns the items the character is attuned to
+func (inv *Inventory) AttunedItems() []*Item {
+	var attuned []*Item
+	for i := range inv.Items {
+		if inv.Items[i].Attuned {
+			attuned = append(attuned, &inv.Items[i])
+		}
+	}
+	return attuned
+}
+
+// Attune attunes the character to an item, using one of their attunement slots
+func (c *Character) Attune(item *Item) error {
+	if !RequiresAttunement(item) {
+		return fmt.Errorf("%s doesn't require attunement", item.Name)
+	}
+	if item.Attuned {
+		return fmt.Errorf("already attuned to %s", item.Name)
+	}
+	if attuned := c.Inventory.AttunedItems(); len(attuned) >= MaxAttunedItems {
+		names := make([]string, len(attuned))
+		for i, other := range attuned {
+			names[i] = other.Name
+		}
+		return fmt.Errorf("already attuned to %d items (%s); end an attunement first",
+			MaxAttunedItems, strings.Join(names, ", "))
+	}
+	item.Attuned = true
+	SyncItemEffects(c)
+	return nil
+}
+
+// Unattune ends the character's attunement to an item, freeing the slot
+func (c *Character) Unattune(item *Item) error {
+	if !item.Attuned {
+		return fmt.Errorf("not attuned to %s", item.Name)
+	}
+	item.Attuned = false
+	SyncItemEffects(c)
+	return nil
+}
+
+// SyncItemEffects reapplies the ability scores and resistances of the magic items whose
+// effects are active, after items are equipped, attuned or removed. AC, saving throw and
+// speed bonuses are evaluated from the inventory by the modifier engine.
+func SyncItemEffects(char *Character) {
+	remover := NewBenefitRemover(char)
+	sources := map[string]bool{}
+	for _, benefit := range char.BenefitTracker.Benefits {
+		if benefit.Source.Type == itemSourceType {
+			sources[benefit.Source.Name] = true
+		}
+	}
+	for name := range sources {
+		remover.RemoveAllBenefits(itemSourceType, name)
+	}
+
+	applier := NewBenefitApplier(char)
+	for i := range char.Inventory.Items {
+		item := &char.Inventory.Items[i]
+		if !ItemEffectsActive(item) {
+			continue
+		}
+		effects := GetItemDefinitionByName(item.Name).Effects
+		source := BenefitSource{Type: itemSourceType, Name: item.Name}
+		for _, name := range sortedAbilities(effects.Abilities) {
+			effect := effects.Abilities[name]
+			ability, ok := ParseAbilityType(name)
+			if !ok {
+				continue
+			}
+			if effect.Bonus > 0 {
+				maxScore := effect.Max
+				if maxScore == 0 {
+					maxScore = AbilityScoreMax
+				}
+				applier.AddAbilityScoreUpTo(source, ability.FullName(), effect.Bonus, maxScore)
+			}
+			if gain := effect.Set - char.AbilityScores.GetScore(ability); gain > 0 {
+				applier.AddAbilityScoreUpTo(source, ability.FullName(), gain, effect.Set)
+			}
+		}
+		for _, resistance := range effects.Resistances {
+			applier.AddResistance(source, resistance)
+		}
+	}
+	char.UpdateDerivedStats()
+}
+
+// itemModifiers are the AC, saving throw and speed bonuses of active magic items
+func itemModifiers(char *Character) []Modifier {
+	var mods []Modifier
+	for i := range char.Inventory.Items {
+		item := &char.Inventory.Items[i]
+		if !ItemEffectsActive(item) {
+			continue
+		}
+		effects := GetItemDefinitionByName(item.Name).Effects
+		source := "Item: " + item.Name
+		if effects.AC != 0 {
+			mods = append(mods, Modifier{Target: TargetAC, Value: effects.AC, Source: source})
+		}
+		if effects.Saves != 0 {
+			for _, ability := range allAbilities {
+				mods = append(mods, Modifier{Target: SaveTarget(ability), Value: effects.Saves, Source: source})
+			}
+		}
+		if effects.Speed != 0 {
+			mods = append(mods, Modifier{Target: TargetSpeed, Value: effects.Speed, Source: source})
+		}
+	}
+	return mods
+}
@@ -0,0 +1,508 @@
+// internal/models/modifiers.go
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Derived stats modifiers apply to. Ability scores use the ability's full name ("Strength").
+const (
+	TargetAC                   = "ac"
+	TargetInitiative           = "initiative"
+	TargetSpeed                = "speed"
+	TargetMaxHP                = "max_hp"
+	TargetPassivePerception    = "passive_perception"
+	TargetPassiveInvestigation = "passive_investigation"
+	TargetPassiveInsight       = "passive_insight"
+	TargetSpellSaveDC          = "spell_save_dc"
+	TargetSpellAttack          = "spell_attack"
+)
+
+// allAbilities lists the six abilities in sheet order
+var allAbilities = []AbilityType{Strength, Dexterity, Constitution, Intelligence, Wisdom, Charisma}
+
+// SaveTarget returns the target of an ability's saving throw, e.g. "dexterity_save"
+func SaveTarget(ability AbilityType) string {
+	return strings.ToLower(ability.FullName()) + "_save"
+}
+
+// targetLabels are the names targets are displayed with
+var targetLabels = map[string]string{
+	TargetAC:                   "Armor Class",
+	TargetInitiative:           "Initiative",
+	TargetSpeed:                "Speed",
+	TargetMaxHP:                "Max HP",
+	TargetPassivePerception:    "Passive Perception",
+	TargetPassiveInvestigation: "Passive Investigation",
+	TargetPassiveInsight:       "Passive Insight",
+	TargetSpellSaveDC:          "Spell Save DC",
+	TargetSpellAttack:          "Spell Attack",
+}
+
+// passiveSkills maps the passive targets to their skill
+var passiveSkills = map[string]SkillType{
+	TargetPassivePerception:    Perception,
+	TargetPassiveInvestigation: Investigation,
+	TargetPassiveInsight:       Insight,
+}
+
+// TargetLabel returns the display name of a target
+func TargetLabel(target string) string {
+	if label, ok := targetLabels[target]; ok {
+		return label
+	}
+	if name, ok := strings.CutSuffix(target, "_save"); ok {
+		if ability, ok := ParseAbilityType(name); ok {
+			return ability.FullName() + " Save"
+		}
+	}
+	return target
+}
+
+// DerivedTargets lists the targets shown on the character sheet, in display order
+func DerivedTargets() []string {
+	targets := []string{TargetAC, TargetInitiative, TargetSpeed, TargetMaxHP,
+		TargetPassivePerception, TargetPassiveInvestigation, TargetPassiveInsight,
+		TargetSpellSaveDC, TargetSpellAttack}
+	for _, ability := range allAbilities {
+		targets = append(targets, ability.FullName())
+	}
+	for _, ability := range allAbilities {
+		targets = append(targets, SaveTarget(ability))
+	}
+	return targets
+}
+
+// ModifierKind tells how a modifier combines with the others on its target
+type ModifierKind string
+
+const (
+	ModifierBase    ModifierKind = "base"    // Starting value; only the highest base applies
+	ModifierBonus   ModifierKind = "bonus"   // Added; bonuses with the same name don't stack, the highest applies
+	ModifierMinimum ModifierKind = "minimum" // The total is at least this value (e.g. Gauntlets of Ogre Power)
+)
+
+// Modifier is a typed contribution of a source to a derived stat
+type Modifier struct {
+	Target string       `json:"target"`
+	Kind   ModifierKind `json:"kind,omitempty"` // Bonus if empty
+	Value  int          `json:"value"`
+	Name   string       `json:"name,omitempty"`   // Effect name for stacking; unnamed bonuses always stack
+	Source string       `json:"source,omitempty"` // Where it comes from, e.g. "Feat: Alert"
+}
+
+func (m Modifier) kind() ModifierKind {
+	if m.Kind == "" {
+		return ModifierBonus
+	}
+	return m.Kind
+}
+
+// Effect is an active effect on the character, such as a spell or condition, that
+// contributes modifiers until it is removed
+type Effect struct {
+	Name        string     `json:"name"`
+	Source      string     `json:"source,omitempty"`
+	Modifiers   []Modifier `json:"modifiers,omitempty"`
+	ACFormula   *ACFormula `json:"ac_formula,omitempty"`  // Base AC while unarmored (Mage Armor)
+	Resistances []string   `json:"resistances,omitempty"` // Damage resistances while active
+	Duration    string     `json:"duration,omitempty"`    // e.g. "1 hour"; effects end on a long rest at the latest
+}
+
+// effectSourceType is the benefit source type of resistances granted by active effects
+const effectSourceType = "effect"
+
+// AddEffect activates an effect; an effect with the same name is replaced
+func (c *Character) AddEffect(effect Effect) {
+	c.RemoveEffect(effect.Name)
+	c.Effects = append(c.Effects, effect)
+	if len(effect.Resistances) > 0 {
+		applier := NewBenefitApplier(c)
+		for _, resistance := range effect.Resistances {
+			applier.AddResistance(BenefitSource{Type: effectSourceType, Name: effect.Name}, resistance)
+		}
+	}
+	c.UpdateDerivedStats()
+}
+
+// RemoveEffect ends an effect by name, reporting whether it was active
+func (c *Character) RemoveEffect(name string) bool {
+	for i, effect := range c.Effects {
+		if strings.EqualFold(effect.Name, name) {
+			c.Effects = append(c.Effects[:i], c.Effects[i+1:]...)
+			if len(effect.Resistances) > 0 {
+				NewBenefitRemover(c).RemoveAllBenefits(effectSourceType, effect.Name)
+			}
+			c.UpdateDerivedStats()
+			return true
+		}
+	}
+	return false
+}
+
+// EndEffects ends every active effect
+func (c *Character) EndEffects() {
+	for len(c.Effects) > 0 {
+		c.RemoveEffect(c.Effects[0].Name)
+	}
+}
+
+// ModifierLine is a modifier in a breakdown, with whether it counted toward the total
+type ModifierLine struct {
+	Modifier
+	Applied bool
+	Note    string // Why it didn't apply, or how it changed the total
+}
+
+// Breakdown explains how a derived stat's total was reached
+type Breakdown struct {
+	Target string
+	Total  int
+	Lines  []ModifierLine
+}
+
+// EvaluateModifiers totals the modifiers on a target: the highest base, plus every
+// bonus (only the highest of same-named bonuses), raised to the highest minimum
+func EvaluateModifiers(target string, modifiers []Modifier) Breakdown {
+	breakdown := Breakdown{Target: target}
+
+	var mods []Modifier
+	for _, m := range modifiers {
+		if m.Target == target {
+			mods = append(mods, m)
+		}
+	}
+
+	// Pick the highest base, the highest bonus of each name and the highest minimum
+	base, minimum := -1, -1
+	named := map[string]int{}
+	for i, m := range mods {
+		switch m.kind() {
+		case ModifierBase:
+			if base < 0 || m.Value > mods[base].Value {
+				base = i
+			}
+		case ModifierBonus:
+			if m.Name == "" {
+				continue
+			}
+			key := strings.ToLower(m.Name)
+			if best, ok := named[key]; !ok || m.Value > mods[best].Value {
+				named[key] = i
+			}
+		case ModifierMinimum:
+			if minimum < 0 || m.Value > mods[minimum].Value {
+				minimum = i
+			}
+		}
+	}
+
+	for i, m := range mods {
+		line := ModifierLine{Modifier: m, Applied: true}
+		switch m.kind() {
+		case ModifierBase:
+			if i != base {
+				line.Applied = false
+				line.Note = fmt.Sprintf("%s is higher", mods[base].Source)
+			} else {
+				breakdown.Total += m.Value
+			}
+		case ModifierBonus:
+			if best, ok := named[strings.ToLower(m.Name)]; m.Name != "" && ok && best != i {
+				line.Applied = false
+				line.Note = fmt.Sprintf("doesn't stack with %s", mods[best].Source)
+			} else {
+				breakdown.Total += m.Value
+			}
+		}
+		breakdown.Lines = append(breakdown.Lines, line)
+	}
+
+	// Minimums apply last, to the total of everything else
+	for i := range breakdown.Lines {
+		line := &breakdown.Lines[i]
+		if line.kind() != ModifierMinimum {
+			continue
+		}
+		switch {
+		case i != minimum:
+			line.Applied = false
+			line.Note = fmt.Sprintf("%s is higher", mods[minimum].Source)
+		case line.Value > breakdown.Total:
+			line.Note = fmt.Sprintf("raises %d to %d", breakdown.Total, line.Value)
+			breakdown.Total = line.Value
+		default:
+			line.Applied = false
+			line.Note = "the total is already higher"
+		}
+	}
+
+	return breakdown
+}
+
+// Breakdown explains a derived stat of the character
+func (c *Character) Breakdown(target string) Breakdown {
+	return EvaluateModifiers(target, c.Modifiers())
+}
+
+// Modifiers collects the modifiers of every source: ability scores, armor, hit points,
+// skills, saving throws, spellcasting, magic items, tracked benefits (species, feats,
+// class features) and active effects
+func (c *Character) Modifiers() []Modifier {
+	var mods []Modifier
+	mods = append(mods, c.abilityModifiers()...)
+	mods = append(mods, armorModifiers(c)...)
+	mods = append(mods,
+		Modifier{Target: TargetInitiative, Kind: ModifierBase, Value: c.AbilityScores.GetModifier(Dexterity), Source: "Dexterity modifier"},
+		Modifier{Target: TargetSpeed, Kind: ModifierBase, Value: c.baseSpeed(), Source: "Species: " + c.Race},
+	)
+	if class := GetClassByName(c.Class); class != nil {
+		mods = append(mods, hitPointModifiers(c, class)...)
+	} else {
+		// Without the class's hit die, the recorded max HP stands in for the levels
+		recorded := c.MaxHP
+		if c.BenefitTracker != nil {
+			for _, benefit := range c.BenefitTracker.GetBenefitsByType(BenefitHP) {
+				recorded -= benefit.Value
+			}
+		}
+		mods = append(mods, Modifier{Target: TargetMaxHP, Kind: ModifierBase, Value: recorded, Source: "Recorded max HP"})
+	}
+	for _, target := range []string{TargetPassivePerception, TargetPassiveInvestigation, TargetPassiveInsight} {
+		mods = append(mods, c.passiveModifiers(target)...)
+	}
+	mods = append(mods, c.savingThrowModifiers()...)
+	mods = append(mods, c.spellcastingModifiers()...)
+	mods = append(mods, itemModifiers(c)...)
+	mods = append(mods, c.grantedModifiers()...)
+	return mods
+}
+
+// grantedModifiers are the modifiers of tracked benefits and active effects
+func (c *Character) grantedModifiers() []Modifier {
+	var mods []Modifier
+	if c.BenefitTracker != nil {
+		for _, benefit := range c.BenefitTracker.Benefits {
+			target := benefitTarget(benefit)
+			if target == "" || benefit.Value == 0 {
+				continue
+			}
+			mods = append(mods, Modifier{Target: target, Value: benefit.Value, Source: benefit.Source.Label()})
+		}
+	}
+	for _, effect := range c.Effects {
+		for _, m := range effect.Modifiers {
+			if m.Name == "" {
+				m.Name = effect.Name
+			}
+			if m.Source == "" {
+				m.Source = effect.Source
+			}
+			if m.Source == "" {
+				m.Source = "Effect: " + effect.Name
+			}
+			mods = append(mods, m)
+		}
+	}
+	return mods
+}
+
+// benefitTarget returns the target a tracked benefit modifies, or "" if it isn't numeric
+func benefitTarget(benefit GrantedBenefit) string {
+	switch benefit.Type {
+	case BenefitAbilityScore:
+		if ability, ok := ParseAbilityType(benefit.Target); ok {
+			return ability.FullName()
+		}
+	case BenefitSpeed:
+		return TargetSpeed
+	case BenefitHP:
+		return TargetMaxHP
+	case BenefitInitiative:
+		return TargetInitiative
+	case BenefitAC:
+		return TargetAC
+	case BenefitPassive:
+		return "passive_" + strings.ToLower(benefit.Target)
+	}
+	return ""
+}
+
+// abilityModifiers explain the stored ability scores: the score not accounted for by
+// tracked increases and manual adjustments is the base score
+func (c *Character) abilityModifiers() []Modifier {
+	tracked := map[string]int{}
+	if c.BenefitTracker != nil {
+		for _, benefit := range c.BenefitTracker.GetBenefitsByType(BenefitAbilityScore) {
+			tracked[benefitTarget(benefit)] += benefit.Value
+		}
+	}
+
+	var mods []Modifier
+	for _, ability := range allAbilities {
+		target := ability.FullName()
+		extra := c.AbilityScores.GetExtraScore(ability)
+		base := c.AbilityScores.GetScore(ability) - extra - tracked[target]
+		mods = append(mods, Modifier{Target: target, Kind: ModifierBase, Value: base, Source: "Base score"})
+		if extra != 0 {
+			mods = append(mods, Modifier{Target: target, Value: extra, Source: "Manual adjustment"})
+		}
+	}
+	return mods
+}
+
+// armorModifiers are the base AC of the worn armor (or none) and a shield's bonus
+func armorModifiers(char *Character) []Modifier {
+	dexMod := char.AbilityScores.GetModifier(Dexterity)
+	mods := []Modifier{}
+	armored, shield := false, false
+
+	for i := range char.Inventory.Items {
+		item := &char.Inventory.Items[i]
+		if !item.Equipped || item.Type != Armor {
+			continue
+		}
+		itemDef := GetItemDefinitionByName(item.Name)
+		if itemDef == nil {
+			continue
+		}
+
+		armorBaseAC := parseArmorAC(itemDef.AC)
+		switch getArmorType(itemDef.Subcategory) {
+		case ArmorTypeShield:
+			shield = true
+			mods = append(mods, Modifier{Target: TargetAC, Value: 2, Name: "Shield", Source: item.Name})
+		case ArmorTypeLight:
+			// Light armor: Base AC + full Dex modifier
+			armored = true
+			mods = append(mods, Modifier{Target: TargetAC, Kind: ModifierBase, Value: armorBaseAC + dexMod,
+				Source: fmt.Sprintf("%s (%d + Dex %+d)", item.Name, armorBaseAC, dexMod)})
+		case ArmorTypeMedium:
+			// Medium armor: Base AC + Dex modifier (max +2)
+			armored = true
+			dexBonus := min(dexMod, 2)
+			mods = append(mods, Modifier{Target: TargetAC, Kind: ModifierBase, Value: armorBaseAC + dexBonus,
+				Source: fmt.Sprintf("%s (%d + Dex %+d, max 2)", item.Name, armorBaseAC, dexBonus)})
+		case ArmorTypeHeavy:
+			// Heavy armor: Base AC only (no Dex modifier)
+			armored = true
+			mods = append(mods, Modifier{Target: TargetAC, Kind: ModifierBase, Value: armorBaseAC, Source: item.Name})
+		}
+	}
+
+	if !armored {
+		// Unarmored: 10 + Dex modifier, or a formula from a feature, trait or spell;
+		// the highest base applies
+		mods = append(mods, Modifier{Target: TargetAC, Kind: ModifierBase, Value: 10 + dexMod,
+			Source: fmt.Sprintf("Unarmored (10 + Dex %+d)", dexMod)})
+		for _, formula := range CharacterACFormulas(char) {
+			if formula.NoShield && shield {
+				continue
+			}
+			mods = append(mods, Modifier{Target: TargetAC, Kind: ModifierBase, Value: formula.Value(char),
+				Source: fmt.Sprintf("%s (%s)", formula.Source, formula.Describe(char))})
+		}
+	}
+	return mods
+}
+
+// hitPointModifiers are the hit points of each level from the HP history, and the
+// species bonus. Levels without an entry take the maximum hit die at 1st level and
+// the fixed value after.
+func hitPointModifiers(char *Character, class *Class) []Modifier {
+	conModifier := char.AbilityScores.GetModifier(Constitution)
+	var mods []Modifier
+	for lvl := 1; lvl <= max(char.Level, 1); lvl++ {
+		entry := char.GetHPEntry(lvl)
+		if entry == nil {
+			fixed, _ := NewHPEntry(lvl, class.Name, class.HitDie, 0)
+			entry = &fixed
+		}
+		mods = append(mods, Modifier{Target: TargetMaxHP, Value: entry.HitPoints(conModifier),
+			Source: fmt.Sprintf("%s, Con %+d", entry, conModifier)})
+	}
+	if char.SpeciesHPBonus != 0 {
+		mods = append(mods, Modifier{Target: TargetMaxHP, Value: char.SpeciesHPBonus, Source: "Species: " + char.Race})
+	}
+	return mods
+}
+
+// passiveModifiers are the 10 and skill bonus of a passive score
+func (c *Character) passiveModifiers(target string) []Modifier {
+	mods := []Modifier{{Target: target, Kind: ModifierBase, Value: 10, Source: "Passive base"}}
+	skill := c.Skills.GetSkill(passiveSkills[target])
+	if skill == nil {
+		return mods
+	}
+	bonus := skill.CalculateBonus(c.AbilityScores.GetModifier(skill.Ability), c.ProficiencyBonus)
+	return append(mods, Modifier{Target: target, Value: bonus, Source: string(skill.Name) + " bonus"})
+}
+
+// savingThrowModifiers are the ability modifier and proficiency bonus of each saving throw
+func (c *Character) savingThrowModifiers() []Modifier {
+	var mods []Modifier
+	for _, ability := range allAbilities {
+		target := SaveTarget(ability)
+		mods = append(mods, Modifier{Target: target, Kind: ModifierBase, Value: c.AbilityScores.GetModifier(ability),
+			Source: ability.FullName() + " modifier"})
+		if c.IsSaveProficient(ability) {
+			mods = append(mods, Modifier{Target: target, Value: c.ProficiencyBonus, Source: "Proficiency bonus"})
+		}
+	}
+	return mods
+}
+
+// IsSaveProficient reports whether the character is proficient in an ability's saving throw
+func (c *Character) IsSaveProficient(ability AbilityType) bool {
+	for _, prof := range c.SavingThrowProficiencies {
+		if a, ok := ParseAbilityType(prof); ok && a == ability {
+			return true
+		}
+	}
+	return false
+}
+
+// SavingThrowBonus returns the bonus added to an ability's saving throw rolls
+func (c *Character) SavingThrowBonus(ability AbilityType) int {
+	return c.Breakdown(SaveTarget(ability)).Total
+}
+
+// spellcastingModifiers are the parts of the spell save DC and attack bonus
+func (c *Character) spellcastingModifiers() []Modifier {
+	if c.SpellBook.SpellcastingMod == "" {
+		return nil
+	}
+	mod := c.AbilityScores.GetModifier(c.SpellBook.SpellcastingMod)
+	abilityLabel := c.SpellBook.SpellcastingMod.FullName() + " modifier"
+	return []Modifier{
+		{Target: TargetSpellSaveDC, Kind: ModifierBase, Value: 8, Source: "Spell save base"},
+		{Target: TargetSpellSaveDC, Value: c.ProficiencyBonus, Source: "Proficiency bonus"},
+		{Target: TargetSpellSaveDC, Value: mod, Source: abilityLabel},
+		{Target: TargetSpellAttack, Value: c.ProficiencyBonus, Source: "Proficiency bonus"},
+		{Target: TargetSpellAttack, Value: mod, Source: abilityLabel},
+	}
+}
+
+// baseSpeed is the species speed; older characters recorded only the total, which
+// includes the tracked speed bonuses
+func (c *Character) baseSpeed() int {
+	if c.BaseSpeed > 0 {
+		return c.BaseSpeed
+	}
+	speed := c.Speed
+	if c.BenefitTracker != nil {
+		for _, benefit := range c.BenefitTracker.GetBenefitsByType(BenefitSpeed) {
+			speed -= benefit.Value
+		}
+	}
+	return speed
+}
+
+// EnsureBaseSpeed records the species speed of characters that predate it, before
+// their speed bonuses change
+func EnsureBaseSpeed(char *Character) {
+	if char.BaseSpeed == 0 {
+		char.BaseSpeed = char.baseSpeed()
+	}
+}
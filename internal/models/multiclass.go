@@ -0,0 +1,309 @@
+// internal/models/multiclass.go
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ClassLevels records the levels a character has in one class
+type ClassLevels struct {
+	Class    string `json:"class"`
+	Level    int    `json:"level"`
+	Subclass string `json:"subclass,omitempty"` // Chosen at the class's subclass_choice level
+}
+
+// MulticlassInfo declares what a class requires and grants when a character multiclasses into it (PHB p.163)
+type MulticlassInfo struct {
+	// Prerequisites lists alternative groups of minimum scores; the character must meet
+	// every score of at least one group, e.g. [{"Strength": 13}, {"Dexterity": 13}]
+	Prerequisites       []map[string]int `json:"prerequisites"`
+	ArmorProficiencies  []string         `json:"armor_proficiencies,omitempty"`
+	WeaponProficiencies []string         `json:"weapon_proficiencies,omitempty"`
+	ToolProficiencies   []string         `json:"tool_proficiencies,omitempty"`
+	SkillChoices        *SkillChoiceInfo `json:"skill_choices,omitempty"`
+}
+
+// MulticlassSpellSlots is the multiclass spellcaster table (PHB p.165), indexed by
+// caster level; each row lists the slots of spell levels 1 to 9
+var MulticlassSpellSlots = [21][9]int{
+	{},
+	{2},
+	{3},
+	{4, 2},
+	{4, 3},
+	{4, 3, 2},
+	{4, 3, 3},
+	{4, 3, 3, 1},
+	{4, 3, 3, 2},
+	{4, 3, 3, 3, 1},
+	{4, 3, 3, 3, 2},
+	{4, 3, 3, 3, 2, 1},
+	{4, 3, 3, 3, 2, 1},
+	{4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 3, 2, 2, 1, 1},
+}
+
+// GetClasses returns the character's class levels. A single class always has the
+// character's full level, including characters saved before multiclassing.
+func (c *Character) GetClasses() []ClassLevels {
+	switch {
+	case len(c.Classes) == 0 && c.Class != "":
+		return []ClassLevels{{Class: c.Class, Level: max(c.Level, 1)}}
+	case len(c.Classes) == 1:
+		single := c.Classes[0]
+		single.Level = max(c.Level, 1)
+		return []ClassLevels{single}
+	}
+	return c.Classes
+}
+
+// GetClassLevels returns the entry for a class the character has levels in, or nil.
+// Call EnsureClassLevels first for characters saved before multiclassing.
+func (c *Character) GetClassLevels(className string) *ClassLevels {
+	for i := range c.Classes {
+		if strings.EqualFold(c.Classes[i].Class, className) {
+			return &c.Classes[i]
+		}
+	}
+	return nil
+}
+
+// ClassLevel returns the character's level in a class, 0 if it has none
+func (c *Character) ClassLevel(className string) int {
+	for _, cl := range c.GetClasses() {
+		if strings.EqualFold(cl.Class, className) {
+			return cl.Level
+		}
+	}
+	return 0
+}
+
+// IsMulticlassed returns true if the character has levels in more than one class
+func (c *Character) IsMulticlassed() bool {
+	return len(c.GetClasses()) > 1
+}
+
+// ClassSummary formats the class levels for display, e.g. "Fighter 2 / Wizard 3"
+func (c *Character) ClassSummary() string {
+	var parts []string
+	for _, cl := range c.GetClasses() {
+		part := fmt.Sprintf("%s %d", cl.Class, cl.Level)
+		if cl.Subclass != "" {
+			part += fmt.Sprintf(" (%s)", cl.Subclass)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " / ")
+}
+
+// ClassAtLevel returns the class that gained a character level and the level reached in
+// that class, from the HP history. Levels without an entry belong to the starting class.
+func (c *Character) ClassAtLevel(level int) (string, int) {
+	className := c.Class
+	if entry := c.GetHPEntry(level); entry != nil && entry.Class != "" {
+		className = entry.Class
+	}
+
+	classLevel := 0
+	for lvl := 1; lvl <= level; lvl++ {
+		lvlClass := c.Class
+		if entry := c.GetHPEntry(lvl); entry != nil && entry.Class != "" {
+			lvlClass = entry.Class
+		}
+		if lvlClass == className {
+			classLevel++
+		}
+	}
+	return className, classLevel
+}
+
+// EnsureClassLevels records the starting class at the character's level for
+// characters saved before multiclassing, and keeps a single class at the full level
+func EnsureClassLevels(char *Character) {
+	if len(char.Classes) == 0 && char.Class != "" {
+		char.Classes = []ClassLevels{{Class: char.Class, Level: max(char.Level, 1)}}
+	} else if len(char.Classes) == 1 {
+		char.Classes[0].Level = max(char.Level, 1)
+	}
+}
+
+// CheckMulticlassPrerequisites checks that the character meets the ability prerequisites
+// of its current classes and of the class it wants to multiclass into
+func CheckMulticlassPrerequisites(char *Character, className string) error {
+	newClass := GetClassByName(className)
+	if newClass == nil {
+		return fmt.Errorf("class %s not found", className)
+	}
+	if char.ClassLevel(className) > 0 {
+		return fmt.Errorf("already has levels in %s", className)
+	}
+
+	classes := []*Class{newClass}
+	for _, cl := range char.GetClasses() {
+		if class := GetClassByName(cl.Class); class != nil {
+			classes = append(classes, class)
+		}
+	}
+
+	for _, class := range classes {
+		if class.Multiclass == nil || len(class.Multiclass.Prerequisites) == 0 {
+			continue
+		}
+		if !meetsAnyGroup(char, class.Multiclass.Prerequisites) {
+			return fmt.Errorf("multiclassing requires %s for %s", DescribeMulticlassPrerequisites(class), class.Name)
+		}
+	}
+	return nil
+}
+
+// DescribeMulticlassPrerequisites formats a class's prerequisites, e.g. "Strength 13 or Dexterity 13"
+func DescribeMulticlassPrerequisites(class *Class) string {
+	if class.Multiclass == nil || len(class.Multiclass.Prerequisites) == 0 {
+		return "nothing"
+	}
+	var groups []string
+	for _, group := range class.Multiclass.Prerequisites {
+		var scores []string
+		for ability, minimum := range group {
+			scores = append(scores, fmt.Sprintf("%s %d", ability, minimum))
+		}
+		sort.Strings(scores)
+		groups = append(groups, strings.Join(scores, " and "))
+	}
+	return strings.Join(groups, " or ")
+}
+
+// meetsAnyGroup checks if every score of at least one group is met
+func meetsAnyGroup(char *Character, groups []map[string]int) bool {
+	for _, group := range groups {
+		met := true
+		for abilityName, minimum := range group {
+			ability, ok := ParseAbilityType(abilityName)
+			if !ok || char.AbilityScores.GetScore(ability) < minimum {
+				met = false
+				break
+			}
+		}
+		if met {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyMulticlassProficiencies grants the restricted proficiencies of a class taken as a
+// multiclass (no saving throws, a subset of armor, weapons and tools) plus the chosen skills,
+// tracked under the class's first level
+func ApplyMulticlassProficiencies(char *Character, class *Class, skills []string) {
+	if class.Multiclass == nil {
+		return
+	}
+	source := LevelSource(class.Name, 1)
+	applier := NewBenefitApplier(char)
+
+	for _, armor := range class.Multiclass.ArmorProficiencies {
+		applier.AddArmorProficiency(source, armor)
+	}
+	for _, weapon := range class.Multiclass.WeaponProficiencies {
+		applier.AddWeaponProficiency(source, weapon)
+	}
+	for _, tool := range class.Multiclass.ToolProficiencies {
+		applier.AddToolProficiency(source, tool)
+	}
+	for _, skill := range skills {
+		applier.AddSkillProficiency(source, skill)
+	}
+}
+
+// casterLevel returns the character's multiclass spellcaster level: full casters count
+// all their levels, half casters half (rounded up, 2024 PHB). Pact Magic levels don't
+// count; their slots are a pool of their own (CalculatePactSlots).
+func casterLevel(char *Character) int {
+	level := 0
+	for _, cl := range char.GetClasses() {
+		class := GetClassByName(cl.Class)
+		if class == nil || class.Spellcasting == nil || class.Spellcasting.PactMagic {
+			continue
+		}
+		if class.Spellcasting.HalfCaster {
+			level += (cl.Level + 1) / 2
+		} else {
+			level += cl.Level
+		}
+	}
+	return level
+}
+
+// CalculateSpellSlots returns the character's spell slots by level. A single class uses
+// its own table; several spellcasting classes combine through the multiclass table.
+// Pact Magic slots are not included, see CalculatePactSlots.
+func CalculateSpellSlots(char *Character) map[int]int {
+	slots := map[int]int{}
+
+	var casters []ClassLevels
+	for _, cl := range char.GetClasses() {
+		class := GetClassByName(cl.Class)
+		if class == nil || class.Spellcasting == nil || class.Spellcasting.PactMagic {
+			continue
+		}
+		casters = append(casters, cl)
+	}
+
+	if len(casters) == 1 {
+		class := GetClassByName(casters[0].Class)
+		if entry := class.GetLevel(casters[0].Level); entry != nil && entry.SpellcastingInfo != nil {
+			for slotLevel, count := range entry.SpellcastingInfo.Slots() {
+				slots[slotLevel] += count
+			}
+		}
+	} else if len(casters) > 1 {
+		row := MulticlassSpellSlots[min(casterLevel(char), 20)]
+		for i, count := range row {
+			if count > 0 {
+				slots[i+1] += count
+			}
+		}
+	}
+	return slots
+}
+
+// CalculatePactSlots returns the number of Pact Magic slots and their level, which are
+// kept apart from the other slots because they come back on a short rest
+func CalculatePactSlots(char *Character) (count, level int) {
+	for _, cl := range char.GetClasses() {
+		class := GetClassByName(cl.Class)
+		if class == nil || class.Spellcasting == nil || !class.Spellcasting.PactMagic {
+			continue
+		}
+		if entry := class.GetLevel(cl.Level); entry != nil && entry.SpellcastingInfo != nil {
+			for slotLevel, n := range entry.SpellcastingInfo.Slots() {
+				count, level = n, slotLevel
+			}
+		}
+	}
+	return count, level
+}
+
+// EnsurePactSlots moves the Pact Magic slots of older saves, which shared the other
+// slots, into their own pool
+func EnsurePactSlots(char *Character) {
+	count, level := CalculatePactSlots(char)
+	if count == 0 || char.SpellBook.PactSlots.Maximum > 0 {
+		return
+	}
+	slots := CalculateSpellSlots(char)
+	for slotLevel := 1; slotLevel <= 9; slotLevel++ {
+		slot := char.SpellBook.GetSlotByLevel(slotLevel)
+		slot.Maximum = slots[slotLevel]
+		slot.Current = min(slot.Current, slot.Maximum)
+	}
+	char.SpellBook.PactSlots = PactSlots{SpellSlot: SpellSlot{Maximum: count, Current: count}, Level: level}
+}
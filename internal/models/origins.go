@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
 )
 
 // Origin represents a character origin from D&D 5e 2024
@@ -34,6 +36,7 @@ func LoadOriginsFromJSON(filepath string) (*OriginsData, error) {
 
 	var originsData OriginsData
 	if err := json.Unmarshal(data, &originsData); err != nil {
+		diagnostics.ReportJSONError(filepath, data, err)
 		return nil, fmt.Errorf("failed to parse origins JSON: %w", err)
 	}
 
@@ -46,6 +49,7 @@ func GetAllOrigins() []Origin {
 	if cachedOrigins == nil {
 		_, err := LoadOriginsFromJSON("data/origins.json")
 		if err != nil {
+			diagnostics.Errorf("data/origins.json", 0, "%v", err)
 			return []Origin{}
 		}
 	}
@@ -108,10 +112,7 @@ func ApplyOriginBenefits(char *Character, origin Origin, chosenAbility string) e
 		applier.AddToolProficiency(source, tool)
 	}
 
-	// Apply equipment
-	for _, equipmentItem := range origin.Equipment {
-		applier.AddItem(source, equipmentItem, 1)
-	}
+	// Equipment is chosen separately, see OriginStartingEquipment
 
 	// Apply the granted feat
 	if origin.Feat != "" {
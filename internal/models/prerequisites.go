@@ -0,0 +1,188 @@
+// internal/models/prerequisites.go
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Requirement is a node of a structured prerequisite. A node either combines other
+// nodes (all of them, or any one of them) or sets exactly one requirement.
+//
+//	{"all": [{"level": 19}, {"spellcasting": true}]}
+//	{"any": [{"ability": "Intelligence", "score": 13}, {"ability": "Wisdom", "score": 13}]}
+type Requirement struct {
+	All []Requirement `json:"all,omitempty"` // Every requirement must be met
+	Any []Requirement `json:"any,omitempty"` // At least one requirement must be met
+
+	Ability      string   `json:"ability,omitempty"`      // Ability score of at least Score
+	Score        int      `json:"score,omitempty"`        // Minimum score for Ability
+	Level        int      `json:"level,omitempty"`        // Minimum character level
+	Proficiency  string   `json:"proficiency,omitempty"`  // e.g. "Heavy Armor", "Martial Weapons", "Smith's Tools"
+	Spellcasting bool     `json:"spellcasting,omitempty"` // The ability to cast at least one spell
+	Feature      string   `json:"feature,omitempty"`      // A feature such as "Fighting Style"
+	Species      []string `json:"species,omitempty"`      // One of these species
+}
+
+// String describes the requirement, e.g. "Strength 13+" or "Level 19+ and Spellcasting"
+func (r Requirement) String() string {
+	switch {
+	case len(r.All) > 0:
+		return joinRequirements(r.All, " and ")
+	case len(r.Any) > 0:
+		return joinRequirements(r.Any, " or ")
+	case r.Ability != "":
+		return fmt.Sprintf("%s %d+", r.Ability, r.Score)
+	case r.Level > 0:
+		return fmt.Sprintf("Level %d+", r.Level)
+	case r.Proficiency != "":
+		return "Proficiency with " + r.Proficiency
+	case r.Spellcasting:
+		return "The ability to cast at least one spell"
+	case r.Feature != "":
+		return r.Feature + " feature"
+	case len(r.Species) > 0:
+		return "Species: " + strings.Join(r.Species, " or ")
+	}
+	return "None"
+}
+
+func joinRequirements(requirements []Requirement, sep string) string {
+	parts := make([]string, len(requirements))
+	for i, requirement := range requirements {
+		parts[i] = requirement.String()
+		if len(requirement.All) > 1 || len(requirement.Any) > 1 {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// Unmet returns a description of every requirement the character doesn't meet at a
+// character level, e.g. "Strength 13+ (you have 10)"; it is empty when the
+// requirement is met
+func (r Requirement) Unmet(char *Character, level int) []string {
+	switch {
+	case len(r.All) > 0:
+		var unmet []string
+		for _, requirement := range r.All {
+			unmet = append(unmet, requirement.Unmet(char, level)...)
+		}
+		return unmet
+	case len(r.Any) > 0:
+		for _, requirement := range r.Any {
+			if len(requirement.Unmet(char, level)) == 0 {
+				return nil
+			}
+		}
+		return []string{r.String()}
+	case r.Ability != "":
+		ability, ok := ParseAbilityType(r.Ability)
+		if !ok {
+			return []string{fmt.Sprintf("%s (unknown ability)", r)}
+		}
+		if score := char.AbilityScores.GetScore(ability); score < r.Score {
+			return []string{fmt.Sprintf("%s (you have %d)", r, score)}
+		}
+	case r.Level > 0:
+		if level < r.Level {
+			return []string{fmt.Sprintf("%s (you are level %d)", r, level)}
+		}
+	case r.Proficiency != "":
+		if !HasProficiency(char, r.Proficiency) {
+			return []string{r.String()}
+		}
+	case r.Spellcasting:
+		if !CanCastSpells(char) {
+			return []string{r.String()}
+		}
+	case r.Feature != "":
+		if !HasFeatureNamed(char, r.Feature) {
+			return []string{r.String()}
+		}
+	case len(r.Species) > 0:
+		if !isSpecies(char.Race, r.Species) {
+			return []string{fmt.Sprintf("%s (you are %s)", r, char.Race)}
+		}
+	}
+	return nil
+}
+
+// MinLevel returns the lowest character level that can meet the requirement, or 0 if
+// it doesn't depend on level
+func (r Requirement) MinLevel() int {
+	switch {
+	case len(r.All) > 0:
+		level := 0
+		for _, requirement := range r.All {
+			level = max(level, requirement.MinLevel())
+		}
+		return level
+	case len(r.Any) > 0:
+		level := r.Any[0].MinLevel()
+		for _, requirement := range r.Any[1:] {
+			level = min(level, requirement.MinLevel())
+		}
+		return level
+	}
+	return r.Level
+}
+
+// HasProficiency checks armor ("Heavy Armor", "Shields"), weapon ("Martial Weapons")
+// and tool proficiencies
+func HasProficiency(char *Character, proficiency string) bool {
+	name := strings.ToLower(strings.TrimSpace(proficiency))
+	if armor, ok := strings.CutSuffix(name, " armor"); ok {
+		return HasArmorProficiency(char, armor)
+	}
+	if name == "shield" || name == "shields" {
+		return HasArmorProficiency(char, "shield")
+	}
+	if weapon, ok := strings.CutSuffix(name, " weapons"); ok {
+		return HasWeaponProficiency(char, weapon)
+	}
+	for _, tool := range char.ToolProficiencies {
+		if strings.EqualFold(tool, proficiency) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanCastSpells reports whether the character has a spellcasting class or knows any
+// spell, e.g. from their species or a feat
+func CanCastSpells(char *Character) bool {
+	if len(char.SpellBook.Spells) > 0 {
+		return true
+	}
+	for _, cl := range char.GetClasses() {
+		if class := GetClassByName(cl.Class); class != nil && class.Spellcasting != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFeatureNamed reports whether the character has a feature, including one recorded
+// with its choice (e.g. "Fighting Style: Defense" for "Fighting Style")
+func HasFeatureNamed(char *Character, name string) bool {
+	for _, feature := range char.Features.Features {
+		if strings.EqualFold(feature.Name, name) ||
+			strings.HasPrefix(strings.ToLower(feature.Name), strings.ToLower(name)+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// isSpecies matches a species by name; "Elf" also matches a subtype such as "Elf (Drow)"
+func isSpecies(race string, species []string) bool {
+	race = strings.ToLower(race)
+	for _, name := range species {
+		name = strings.ToLower(name)
+		if race == name || strings.HasPrefix(race, name+" ") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+// internal/models/resources.go
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Resource is a pool of points or dice that a class spends and recovers on rest
+// (Sorcery Points, Ki, Superiority Dice, Bardic Inspiration, Lay on Hands)
+type Resource struct {
+	Name     string   `json:"name"`
+	Current  int      `json:"current"`
+	Max      int      `json:"max"`
+	Die      string   `json:"die,omitempty"` // Die size for dice pools, e.g. "d8"
+	RestType RestType `json:"rest_type"`     // When the pool recharges
+	Source   string   `json:"source"`        // e.g., "Class: Sorcerer"
+}
+
+// ResourceDefinition declares a resource in a class level_progression entry.
+// A later level can redeclare a resource with the same name to change its die or recharge.
+type ResourceDefinition struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Max         string `json:"max"`                // Number or formula: "level", "level*5", "proficiency", "charisma"
+	Die         string `json:"die,omitempty"`      // e.g. "d6"
+	Recharge    string `json:"recharge,omitempty"` // "short_rest" or "long_rest"
+}
+
+// ParseRecharge converts a data recharge key ("short_rest", "long_rest") to a RestType
+func ParseRecharge(recharge string) RestType {
+	switch strings.ToLower(strings.TrimSpace(recharge)) {
+	case "short_rest", "short rest":
+		return ShortRest
+	case "long_rest", "long rest":
+		return LongRest
+	case "daily", "dawn":
+		return Daily
+	default:
+		return None
+	}
+}
+
+// CalculateMax resolves the Max formula for a character and class level
+func (rd *ResourceDefinition) CalculateMax(char *Character, classLevel int) int {
+	formula := strings.ToLower(strings.ReplaceAll(rd.Max, " ", ""))
+	if n, err := strconv.Atoi(formula); err == nil {
+		return n
+	}
+
+	switch {
+	case formula == "level":
+		return classLevel
+	case strings.HasPrefix(formula, "level*"):
+		n, _ := strconv.Atoi(strings.TrimPrefix(formula, "level*"))
+		return classLevel * n
+	case formula == "proficiency", formula == "proficiency_bonus":
+		return char.ProficiencyBonus
+	}
+
+	if ability, ok := ParseAbilityType(formula); ok {
+		// Ability-based pools always have at least one use
+		return max(char.AbilityScores.GetModifier(ability), 1)
+	}
+	return 0
+}
+
+// GetClassResources returns the resources a class has at a given level.
+// Each resource uses its most recent declaration at or below the level.
+func GetClassResources(className string, level int) []ResourceDefinition {
+	class := GetClassByName(className)
+	if class == nil {
+		return nil
+	}
+
+	var resources []ResourceDefinition
+	for _, entry := range class.LevelProgression {
+		if entry.Level <= level {
+			resources = declareResources(resources, entry.Resources)
+		}
+	}
+	return resources
+}
+
+// GetSubclassResources returns the resources a subclass has at a class level, declared
+// like a class's (the Battle Master's Superiority Dice)
+func GetSubclassResources(subclass *Subclass, level int) []ResourceDefinition {
+	var resources []ResourceDefinition
+	for _, entry := range subclass.Levels {
+		if entry.Level <= level {
+			resources = declareResources(resources, entry.Resources)
+		}
+	}
+	return resources
+}
+
+// declareResources adds resource declarations, replacing earlier ones with the same name
+func declareResources(resources, defs []ResourceDefinition) []ResourceDefinition {
+	for _, def := range defs {
+		replaced := false
+		for i := range resources {
+			if resources[i].Name == def.Name {
+				resources[i] = def
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			resources = append(resources, def)
+		}
+	}
+	return resources
+}
+
+// SyncClassResources rebuilds the character's class and subclass resources for each of
+// its classes at its level in that class. Spent points carry over; a larger pool gains the difference.
+func SyncClassResources(char *Character) {
+	previous := map[string]Resource{}
+	var kept []Resource
+	for _, res := range char.Resources {
+		if strings.HasPrefix(res.Source, "Class: ") {
+			previous[res.Name] = res
+		} else {
+			kept = append(kept, res)
+		}
+	}
+
+	for _, cl := range char.GetClasses() {
+		source := "Class: " + cl.Class
+		defs := GetClassResources(cl.Class, cl.Level)
+		if subclass := GetSubclass(cl.Class, cl.Subclass); subclass != nil {
+			defs = append(defs, GetSubclassResources(subclass, cl.Level)...)
+		}
+		for _, def := range defs {
+			res := Resource{
+				Name:     def.Name,
+				Max:      def.CalculateMax(char, cl.Level),
+				Die:      def.Die,
+				RestType: ParseRecharge(def.Recharge),
+				Source:   source,
+			}
+			res.Current = res.Max
+			if old, ok := previous[def.Name]; ok && old.Source == source {
+				res.Current = min(max(old.Current+res.Max-old.Max, 0), res.Max)
+			}
+			kept = append(kept, res)
+		}
+	}
+	char.Resources = kept
+}
+
+// GetResource returns a character resource by name, or nil if the character doesn't have it
+func (c *Character) GetResource(name string) *Resource {
+	for i := range c.Resources {
+		if strings.EqualFold(c.Resources[i].Name, name) {
+			return &c.Resources[i]
+		}
+	}
+	return nil
+}
+
+// Spend removes points from the pool, failing if not enough remain
+func (r *Resource) Spend(amount int) bool {
+	if amount <= 0 || r.Current < amount {
+		return false
+	}
+	r.Current -= amount
+	return true
+}
+
+// Restore adds points back to the pool, up to its maximum
+func (r *Resource) Restore(amount int) bool {
+	if amount <= 0 || r.Current >= r.Max {
+		return false
+	}
+	r.Current = min(r.Current+amount, r.Max)
+	return true
+}
+
+// String formats the pool for display, e.g. "Bardic Inspiration (d8): 3/3"
+func (r *Resource) String() string {
+	if r.Die != "" {
+		return fmt.Sprintf("%s (%s): %d/%d", r.Name, r.Die, r.Current, r.Max)
+	}
+	return fmt.Sprintf("%s: %d/%d", r.Name, r.Current, r.Max)
+}
+
+// ShortRestRecoverResources refills pools that recharge on a short rest
+func (c *Character) ShortRestRecoverResources() {
+	for i := range c.Resources {
+		if c.Resources[i].RestType == ShortRest {
+			c.Resources[i].Current = c.Resources[i].Max
+		}
+	}
+}
+
+// LongRestRecoverResources refills all rechargeable pools
+func (c *Character) LongRestRecoverResources() {
+	for i := range c.Resources {
+		if c.Resources[i].RestType != None {
+			c.Resources[i].Current = c.Resources[i].Max
+		}
+	}
+}
+
+// SorceryPoints is the resource spent by Font of Magic
+const SorceryPoints = "Sorcery Points"
+
+// FontOfMagicSlotCost is the sorcery point cost to create a spell slot of each level (PHB p.101)
+var FontOfMagicSlotCost = map[int]int{1: 2, 2: 3, 3: 5, 4: 6, 5: 7}
+
+// CreateSpellSlot spends sorcery points to create a spell slot (Flexible Casting).
+// Created slots vanish on a long rest, when slots reset to their maximum.
+func CreateSpellSlot(char *Character, slotLevel int) error {
+	cost, ok := FontOfMagicSlotCost[slotLevel]
+	if !ok {
+		return fmt.Errorf("only spell slots of level 1 to 5 can be created with Font of Magic")
+	}
+	points := char.GetResource(SorceryPoints)
+	if points == nil {
+		return fmt.Errorf("you have no sorcery points")
+	}
+	slot := char.SpellBook.GetSlotByLevel(slotLevel)
+	if slot == nil {
+		return fmt.Errorf("invalid spell slot level %d", slotLevel)
+	}
+	if !points.Spend(cost) {
+		return fmt.Errorf("a level %d slot costs %d sorcery points, you have %d", slotLevel, cost, points.Current)
+	}
+	slot.Current++
+	return nil
+}
+
+// ConvertSpellSlot expends a spell slot to gain sorcery points equal to its level
+func ConvertSpellSlot(char *Character, slotLevel int) error {
+	points := char.GetResource(SorceryPoints)
+	if points == nil {
+		return fmt.Errorf("you have no sorcery points")
+	}
+	if points.Current+slotLevel > points.Max {
+		return fmt.Errorf("converting a level %d slot would exceed your %d sorcery points", slotLevel, points.Max)
+	}
+	slot := char.SpellBook.GetSlotByLevel(slotLevel)
+	if slot == nil || !slot.UseSlot() {
+		return fmt.Errorf("no level %d spell slots remaining", slotLevel)
+	}
+	points.Current += slotLevel
+	return nil
+}
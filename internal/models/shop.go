@@ -0,0 +1,215 @@
+// internal/models/shop.go
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
+)
+
+// DefaultSellRate is the share of an item's price merchants pay for it
+const DefaultSellRate = 0.5
+
+// ShopItem is an item a shop sells. Items come from the item catalog; a shop file can
+// set its own price and how many are in stock.
+type ShopItem struct {
+	Name    string  `json:"name"`
+	PriceGP float64 `json:"price_gp,omitempty"` // Overrides the catalog price
+	Stock   *int    `json:"stock,omitempty"`    // Items left; unlimited if unset
+}
+
+// Definition returns the catalog entry of the item
+func (si ShopItem) Definition() *ItemDefinition {
+	return GetItemDefinitionByName(si.Name)
+}
+
+// Price returns the price of one item in copper pieces
+func (si ShopItem) Price() int {
+	if si.PriceGP > 0 {
+		return gpToCopper(si.PriceGP)
+	}
+	if def := si.Definition(); def != nil {
+		return gpToCopper(def.PriceGP)
+	}
+	return 0
+}
+
+// InStock reports whether at least one item is left
+func (si ShopItem) InStock() bool {
+	return si.Stock == nil || *si.Stock > 0
+}
+
+func gpToCopper(gp float64) int {
+	return int(math.Round(gp * CopperPerGold))
+}
+
+// Shop is a merchant to buy items from and sell them to: the whole item catalog at
+// list prices, or a DM-defined shop loaded from a file:
+//
+//	{"name": "The Gilded Anvil", "sell_rate": 0.4,
+//	 "items": [{"name": "Longsword", "price_gp": 12, "stock": 2}, {"name": "Torch"}]}
+type Shop struct {
+	Name     string     `json:"name"`
+	SellRate *float64   `json:"sell_rate,omitempty"` // Share of the price paid for items sold; 0.5 if unset, 0 buys nothing
+	Items    []ShopItem `json:"items"`
+}
+
+// CatalogShop sells every item of the catalog at its list price, with no stock limit.
+// It pays the character's catalog sell rate for items sold.
+func CatalogShop(char *Character) *Shop {
+	shop := &Shop{Name: "Item Catalog"}
+	if char != nil && char.CatalogSellRate != nil {
+		shop.SetRate(*char.CatalogSellRate)
+	}
+	for _, def := range GetAllItemDefinitions() {
+		shop.Items = append(shop.Items, ShopItem{Name: def.Name})
+	}
+	return shop
+}
+
+// LoadShop reads a shop file. Every item must be in the item catalog.
+func LoadShop(path string) (*Shop, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var shop Shop
+	if err := json.Unmarshal(data, &shop); err != nil {
+		return nil, errors.New(diagnostics.JSONProblem(path, data, err).String())
+	}
+	if err := shop.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if shop.Name == "" {
+		shop.Name = "Shop"
+	}
+	return &shop, nil
+}
+
+// Validate checks that the shop sells catalog items at sensible prices
+func (s *Shop) Validate() error {
+	if s.SellRate != nil && (*s.SellRate < 0 || *s.SellRate > 1) {
+		return fmt.Errorf("sell_rate must be between 0 and 1, got %g", *s.SellRate)
+	}
+	for _, item := range s.Items {
+		if item.Definition() == nil {
+			return fmt.Errorf("%q is not in the item catalog", item.Name)
+		}
+		if item.PriceGP < 0 || (item.Stock != nil && *item.Stock < 0) {
+			return fmt.Errorf("%s has a negative price or stock", item.Name)
+		}
+	}
+	return nil
+}
+
+// Save writes the shop back to its file, keeping the stock up to date
+func (s *Shop) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Rate returns the share of an item's price the shop pays for it
+func (s *Shop) Rate() float64 {
+	if s.SellRate != nil {
+		return *s.SellRate
+	}
+	return DefaultSellRate
+}
+
+// SetRate sets the share of an item's price the shop pays for it
+func (s *Shop) SetRate(rate float64) {
+	s.SellRate = &rate
+}
+
+// find returns the shop's entry for an item, or nil if it doesn't sell it
+func (s *Shop) find(name string) *ShopItem {
+	for i := range s.Items {
+		if s.Items[i].Name == name {
+			return &s.Items[i]
+		}
+	}
+	return nil
+}
+
+// Buy pays for items of the shop and adds them to the inventory. Nothing is bought
+// when the purse is short or the shop doesn't have enough in stock.
+func (s *Shop) Buy(char *Character, index, quantity int) error {
+	if index < 0 || index >= len(s.Items) {
+		return fmt.Errorf("no such item")
+	}
+	if quantity < 1 {
+		return fmt.Errorf("buy at least 1")
+	}
+	item := &s.Items[index]
+	def := item.Definition()
+	if def == nil {
+		return fmt.Errorf("%s is not in the item catalog", item.Name)
+	}
+	if item.Stock != nil && *item.Stock < quantity {
+		return fmt.Errorf("%s has only %d %s left", s.Name, *item.Stock, item.Name)
+	}
+
+	reason := fmt.Sprintf("Bought %s (%s)", item.Name, s.Name)
+	if quantity > 1 {
+		reason = fmt.Sprintf("Bought %d %s (%s)", quantity, item.Name, s.Name)
+	}
+	if cost := item.Price() * quantity; cost > 0 {
+		if err := char.Inventory.Pay(cost, reason); err != nil {
+			return fmt.Errorf("can't afford %s: %w", item.Name, err)
+		}
+	}
+
+	if item.Stock != nil {
+		*item.Stock -= quantity
+	}
+	char.Inventory.AddItem(ConvertToInventoryItem(*def, quantity))
+	return nil
+}
+
+// SellPrice returns what the shop pays for one of an item in copper pieces: its sell
+// rate of the shop's own price, the catalog price or the item's recorded value
+func (s *Shop) SellPrice(item *Item) int {
+	price := item.Value * CopperPerGold
+	if listed := s.find(item.Name); listed != nil {
+		price = listed.Price()
+	} else if def := GetItemDefinitionByName(item.Name); def != nil {
+		price = gpToCopper(def.PriceGP)
+	}
+	return int(float64(price) * s.Rate())
+}
+
+// Sell sells one of an inventory item to the shop and returns the copper received.
+// Equipped items and containers with something inside can't be sold.
+func (s *Shop) Sell(char *Character, item *Item) (int, error) {
+	if item.Equipped {
+		return 0, fmt.Errorf("unequip %s before selling it", item.Name)
+	}
+	if IsContainer(item) && len(char.Inventory.Contents(item)) > 0 {
+		return 0, fmt.Errorf("empty %s before selling it", item.Name)
+	}
+	price := s.SellPrice(item)
+	if price <= 0 {
+		return 0, fmt.Errorf("%s won't pay anything for %s", s.Name, item.Name)
+	}
+
+	name := item.Name
+	if err := char.Inventory.Receive(CoinsFromCopper(price), fmt.Sprintf("Sold %s (%s)", name, s.Name)); err != nil {
+		return 0, err
+	}
+	char.Inventory.RemoveOne(item)
+	if listed := s.find(name); listed != nil && listed.Stock != nil {
+		*listed.Stock++
+	}
+	return price, nil
+}
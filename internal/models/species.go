@@ -7,6 +7,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
 )
 
 // SpeciesTrait represents a special trait or ability of a species
@@ -18,6 +20,8 @@ type SpeciesTrait struct {
 	RestType     string `json:"rest_type"`      // When it recharges: "Short Rest", "Long Rest", "Daily"
 	UsesFormula  string `json:"uses_formula"`   // Formula for calculating uses (e.g., "proficiency", "level")
 	EffectFormula string `json:"effect_formula"` // Formula for effect (e.g., "level", "1d12+con", "2d6")
+	Spells       []SpellGrant `json:"spells,omitempty"` // Spells granted by this trait
+	ACFormula    *ACFormula   `json:"ac_formula,omitempty"` // Natural armor: base AC while unarmored
 }
 
 // SpeciesSubtype represents a variant of a species
@@ -87,6 +91,7 @@ func LoadSpeciesFromJSON(filepath string) ([]SpeciesInfo, error) {
 
 	var data SpeciesData
 	if err := json.Unmarshal(file, &data); err != nil {
+		diagnostics.ReportJSONError(filepath, file, err)
 		return nil, err
 	}
 
@@ -108,6 +113,9 @@ func GetAllSpecies() []SpeciesInfo {
 	}
 
 	// Fallback to hardcoded species if JSON file is not available
+	if os.IsNotExist(err) {
+		diagnostics.Warnf("data/species.json", 0, "not found, using the built-in species")
+	}
 	cachedSpecies = []SpeciesInfo{
 		{
 			Name:        "Aasimar",
@@ -366,6 +374,9 @@ func ApplySpeciesWithSubtype(char *Character, speciesName string, subtypeName st
 	}
 
 	// Remove old species data first
+	if char.Race != "" {
+		NewBenefitRemover(char).RemoveAllBenefits("species", char.Race)
+	}
 	RemoveSpeciesSkillProficiencies(char)
 	RemoveSpeciesFeatures(char)
 	RemoveSpeciesSpells(char)
@@ -375,6 +386,7 @@ func ApplySpeciesWithSubtype(char *Character, speciesName string, subtypeName st
 	char.Race = species.Name
 	char.Subtype = subtypeName
 	char.Speed = species.Speed
+	char.BaseSpeed = species.Speed
 	char.Darkvision = species.Darkvision
 
 	// Apply subtype property overrides
@@ -382,6 +394,7 @@ func ApplySpeciesWithSubtype(char *Character, speciesName string, subtypeName st
 		if props, ok := species.SubtypeProperties[subtypeName]; ok {
 			if props.Speed > 0 {
 				char.Speed = props.Speed
+				char.BaseSpeed = props.Speed
 			}
 			if props.Darkvision > 0 {
 				char.Darkvision = props.Darkvision
@@ -470,6 +483,7 @@ func ApplySpeciesToCharacterWithChoices(char *Character, speciesName string, cho
 	// Update basic properties
 	char.Race = species.Name
 	char.Speed = species.Speed
+	char.BaseSpeed = species.Speed
 	char.Darkvision = species.Darkvision
 
 	// Update species traits
@@ -651,29 +665,16 @@ func HasFeatChoice(species *SpeciesInfo) bool {
 
 // ApplySpeciesSpells adds spells granted by species traits based on character level
 func ApplySpeciesSpells(char *Character, species *SpeciesInfo) {
-	spellDB := GetSpeciesSpells()
-	char.SpeciesSpells = []string{}
+	source := BenefitSource{
+		Type: "species",
+		Name: species.Name,
+	}
+	applier := NewBenefitApplier(char)
 
 	for _, trait := range species.Traits {
-		spells := GetSpellsForLevel(trait, char.Level)
-		for _, spellName := range spells {
-			// Check if spell is in our database
-			if spellData, exists := spellDB[spellName]; exists {
-				// Check if character already has this spell
-				hasSpell := false
-				for _, existing := range char.SpellBook.Spells {
-					if existing.Name == spellName {
-						hasSpell = true
-						break
-					}
-				}
-
-				if !hasSpell {
-					// Add spell to spellbook
-					char.SpellBook.AddSpell(spellData)
-					// Track that this spell came from species
-					char.SpeciesSpells = append(char.SpeciesSpells, spellName)
-				}
+		for _, grant := range trait.Spells {
+			if char.Level >= grant.UnlockLevel() {
+				applier.AddSpellGrant(source, grant)
 			}
 		}
 	}
@@ -904,35 +905,34 @@ func ApplySpeciesSkillProficienciesWithTraits(char *Character, species *SpeciesI
 }
 
 // ApplySpeciesSpellsWithTraits adds spells granted by species traits based on character level
+// and tracks them (with their free-cast features) so changing species removes them
 func ApplySpeciesSpellsWithTraits(char *Character, species *SpeciesInfo, traits []SpeciesTrait) {
-	spellDB := GetSpeciesSpells()
 	char.SpeciesSpells = []string{}
+	source := BenefitSource{
+		Type: "species",
+		Name: species.Name,
+	}
+	applier := NewBenefitApplier(char)
 
 	for _, trait := range traits {
-		spells := GetSpellsForLevel(trait, char.Level)
-		for _, spellName := range spells {
-			// Check if spell is in our database
-			if spellData, exists := spellDB[spellName]; exists {
-				// Check if character already has this spell
-				hasSpell := false
-				for _, existing := range char.SpellBook.Spells {
-					if existing.Name == spellName {
-						hasSpell = true
-						break
-					}
-				}
-
-				if !hasSpell {
-					// Add spell to spellbook
-					char.SpellBook.AddSpell(spellData)
-					// Track that this spell came from species
-					char.SpeciesSpells = append(char.SpeciesSpells, spellName)
-				}
+		for _, grant := range trait.Spells {
+			if char.Level >= grant.UnlockLevel() {
+				applier.AddSpellGrant(source, grant)
 			}
 		}
 	}
 }
 
+// hasSpell checks if the character's spellbook already contains a spell
+func hasSpell(char *Character, spellName string) bool {
+	for _, existing := range char.SpellBook.Spells {
+		if strings.EqualFold(existing.Name, spellName) {
+			return true
+		}
+	}
+	return false
+}
+
 // ApplySpeciesFeaturesWithTraits adds features from species traits to the character
 func ApplySpeciesFeaturesWithTraits(char *Character, species *SpeciesInfo, traits []SpeciesTrait) {
 	for _, trait := range traits {
@@ -3,9 +3,13 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
 )
 
 // LoadSpellsFromJSON loads spell data from the JSON file
@@ -17,6 +21,7 @@ func LoadSpellsFromJSON(filepath string) ([]Spell, error) {
 
 	var spells []Spell
 	if err := json.Unmarshal(file, &spells); err != nil {
+		diagnostics.ReportJSONError(filepath, file, err)
 		return nil, err
 	}
 
@@ -42,6 +47,33 @@ func LoadSpellsFromJSON(filepath string) ([]Spell, error) {
 	return spells, nil
 }
 
+var cachedSpells []Spell
+
+// GetAllSpells returns the spell catalog from data/spells.json (cached)
+func GetAllSpells() []Spell {
+	if cachedSpells != nil {
+		return cachedSpells
+	}
+
+	spells, err := LoadSpellsFromJSON("data/spells.json")
+	if err != nil {
+		return []Spell{}
+	}
+	cachedSpells = spells
+	return cachedSpells
+}
+
+// GetSpellByName returns a spell from the catalog by name (case-insensitive)
+func GetSpellByName(name string) *Spell {
+	nameLower := strings.ToLower(name)
+	for _, spell := range GetAllSpells() {
+		if strings.ToLower(spell.Name) == nameLower {
+			return &spell
+		}
+	}
+	return nil
+}
+
 // capitalizeFirst capitalizes the first letter of a string
 func capitalizeFirst(s string) string {
 	if len(s) == 0 {
@@ -50,280 +82,116 @@ func capitalizeFirst(s string) string {
 	return strings.ToUpper(string(s[0])) + strings.ToLower(s[1:])
 }
 
-// GetWizardCantrips returns all wizard cantrips from the spell database
-func GetWizardCantrips() []Spell {
-	spells, err := LoadSpellsFromJSON("data/spells.json")
-	if err != nil {
-		// Fallback to hardcoded list
-		return getHardcodedWizardCantrips()
-	}
-
-	cantrips := []Spell{}
-	for _, spell := range spells {
-		if spell.Level == 0 {
-			// Check if Wizard is in the classes list
-			for _, class := range spell.Classes {
-				if class == "Wizard" {
-					cantrips = append(cantrips, spell)
-					break
-				}
+// GetSpellsForClass returns catalog spells of a given level on a class's spell list
+func GetSpellsForClass(className string, level int) []Spell {
+	spells := []Spell{}
+	for _, spell := range GetAllSpells() {
+		if spell.Level != level {
+			continue
+		}
+		for _, class := range spell.Classes {
+			if strings.EqualFold(class, className) {
+				spells = append(spells, spell)
+				break
 			}
 		}
 	}
+	return spells
+}
 
-	return cantrips
+// GetWizardCantrips returns all wizard cantrips from the spell database
+func GetWizardCantrips() []Spell {
+	return GetSpellsForClass("Wizard", 0)
 }
 
-// getHardcodedWizardCantrips returns a fallback list of wizard cantrips
-func getHardcodedWizardCantrips() []Spell {
-	return []Spell{
-		{
-			Name:        "Acid Splash",
-			Level:       0,
-			School:      Evocation,
-			CastingTime: "1 action",
-			Range:       "60 feet",
-			Components:  "V, S",
-			Duration:    "Instantaneous",
-			Description: "You hurl a bubble of acid. Choose one creature within range, or two creatures within 5 feet of each other. A target must succeed on a Dexterity saving throw or take 1d6 acid damage.",
-			Known:       true,
-			Prepared:    true,
-		},
-		{
-			Name:        "Fire Bolt",
-			Level:       0,
-			School:      Evocation,
-			CastingTime: "1 action",
-			Range:       "120 feet",
-			Components:  "V, S",
-			Duration:    "Instantaneous",
-			Description: "You hurl a mote of fire at a creature or object within range. Make a ranged spell attack. On a hit, the target takes 1d10 fire damage.",
-			Known:       true,
-			Prepared:    true,
-		},
-		{
-			Name:        "Mage Hand",
-			Level:       0,
-			School:      Conjuration,
-			CastingTime: "1 action",
-			Range:       "30 feet",
-			Components:  "V, S",
-			Duration:    "1 minute",
-			Description: "A spectral, floating hand appears at a point you choose within range. The hand lasts for the duration or until you dismiss it as an action.",
-			Known:       true,
-			Prepared:    true,
-		},
-		{
-			Name:        "Ray of Frost",
-			Level:       0,
-			School:      Evocation,
-			CastingTime: "1 action",
-			Range:       "60 feet",
-			Components:  "V, S",
-			Duration:    "Instantaneous",
-			Description: "A frigid beam of blue-white light streaks toward a creature within range. Make a ranged spell attack. On a hit, it takes 1d8 cold damage.",
-			Known:       true,
-			Prepared:    true,
-		},
-		{
-			Name:        "Shocking Grasp",
-			Level:       0,
-			School:      Evocation,
-			CastingTime: "1 action",
-			Range:       "Touch",
-			Components:  "V, S",
-			Duration:    "Instantaneous",
-			Description: "Lightning springs from your hand to deliver a shock to a creature you try to touch. Make a melee spell attack. On a hit, the target takes 1d8 lightning damage.",
-			Known:       true,
-			Prepared:    true,
-		},
+// ResolveSpellGrant looks up a granted spell in the catalog and prepares it for the spellbook
+func ResolveSpellGrant(grant SpellGrant) (Spell, bool) {
+	catalogSpell := GetSpellByName(grant.SpellName)
+	if catalogSpell == nil {
+		return Spell{}, false
 	}
+
+	spell := *catalogSpell
+	spell.Known = true
+	spell.Prepared = true // Granted spells are always prepared
+	spell.SpellcastingAbility = grant.Ability
+	return spell, true
+}
+
+// SpellGrant represents a spell granted by a species trait or feat
+type SpellGrant struct {
+	SpellName     string `json:"spell"`
+	LevelRequired int    `json:"level,omitempty"`      // Character level that unlocks the spell (default 1)
+	Ability       string `json:"ability,omitempty"`    // Spellcasting ability for the spell
+	FreeCasts     int    `json:"free_casts,omitempty"` // Casts per long rest without a spell slot
 }
 
-// GetSpeciesSpells returns a map of spell definitions for species-granted spells
-func GetSpeciesSpells() map[string]Spell {
-	return map[string]Spell{
-		// Cantrips
-		"Light": {
-			Name:        "Light",
-			Level:       0,
-			School:      Evocation,
-			CastingTime: "1 action",
-			Range:       "Touch",
-			Components:  "V, M",
-			Duration:    "1 hour",
-			Description: "You touch one object that is no larger than 10 feet in any dimension. Until the spell ends, the object sheds bright light in a 20-foot radius and dim light for an additional 20 feet.",
-			Known:       true,
-			Prepared:    true,
-		},
-		"Dancing Lights": {
-			Name:        "Dancing Lights",
-			Level:       0,
-			School:      Evocation,
-			CastingTime: "1 action",
-			Range:       "120 feet",
-			Components:  "V, S, M",
-			Duration:    "Concentration, up to 1 minute",
-			Description: "You create up to four torch-sized lights within range, making them appear as torches, lanterns, or glowing orbs that hover in the air for the duration.",
-			Known:       true,
-			Prepared:    true,
-		},
-		"Chill Touch": {
-			Name:        "Chill Touch",
-			Level:       0,
-			School:      Necromancy,
-			CastingTime: "1 action",
-			Range:       "120 feet",
-			Components:  "V, S",
-			Duration:    "1 round",
-			Description: "You create a ghostly, skeletal hand in the space of a creature within range. Make a ranged spell attack. On a hit, the target takes 1d8 necrotic damage.",
-			Known:       true,
-			Prepared:    true,
-		},
-		"Thaumaturgy": {
-			Name:        "Thaumaturgy",
-			Level:       0,
-			School:      Transmutation,
-			CastingTime: "1 action",
-			Range:       "30 feet",
-			Components:  "V",
-			Duration:    "Up to 1 minute",
-			Description: "You manifest a minor wonder, a sign of supernatural power, within range. You create one of the following effects: your voice booms up to three times as loud, you cause flames to flicker, you cause harmless tremors, or you create an instantaneous sound.",
-			Known:       true,
-			Prepared:    true,
-		},
-		// 1st Level Spells
-		"Faerie Fire": {
-			Name:        "Faerie Fire",
-			Level:       1,
-			School:      Evocation,
-			CastingTime: "1 action",
-			Range:       "60 feet",
-			Components:  "V",
-			Duration:    "Concentration, up to 1 minute",
-			Description: "Each object in a 20-foot cube within range is outlined in blue, green, or violet light. Any creature in the area when the spell is cast is also outlined if it fails a Dexterity saving throw. For the duration, objects and affected creatures shed dim light in a 10-foot radius. Attack rolls against affected creatures have advantage.",
-			Known:       true,
-			Prepared:    false,
-		},
-		"Burning Hands": {
-			Name:        "Burning Hands",
-			Level:       1,
-			School:      Evocation,
-			CastingTime: "1 action",
-			Range:       "Self (15-foot cone)",
-			Components:  "V, S",
-			Duration:    "Instantaneous",
-			Description: "As you hold your hands with thumbs touching and fingers spread, a thin sheet of flames shoots forth from your outstretched fingertips. Each creature in a 15-foot cone must make a Dexterity saving throw. A creature takes 3d6 fire damage on a failed save, or half as much damage on a successful one.",
-			Known:       true,
-			Prepared:    false,
-		},
-		"False Life": {
-			Name:        "False Life",
-			Level:       1,
-			School:      Necromancy,
-			CastingTime: "1 action",
-			Range:       "Self",
-			Components:  "V, S, M",
-			Duration:    "1 hour",
-			Description: "Bolstering yourself with a necromantic facsimile of life, you gain 1d4 + 4 temporary hit points for the duration.",
-			Known:       true,
-			Prepared:    false,
-		},
-		"Hellish Rebuke": {
-			Name:        "Hellish Rebuke",
-			Level:       1,
-			School:      Evocation,
-			CastingTime: "1 reaction",
-			Range:       "60 feet",
-			Components:  "V, S",
-			Duration:    "Instantaneous",
-			Description: "You point your finger, and the creature that damaged you is momentarily surrounded by hellish flames. The creature must make a Dexterity saving throw. It takes 2d10 fire damage on a failed save, or half as much damage on a successful one.",
-			Known:       true,
-			Prepared:    false,
-		},
-		// 2nd Level Spells
-		"Darkness": {
-			Name:        "Darkness",
-			Level:       2,
-			School:      Evocation,
-			CastingTime: "1 action",
-			Range:       "60 feet",
-			Components:  "V, M",
-			Duration:    "Concentration, up to 10 minutes",
-			Description: "Magical darkness spreads from a point you choose within range to fill a 15-foot radius sphere for the duration. The darkness spreads around corners. Darkvision can't penetrate this darkness.",
-			Known:       true,
-			Prepared:    false,
-		},
-		"Alter Self": {
-			Name:        "Alter Self",
-			Level:       2,
-			School:      Transmutation,
-			CastingTime: "1 action",
-			Range:       "Self",
-			Components:  "V, S",
-			Duration:    "Concentration, up to 1 hour",
-			Description: "You assume a different form. When you cast the spell, choose one of the following options: Aquatic Adaptation, Change Appearance, or Natural Weapons.",
-			Known:       true,
-			Prepared:    false,
-		},
-		"Ray of Enfeeblement": {
-			Name:        "Ray of Enfeeblement",
-			Level:       2,
-			School:      Necromancy,
-			CastingTime: "1 action",
-			Range:       "60 feet",
-			Components:  "V, S",
-			Duration:    "Concentration, up to 1 minute",
-			Description: "A black beam of enervating energy springs from your finger toward a creature within range. Make a ranged spell attack. On a hit, the target deals only half damage with weapon attacks that use Strength until the spell ends.",
-			Known:       true,
-			Prepared:    false,
-		},
+// UnlockLevel returns the character level at which the grant becomes available
+func (g SpellGrant) UnlockLevel() int {
+	if g.LevelRequired < 1 {
+		return 1
 	}
+	return g.LevelRequired
 }
 
-// ParseSpeciesSpells extracts spell information from species traits
-func ParseSpeciesSpells(trait SpeciesTrait) []SpellGrant {
-	grants := []SpellGrant{}
-	desc := strings.ToLower(trait.Description)
-	name := strings.ToLower(trait.Name)
+// FreeCastFeature returns the limited-use feature tracking the grant's free casts
+func (g SpellGrant) FreeCastFeature() FeatureDefinition {
+	description := fmt.Sprintf("Cast %s without expending a spell slot.", g.SpellName)
+	if g.Ability != "" {
+		description += fmt.Sprintf(" %s is your spellcasting ability for it.", g.Ability)
+	}
+	return FeatureDefinition{
+		Name:        g.SpellName,
+		Description: description,
+		MaxUses:     strconv.Itoa(g.FreeCasts),
+		RestType:    LongRest,
+	}
+}
 
-	// Check for spell grants in the description
-	if strings.Contains(desc, "cantrip") || strings.Contains(name, "legacy") || strings.Contains(name, "bearer") || strings.Contains(name, "magic") {
-		// Extract spell names and their level requirements
-		spellNames := []string{
-			"Light", "Dancing Lights", "Chill Touch", "Thaumaturgy",
-			"Faerie Fire", "Burning Hands", "False Life", "Hellish Rebuke",
-			"Darkness", "Alter Self", "Ray of Enfeeblement",
+// SyncSpellGrants adds the species and feat spells unlocked at the character's level
+// and removes those granted above it, after the level changes
+func SyncSpellGrants(char *Character) {
+	applier := NewBenefitApplier(char)
+	remover := NewBenefitRemover(char)
+	sync := func(source BenefitSource, grants []SpellGrant, ability string) {
+		for _, grant := range grants {
+			if char.Level < grant.UnlockLevel() {
+				remover.RemoveSpellGrant(source, grant.SpellName)
+				continue
+			}
+			if grant.Ability == "" {
+				grant.Ability = ability
+			}
+			applier.AddSpellGrant(source, grant)
 		}
+	}
 
-		for _, spellName := range spellNames {
-			spellLower := strings.ToLower(spellName)
-			if strings.Contains(desc, spellLower) || strings.Contains(name, spellLower) {
-				// Determine level requirement
-				levelReq := 1 // Default to level 1 for cantrips
-
-				// Check for level requirements
-				if strings.Contains(desc, "3rd level") && strings.Index(desc, "3rd level") < strings.Index(desc, spellLower) {
-					levelReq = 3
-				} else if strings.Contains(desc, "5th level") && strings.Index(desc, "5th level") < strings.Index(desc, spellLower) {
-					levelReq = 5
-				}
-
-				grants = append(grants, SpellGrant{
-					SpellName:    spellName,
-					LevelRequired: levelReq,
-				})
+	if char.Race != "" {
+		source := BenefitSource{Type: "species", Name: char.Race}
+		for _, trait := range char.SpeciesTraits {
+			sync(source, trait.Spells, "")
+		}
+	}
+	for _, name := range char.Feats {
+		feat := GetFeatByName(name)
+		if feat == nil || len(feat.Spells) == 0 {
+			continue
+		}
+		source := BenefitSource{Type: "feat", Name: feat.Name}
+		// Without an explicit ability the spells use the one the feat increased
+		ability := ""
+		for _, b := range char.BenefitTracker.GetBenefitsBySource(source.Type, source.Name) {
+			if b.Type == BenefitAbilityScore {
+				ability = b.Target
 			}
 		}
+		sync(source, feat.Spells, ability)
 	}
-
-	return grants
 }
 
-// SpellGrant represents a spell granted by a species trait
-type SpellGrant struct {
-	SpellName     string
-	LevelRequired int
+// ParseSpeciesSpells returns the spell grants declared on a species trait
+func ParseSpeciesSpells(trait SpeciesTrait) []SpellGrant {
+	return trait.Spells
 }
 
 // GetSpellsForLevel returns all species spells available at a given level
@@ -332,7 +200,7 @@ func GetSpellsForLevel(trait SpeciesTrait, characterLevel int) []string {
 	available := []string{}
 
 	for _, grant := range grants {
-		if characterLevel >= grant.LevelRequired {
+		if characterLevel >= grant.UnlockLevel() {
 			available = append(available, grant.SpellName)
 		}
 	}
@@ -0,0 +1,157 @@
+// internal/models/spell_components.go
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaterialComponent describes the material requirement parsed from Spell.Material
+type MaterialComponent struct {
+	Description string
+	CostGP      int  // Minimum value in GP, 0 if the component has no cost
+	Consumed    bool // The spell consumes the component
+}
+
+// ComponentCheck is the result of checking a character's inventory for a spell's components
+type ComponentCheck struct {
+	OK           bool
+	Warning      string // Why the spell can't be cast (empty when OK)
+	ConsumeItem  string // Inventory item to consume on casting
+	ConsumeQty   int
+	ConsumeIndex int // Inventory stack the item is consumed from
+}
+
+var materialCostPattern = regexp.MustCompile(`(?i)worth\s+(?:at least\s+)?([\d,]+)\+?\s*GP`)
+
+// HasMaterialComponent returns true if the spell lists an M component
+func (s *Spell) HasMaterialComponent() bool {
+	for _, comp := range strings.Split(s.GetComponentsString(), ",") {
+		if strings.EqualFold(strings.TrimSpace(comp), "M") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMaterialComponent parses cost and consumption from the material text
+func (s *Spell) GetMaterialComponent() MaterialComponent {
+	mat := MaterialComponent{Description: s.Material}
+	if m := materialCostPattern.FindStringSubmatch(s.Material); m != nil {
+		mat.CostGP, _ = strconv.Atoi(strings.ReplaceAll(m[1], ",", ""))
+	}
+	mat.Consumed = strings.Contains(strings.ToLower(s.Material), "consume")
+	return mat
+}
+
+// IsSpellcastingFocus returns true if the item is a component pouch or spellcasting focus
+func IsSpellcastingFocus(item Item) bool {
+	if strings.Contains(strings.ToLower(item.Name), "component pouch") {
+		return true
+	}
+	def := GetItemDefinitionByName(item.Name)
+	return def != nil && def.Subcategory == "spellcasting_focus"
+}
+
+// HasSpellcastingFocus checks if the character carries a component pouch or focus
+func HasSpellcastingFocus(char *Character) bool {
+	for _, item := range char.Inventory.Items {
+		if IsSpellcastingFocus(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesComponent checks if every significant word of the item name appears in the material text
+func matchesComponent(item Item, material string) bool {
+	name := strings.ToLower(item.Name)
+	if idx := strings.Index(name, "("); idx > 0 {
+		name = name[:idx]
+	}
+
+	words := 0
+	for _, word := range strings.Fields(name) {
+		if len(word) < 3 || word == "the" {
+			continue
+		}
+		if !strings.Contains(material, word) {
+			return false
+		}
+		words++
+	}
+	return words > 0
+}
+
+// CheckSpellComponents verifies that the character can supply a spell's material component.
+// Components without a cost can be replaced by a component pouch or focus; priced
+// components need a matching inventory item worth the cost on its own: ten 50 GP
+// diamonds are not a 300 GP diamond. The cheapest such item is used.
+func CheckSpellComponents(char *Character, spell *Spell) ComponentCheck {
+	if spell == nil || !spell.HasMaterialComponent() {
+		return ComponentCheck{OK: true}
+	}
+
+	mat := spell.GetMaterialComponent()
+	if mat.CostGP == 0 {
+		if HasSpellcastingFocus(char) {
+			return ComponentCheck{OK: true}
+		}
+		return ComponentCheck{
+			Warning: fmt.Sprintf("%s needs a component pouch or spellcasting focus (%s)", spell.Name, mat.Description),
+		}
+	}
+
+	material := strings.ToLower(mat.Description)
+	items := char.Inventory.Items
+	found, best := -1, -1 // Cheapest item worth the cost, most valuable item that isn't
+	for i, item := range items {
+		if !matchesComponent(item, material) {
+			continue
+		}
+		if item.Value >= mat.CostGP {
+			if found < 0 || item.Value < items[found].Value {
+				found = i
+			}
+		} else if best < 0 || item.Value > items[best].Value {
+			best = i
+		}
+	}
+
+	if found >= 0 {
+		check := ComponentCheck{OK: true}
+		if mat.Consumed {
+			check.ConsumeItem = items[found].Name
+			check.ConsumeQty = 1
+			check.ConsumeIndex = found
+		}
+		return check
+	}
+	if best >= 0 {
+		return ComponentCheck{
+			Warning: fmt.Sprintf("%s needs %s; your best %s is only worth %d GP", spell.Name, mat.Description, items[best].Name, items[best].Value),
+		}
+	}
+	return ComponentCheck{
+		Warning: fmt.Sprintf("%s needs %s, which isn't in your inventory", spell.Name, mat.Description),
+	}
+}
+
+// ConsumeSpellComponents removes consumed components after a successful cast
+func ConsumeSpellComponents(char *Character, check ComponentCheck) {
+	if check.ConsumeItem == "" || check.ConsumeQty <= 0 {
+		return
+	}
+	inv := &char.Inventory
+	if i := check.ConsumeIndex; i >= 0 && i < len(inv.Items) && inv.Items[i].Name == check.ConsumeItem {
+		if inv.Items[i].Quantity > check.ConsumeQty {
+			inv.Items[i].Quantity -= check.ConsumeQty
+		} else {
+			inv.RemoveAt(i)
+		}
+		return
+	}
+	inv.RemoveItem(check.ConsumeItem, check.ConsumeQty)
+}
@@ -0,0 +1,398 @@
+// internal/models/spell_mechanics.go
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SpellAttackType describes whether a spell uses a spell attack roll
+type SpellAttackType string
+
+const (
+	SpellAttackNone   SpellAttackType = "none"
+	SpellAttackMelee  SpellAttackType = "melee"
+	SpellAttackRanged SpellAttackType = "ranged"
+)
+
+// SpellDamage is one damage component of a spell
+type SpellDamage struct {
+	Dice        string   `json:"dice"`                  // e.g. "8d6"
+	Type        string   `json:"type,omitempty"`        // e.g. "fire"
+	Types       []string `json:"types,omitempty"`       // Alternatives the caster picks from, e.g. radiant or necrotic
+	AddModifier bool     `json:"addModifier,omitempty"` // Add spellcasting ability modifier
+	Upcast      string   `json:"upcast,omitempty"`      // Dice added per slot level above the spell's level (or per cantrip tier)
+}
+
+// TypeLabel returns the damage type, or its alternatives joined with "/"
+func (d SpellDamage) TypeLabel() string {
+	if len(d.Types) > 0 {
+		return strings.Join(d.Types, "/")
+	}
+	return d.Type
+}
+
+// HasType returns true if the damage is, or can be chosen to be, the given type
+func (d SpellDamage) HasType(damageType string) bool {
+	if d.Type == damageType {
+		return true
+	}
+	for _, t := range d.Types {
+		if t == damageType {
+			return true
+		}
+	}
+	return false
+}
+
+// SpellHealing describes the hit points a spell restores
+type SpellHealing struct {
+	Dice        string `json:"dice"`                  // e.g. "2d8"
+	AddModifier bool   `json:"addModifier,omitempty"` // Add spellcasting ability modifier
+	Upcast      string `json:"upcast,omitempty"`      // Dice added per slot level above the spell's level
+}
+
+// SpellArea describes the area of effect of a spell
+type SpellArea struct {
+	Shape string `json:"shape"` // sphere, cone, cube, cylinder, line, emanation
+	Size  int    `json:"size"`  // In feet (radius, length or side)
+}
+
+// CantripTier returns how many times cantrip dice are multiplied at a character level (levels 5, 11, 17)
+func CantripTier(characterLevel int) int {
+	switch {
+	case characterLevel >= 17:
+		return 4
+	case characterLevel >= 11:
+		return 3
+	case characterLevel >= 5:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// HasMechanics returns true if the spell has structured damage, healing, save or attack data
+func (s *Spell) HasMechanics() bool {
+	return len(s.Damage) > 0 || s.Healing != nil || s.SavingThrow != "" ||
+		s.AttackType == SpellAttackMelee || s.AttackType == SpellAttackRanged
+}
+
+// SaveAbility returns the ability used for the spell's saving throw
+func (s *Spell) SaveAbility() (AbilityType, bool) {
+	return ParseAbilityType(s.SavingThrow)
+}
+
+// DamageAt returns the damage dealt when cast with the given slot level.
+// Cantrips scale with character level instead of slot level.
+func (s *Spell) DamageAt(slotLevel, characterLevel int) []SpellDamage {
+	result := make([]SpellDamage, 0, len(s.Damage))
+	for _, dmg := range s.Damage {
+		dice := dmg.Dice
+		if s.Level == 0 {
+			tier := CantripTier(characterLevel)
+			if dmg.Upcast != "" {
+				dice = AddDice(dmg.Dice, dmg.Upcast, tier-1)
+			} else {
+				dice = AddDice(dmg.Dice, dmg.Dice, tier-1)
+			}
+		} else if dmg.Upcast != "" && slotLevel > s.Level {
+			dice = AddDice(dmg.Dice, dmg.Upcast, slotLevel-s.Level)
+		}
+		result = append(result, SpellDamage{Dice: dice, Type: dmg.Type, Types: dmg.Types, AddModifier: dmg.AddModifier})
+	}
+	return result
+}
+
+// HealingAt returns the healing dice expression when cast with the given slot level
+func (s *Spell) HealingAt(slotLevel, spellcastingMod int) string {
+	if s.Healing == nil {
+		return ""
+	}
+
+	dice := s.Healing.Dice
+	if s.Healing.Upcast != "" && slotLevel > s.Level {
+		dice = AddDice(dice, s.Healing.Upcast, slotLevel-s.Level)
+	}
+	if s.Healing.AddModifier && spellcastingMod != 0 {
+		dice = AddDice(dice, strconv.Itoa(spellcastingMod), 1)
+	}
+	return dice
+}
+
+// MechanicsSummary returns a one-line description such as "9d6 fire • DEX save DC 15 • 20-ft sphere"
+func (s *Spell) MechanicsSummary(slotLevel, characterLevel int, sb *SpellBook) string {
+	var parts []string
+
+	for _, dmg := range s.DamageAt(slotLevel, characterLevel) {
+		damage := fmt.Sprintf("%s %s", dmg.Dice, dmg.TypeLabel())
+		if dmg.AddModifier {
+			damage = fmt.Sprintf("%s + mod %s", dmg.Dice, dmg.TypeLabel())
+		}
+		parts = append(parts, damage)
+	}
+	if s.Healing != nil {
+		heal := s.Healing.Dice
+		if slotLevel > s.Level && s.Healing.Upcast != "" {
+			heal = AddDice(heal, s.Healing.Upcast, slotLevel-s.Level)
+		}
+		if s.Healing.AddModifier {
+			heal += " + mod"
+		}
+		parts = append(parts, fmt.Sprintf("heals %s", heal))
+	}
+
+	switch s.AttackType {
+	case SpellAttackMelee, SpellAttackRanged:
+		attack := fmt.Sprintf("%s spell attack", s.AttackType)
+		if sb != nil {
+			attack += fmt.Sprintf(" +%d", sb.SpellAttackBonus)
+		}
+		parts = append(parts, attack)
+	}
+
+	if ability, ok := s.SaveAbility(); ok {
+		save := fmt.Sprintf("%s save", ability)
+		if sb != nil {
+			save += fmt.Sprintf(" DC %d", sb.SpellSaveDC)
+		}
+		parts = append(parts, save)
+	}
+
+	if s.Area != nil {
+		parts = append(parts, fmt.Sprintf("%d-ft %s", s.Area.Size, s.Area.Shape))
+	}
+
+	return strings.Join(parts, " • ")
+}
+
+// RollExpression returns the dice expression to roll for the spell's effect at a slot level
+func (s *Spell) RollExpression(slotLevel, characterLevel, spellcastingMod int) string {
+	if len(s.Damage) > 0 {
+		var exprs []string
+		for _, dmg := range s.DamageAt(slotLevel, characterLevel) {
+			dice := dmg.Dice
+			if dmg.AddModifier && spellcastingMod != 0 {
+				dice = AddDice(dice, strconv.Itoa(spellcastingMod), 1)
+			}
+			exprs = append(exprs, dice)
+		}
+		return strings.Join(exprs, ", ")
+	}
+	return s.HealingAt(slotLevel, spellcastingMod)
+}
+
+// diceExpr holds dice counts by die size plus a flat modifier
+type diceExpr struct {
+	counts map[int]int
+	order  []int
+	flat   int
+}
+
+var diceTermPattern = regexp.MustCompile(`^(\d*)d(\d+)$`)
+
+// parseDiceExpr parses simple expressions like "2d6", "1d4+1" or "3"
+func parseDiceExpr(expr string) (diceExpr, bool) {
+	d := diceExpr{counts: make(map[int]int)}
+	expr = strings.ToLower(strings.ReplaceAll(expr, " ", ""))
+	if expr == "" {
+		return d, false
+	}
+
+	expr = strings.ReplaceAll(expr, "-", "+-")
+	for _, term := range strings.Split(expr, "+") {
+		if term == "" {
+			continue
+		}
+		if m := diceTermPattern.FindStringSubmatch(term); m != nil {
+			count := 1
+			if m[1] != "" {
+				count, _ = strconv.Atoi(m[1])
+			}
+			sides, _ := strconv.Atoi(m[2])
+			if _, seen := d.counts[sides]; !seen {
+				d.order = append(d.order, sides)
+			}
+			d.counts[sides] += count
+			continue
+		}
+		n, err := strconv.Atoi(term)
+		if err != nil {
+			return d, false
+		}
+		d.flat += n
+	}
+	return d, true
+}
+
+// String formats the expression back to dice notation
+func (d diceExpr) String() string {
+	var b strings.Builder
+	for _, sides := range d.order {
+		if d.counts[sides] == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("+")
+		}
+		fmt.Fprintf(&b, "%dd%d", d.counts[sides], sides)
+	}
+	switch {
+	case d.flat > 0 && b.Len() > 0:
+		fmt.Fprintf(&b, "+%d", d.flat)
+	case d.flat < 0 || (d.flat > 0 && b.Len() == 0):
+		fmt.Fprintf(&b, "%d", d.flat)
+	}
+	return b.String()
+}
+
+// AddDice adds an increment expression to a base expression a number of times.
+// AddDice("8d6", "1d6", 1) returns "9d6"; unparseable input returns base unchanged.
+func AddDice(base, increment string, times int) string {
+	if times <= 0 {
+		return base
+	}
+	b, ok := parseDiceExpr(base)
+	if !ok {
+		return base
+	}
+	inc, ok := parseDiceExpr(increment)
+	if !ok {
+		return base
+	}
+
+	for _, sides := range inc.order {
+		if _, seen := b.counts[sides]; !seen {
+			b.order = append(b.order, sides)
+		}
+		b.counts[sides] += inc.counts[sides] * times
+	}
+	b.flat += inc.flat * times
+	return b.String()
+}
+
+var (
+	spellAttackPattern    = regexp.MustCompile(`(?i)\b(melee|ranged) spell attack`)
+	spellSavePattern      = regexp.MustCompile(`(Strength|Dexterity|Constitution|Intelligence|Wisdom|Charisma) saving throw`)
+	spellDamagePattern    = regexp.MustCompile(`(\d+d\d+(?:\s*\+\s*\d+)?)\s+(Acid|Bludgeoning|Cold|Fire|Force|Lightning|Necrotic|Piercing|Poison|Psychic|Radiant|Slashing|Thunder) damage`)
+	spellAltDamagePattern = regexp.MustCompile(`(\d+d\d+(?:\s*\+\s*\d+)?)\s+(Acid|Bludgeoning|Cold|Fire|Force|Lightning|Necrotic|Piercing|Poison|Psychic|Radiant|Slashing|Thunder) damage(?:\s*\([^)]*\))?\s+or\s+(\d+d\d+(?:\s*\+\s*\d+)?)\s+(Acid|Bludgeoning|Cold|Fire|Force|Lightning|Necrotic|Piercing|Poison|Psychic|Radiant|Slashing|Thunder) damage`)
+	spellHealPattern      = regexp.MustCompile(`Hit Points equal to (\d+d\d+(?:\s*\+\s*\d+)?)(\s*(?:plus|\+)\s*your spellcasting ability modifier)?`)
+	spellAreaPattern      = regexp.MustCompile(`(\d+)-\s?(?:foot|feet)[-\w ,]{0,30}?\b(Sphere|Cone|Cube|Cylinder|Line|Emanation)\b`)
+	spellUpcastPattern    = regexp.MustCompile(`(?i)increases? by (\d+d\d+)`)
+	spellDamageTypeList   = regexp.MustCompile(`(Acid|Bludgeoning|Cold|Fire|Force|Lightning|Necrotic|Piercing|Poison|Psychic|Radiant|Slashing|Thunder) damage`)
+)
+
+// ParseSpellMechanics fills in missing structured mechanics from the spell's prose
+// (description, higherLevelSlot and cantripUpgrade). Returns true if anything changed.
+func ParseSpellMechanics(spell *Spell) bool {
+	changed := false
+
+	if spell.AttackType == "" {
+		spell.AttackType = SpellAttackNone
+		if m := spellAttackPattern.FindStringSubmatch(spell.Description); m != nil {
+			spell.AttackType = SpellAttackType(strings.ToLower(m[1]))
+		}
+		changed = true
+	}
+
+	if spell.SavingThrow == "" {
+		if m := spellSavePattern.FindStringSubmatch(spell.Description); m != nil {
+			spell.SavingThrow = strings.ToLower(m[1])
+			changed = true
+		}
+	}
+
+	if len(spell.Damage) == 0 {
+		seen := make(map[string]bool)
+		// "3d8 Radiant damage (if ...) or 3d8 Necrotic damage" is one roll of either type
+		for _, m := range spellAltDamagePattern.FindAllStringSubmatch(spell.Description, -1) {
+			dice := strings.ReplaceAll(m[1], " ", "")
+			if dice != strings.ReplaceAll(m[3], " ", "") {
+				continue
+			}
+			first, second := strings.ToLower(m[2]), strings.ToLower(m[4])
+			seen[first], seen[second] = true, true
+			spell.Damage = append(spell.Damage, SpellDamage{Dice: dice, Types: []string{first, second}})
+		}
+		for _, m := range spellDamagePattern.FindAllStringSubmatch(spell.Description, -1) {
+			damageType := strings.ToLower(m[2])
+			if seen[damageType] {
+				continue
+			}
+			seen[damageType] = true
+			spell.Damage = append(spell.Damage, SpellDamage{
+				Dice: strings.ReplaceAll(m[1], " ", ""),
+				Type: damageType,
+			})
+		}
+		if len(spell.Damage) > 0 {
+			applyDamageUpcast(spell)
+			changed = true
+		}
+	}
+
+	if spell.Healing == nil {
+		if m := spellHealPattern.FindStringSubmatch(spell.Description); m != nil {
+			spell.Healing = &SpellHealing{
+				Dice:        strings.ReplaceAll(m[1], " ", ""),
+				AddModifier: m[2] != "",
+			}
+			if strings.Contains(strings.ToLower(spell.HigherLevelSlot), "healing increases") {
+				if up := spellUpcastPattern.FindStringSubmatch(spell.HigherLevelSlot); up != nil {
+					spell.Healing.Upcast = up[1]
+				}
+			}
+			changed = true
+		}
+	}
+
+	if spell.Area == nil {
+		if m := spellAreaPattern.FindStringSubmatch(spell.Description); m != nil {
+			size, _ := strconv.Atoi(m[1])
+			spell.Area = &SpellArea{Shape: strings.ToLower(m[2]), Size: size}
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// applyDamageUpcast reads upcast increments from higherLevelSlot text.
+// Cantrips are left without Upcast since their base dice scale by tier.
+func applyDamageUpcast(spell *Spell) {
+	if spell.Level == 0 || spell.HigherLevelSlot == "" {
+		return
+	}
+	up := spellUpcastPattern.FindStringSubmatch(spell.HigherLevelSlot)
+	if up == nil || strings.Contains(strings.ToLower(spell.HigherLevelSlot), "healing increases") {
+		return
+	}
+
+	// "The Fire damage and the Radiant damage increase by 1d6" names its targets
+	named := make(map[string]bool)
+	for _, m := range spellDamageTypeList.FindAllStringSubmatch(spell.HigherLevelSlot, -1) {
+		named[strings.ToLower(m[1])] = true
+	}
+
+	for i := range spell.Damage {
+		if len(named) == 0 && i > 0 {
+			break
+		}
+		if len(named) > 0 && !namesDamage(named, spell.Damage[i]) {
+			continue
+		}
+		spell.Damage[i].Upcast = up[1]
+	}
+}
+
+// namesDamage returns true if any of the damage's types is in named
+func namesDamage(named map[string]bool, dmg SpellDamage) bool {
+	for damageType := range named {
+		if dmg.HasType(damageType) {
+			return true
+		}
+	}
+	return false
+}
@@ -27,6 +27,7 @@ type Spell struct {
 	School         SpellSchool `json:"school"`
 	CastingTime    string      `json:"casting_time"`
 	ActionType     string      `json:"actionType"`     // action, bonus action, reaction
+	CastingTrigger string      `json:"castingTrigger,omitempty"` // When a bonus action or reaction is taken
 	Range          string      `json:"range"`
 	Components     interface{} `json:"components"`     // Can be string or []string
 	Material       string      `json:"material"`       // Material component description
@@ -38,6 +39,17 @@ type Spell struct {
 	Known          bool        `json:"known"`          // For known casters
 	Ritual         bool        `json:"ritual"`
 	Classes        []string    `json:"classes"` // Classes that can learn this spell
+
+	// Structured mechanics (backfilled from prose by tools/spellconvert)
+	HigherLevelSlot string          `json:"higherLevelSlot,omitempty"` // Upcasting info
+	AttackType      SpellAttackType `json:"attackType,omitempty"`      // melee, ranged or none
+	SavingThrow     string          `json:"savingThrow,omitempty"`     // e.g. "dexterity"
+	Damage          []SpellDamage   `json:"damage,omitempty"`
+	Healing         *SpellHealing   `json:"healing,omitempty"`
+	Area            *SpellArea      `json:"area,omitempty"`
+	ACFormula       *ACFormula      `json:"ac_formula,omitempty"` // Base AC while the spell lasts (Mage Armor)
+
+	SpellcastingAbility string `json:"spellcasting_ability,omitempty"` // Overrides the spellbook ability (species/feat spells)
 }
 
 // GetComponentsString returns components as a formatted string
@@ -102,13 +114,28 @@ func (s *SpellSlot) RestoreAll() {
 	s.Current = s.Maximum
 }
 
+// PactSlots are the Pact Magic slots (Warlock): all of one level, kept apart from the
+// other slots and recovered on a short rest
+type PactSlots struct {
+	SpellSlot
+	Level int `json:"level"` // Slot level
+}
+
 // SpellBook holds all character spells and slots
 type SpellBook struct {
 	Spells           []Spell    `json:"spells"`
 	Slots            SpellSlots `json:"slots"`
+	PactSlots        PactSlots  `json:"pact_slots"`
 	SpellcastingMod  AbilityType `json:"spellcasting_mod"`  // INT, WIS, or CHA
 	SpellSaveDC      int        `json:"spell_save_dc"`
 	SpellAttackBonus int        `json:"spell_attack_bonus"`
+	CantripsKnown    int        `json:"cantrips_known,omitempty"` // From the class's spellcasting_info
+	SpellsKnown      int        `json:"spells_known,omitempty"`
+
+	// Wizard spellbook (spells copied into the physical book, separate from prepared spells)
+	Book       []Spell  `json:"book,omitempty"`
+	BackupBook []string `json:"backup_book,omitempty"` // Spell names copied into a backup book
+	BookLost   bool     `json:"book_lost,omitempty"`
 }
 
 // GetSlotByLevel returns a pointer to the spell slot for a given level
@@ -137,8 +164,23 @@ func (sb *SpellBook) GetSlotByLevel(level int) *SpellSlot {
 	}
 }
 
+// UseSlot spends a slot of the given level, a Pact Magic slot of that level once the
+// others are gone
+func (sb *SpellBook) UseSlot(level int) bool {
+	if slot := sb.GetSlotByLevel(level); slot != nil && slot.UseSlot() {
+		return true
+	}
+	return sb.PactSlots.Level == level && sb.PactSlots.UseSlot()
+}
+
+// ShortRest restores the Pact Magic slots
+func (sb *SpellBook) ShortRest() {
+	sb.PactSlots.RestoreAll()
+}
+
 // LongRest restores all spell slots
 func (sb *SpellBook) LongRest() {
+	sb.PactSlots.RestoreAll()
 	sb.Slots.Level1.RestoreAll()
 	sb.Slots.Level2.RestoreAll()
 	sb.Slots.Level3.RestoreAll()
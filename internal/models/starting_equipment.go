@@ -0,0 +1,356 @@
+// internal/models/starting_equipment.go
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// OriginStartingGold is the gold a background gives instead of its equipment
+const OriginStartingGold = 50
+
+// equipmentSourceType tracks a class's starting equipment, so changing class removes it
+const equipmentSourceType = "equipment"
+
+// EquipmentItem is an item of starting equipment. An item with choices is a pick from
+// the catalog, e.g. "any Martial Melee Weapon" or a Holy Symbol.
+type EquipmentItem struct {
+	Name     string // Catalog name, or the text as written if it isn't in the catalog
+	Quantity int
+	Choices  []string // Catalog items to pick from, one per quantity
+}
+
+// EquipmentBundle is one alternative of a line: the items that come together
+type EquipmentBundle struct {
+	Label string // As written, e.g. "Light Crossbow and 20 Bolts"
+	Items []EquipmentItem
+}
+
+// EquipmentLine is one line of starting equipment; it has several options when it
+// offers a choice, e.g. "Quarterstaff or Dagger"
+type EquipmentLine struct {
+	Entry   string
+	Options []EquipmentBundle
+}
+
+// EquipmentStep is a choice the player makes while resolving starting equipment
+type EquipmentStep struct {
+	Prompt  string
+	Options []string
+}
+
+// StartingEquipment is the equipment a class or background starts with, and the gold
+// that can be taken instead
+type StartingEquipment struct {
+	Source BenefitSource
+	Lines  []EquipmentLine
+	Gold   int // 0 if there is no gold alternative
+}
+
+// ClassStartingEquipment parses a class's starting equipment
+func ClassStartingEquipment(class *Class) StartingEquipment {
+	equipment := StartingEquipment{
+		Source: BenefitSource{Type: equipmentSourceType, Name: class.Name},
+		Gold:   class.StartingGold,
+	}
+	for _, entry := range class.StartingEquipment {
+		equipment.Lines = append(equipment.Lines, ParseEquipmentLine(entry))
+	}
+	return equipment
+}
+
+// OriginStartingEquipment lists a background's equipment, each entry a single item,
+// with 50 GP as the alternative
+func OriginStartingEquipment(origin *Origin) StartingEquipment {
+	equipment := StartingEquipment{
+		Source: BenefitSource{Type: "origin", Name: origin.Name},
+		Gold:   OriginStartingGold,
+	}
+	for _, entry := range origin.Equipment {
+		item := parseEquipmentItem(entry)
+		equipment.Lines = append(equipment.Lines, EquipmentLine{
+			Entry:   entry,
+			Options: []EquipmentBundle{{Label: entry, Items: []EquipmentItem{item}}},
+		})
+	}
+	return equipment
+}
+
+var (
+	// equipmentCount matches a leading quantity, e.g. "20 Arrows" or "Two Daggers"
+	equipmentCount = regexp.MustCompile(`(?i)^(\d+|two|three|four|five|ten|twenty) `)
+	// equipmentCoins matches coins given with equipment, e.g. "50 GP"
+	equipmentCoins = regexp.MustCompile(`(?i)^\d+ (cp|sp|ep|gp|pp)$`)
+	// equipmentNoteCount matches a quantity in parentheses, e.g. "Dagger (2)"
+	equipmentNoteCount = regexp.MustCompile(`\s*\((\d+)[^)]*\)$`)
+	// equipmentNote matches a note, e.g. "Warhammer (if proficient)"
+	equipmentNote = regexp.MustCompile(`\s*\([^)]*\)$`)
+	// equipmentQuiver matches "Quiver of 20 Arrows", which is a quiver and the arrows
+	equipmentQuiver = regexp.MustCompile(`(?i)quiver of (\d+) arrows`)
+	// equipmentWeapon matches a pick such as "Martial Weapon" or "Simple Melee Weapon"
+	equipmentWeapon = regexp.MustCompile(`(?i)^(simple|martial)( melee| ranged)? weapon$`)
+)
+
+var equipmentNumbers = map[string]int{"two": 2, "three": 3, "four": 4, "five": 5, "ten": 10, "twenty": 20}
+
+// ParseEquipmentLine splits a starting equipment line into its alternatives ("A or B",
+// "A, B, or C") and each alternative into its items ("A, B, and C")
+func ParseEquipmentLine(entry string) EquipmentLine {
+	var options []string
+	if head, last, ok := strings.Cut(entry, ", or "); ok {
+		options = append(strings.Split(head, ", "), last)
+	} else {
+		options = strings.Split(entry, " or ")
+	}
+
+	line := EquipmentLine{Entry: entry}
+	bundle := strings.NewReplacer(", and ", ",", " and ", ",")
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		var items []EquipmentItem
+		parts := bundle.Replace(equipmentQuiver.ReplaceAllString(option, "Quiver, $1 Arrows"))
+		for _, part := range strings.Split(parts, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				items = append(items, parseEquipmentItem(part))
+			}
+		}
+		line.Options = append(line.Options, EquipmentBundle{Label: option, Items: items})
+	}
+	return line
+}
+
+// parseEquipmentItem reads a quantity and looks the item up in the catalog
+func parseEquipmentItem(text string) EquipmentItem {
+	text = strings.TrimSpace(text)
+	if equipmentCoins.MatchString(text) {
+		return EquipmentItem{Name: text, Quantity: 1}
+	}
+
+	item := EquipmentItem{Quantity: 1}
+	if match := equipmentCount.FindStringSubmatch(text); match != nil {
+		if n, err := strconv.Atoi(match[1]); err == nil {
+			item.Quantity = n
+		} else {
+			item.Quantity = equipmentNumbers[strings.ToLower(match[1])]
+		}
+		text = text[len(match[0]):]
+	}
+	if lower := strings.ToLower(text); strings.HasPrefix(lower, "any ") {
+		text = text[len("any "):]
+	}
+	item.Name = text
+
+	if match := equipmentWeapon.FindStringSubmatch(singular(text)); match != nil {
+		category := strings.ToLower(match[1] + match[2])
+		item.Name = singular(text)
+		for _, def := range GetAllItemDefinitions() {
+			if strings.EqualFold(def.Category, "weapon") && strings.HasPrefix(strings.ToLower(def.Subcategory), category) {
+				item.Choices = append(item.Choices, def.Name)
+			}
+		}
+		return item
+	}
+
+	name, per, choices := lookupEquipment(text)
+	if name == "" {
+		return item
+	}
+	item.Name = name
+	item.Quantity *= per
+	item.Choices = choices
+
+	// Ammunition comes in bundles: 20 Arrows are one "Arrows (20)"
+	if match := equipmentNoteCount.FindStringSubmatch(name); match != nil && len(choices) == 0 {
+		if size, _ := strconv.Atoi(match[1]); size > 0 && item.Quantity%size == 0 {
+			item.Quantity /= size
+		}
+	}
+	return item
+}
+
+// singular drops the plural ending of the last word, e.g. "Martial Weapons"
+func singular(text string) string {
+	return strings.TrimSuffix(text, "s")
+}
+
+// lookupEquipment finds the catalog item an equipment name refers to, ignoring plurals
+// and notes. It returns the name, a quantity given in parentheses ("Dagger (2)"), and
+// the items to pick from when the name is generic: the variants of "Holy Symbol", or
+// the items of a subcategory such as "Gaming Set". The name is empty if nothing matches.
+func lookupEquipment(text string) (string, int, []string) {
+	defs := GetAllItemDefinitions()
+	exact := func(name string) string {
+		for _, form := range []string{name, strings.TrimSuffix(name, "s"), strings.TrimSuffix(name, "es")} {
+			for _, def := range defs {
+				if strings.EqualFold(def.Name, form) {
+					return def.Name
+				}
+			}
+		}
+		return ""
+	}
+
+	if name := exact(text); name != "" {
+		return name, 1, nil
+	}
+	per := 1
+	if match := equipmentNoteCount.FindStringSubmatch(text); match != nil {
+		per, _ = strconv.Atoi(match[1])
+		text = text[:len(text)-len(match[0])]
+	}
+	text = equipmentNote.ReplaceAllString(text, "")
+	if name := exact(text); name != "" {
+		return name, per, nil
+	}
+
+	// Variants ("Holy Symbol (Amulet)") and bundles ("Crossbow Bolts (20)")
+	lower := strings.ToLower(text)
+	var variants []string
+	for _, form := range []string{lower, strings.TrimSuffix(lower, "s")} {
+		for _, def := range defs {
+			name := strings.ToLower(def.Name)
+			if strings.HasPrefix(name, form+" (") || strings.Contains(name, " "+form+" (") {
+				variants = append(variants, def.Name)
+			}
+		}
+		if len(variants) > 0 {
+			break
+		}
+	}
+	switch len(variants) {
+	case 0:
+	case 1:
+		return variants[0], per, nil
+	default:
+		return text, per, variants
+	}
+
+	// A kind of item, e.g. "Musical Instrument" or "Artisan's Tools"
+	var kinds []string
+	for _, form := range []string{lower, strings.TrimSuffix(lower, "s")} {
+		kind := strings.ReplaceAll(strings.ReplaceAll(form, "'", ""), " ", "_")
+		for _, def := range defs {
+			if def.Subcategory == kind {
+				kinds = append(kinds, def.Name)
+			}
+		}
+		if len(kinds) > 0 {
+			return text, per, kinds
+		}
+	}
+
+	// A described item, e.g. "Wooden Shield" is a Shield
+	for _, def := range defs {
+		if strings.HasSuffix(lower, " "+strings.ToLower(def.Name)) {
+			return def.Name, per, nil
+		}
+	}
+	return "", 1, nil
+}
+
+// Resolve replays the answers given so far (an option index per step) and returns the
+// next choice to make, or nil when every choice is made together with the resulting
+// items, or the gold if it was taken instead
+func (se StartingEquipment) Resolve(answers []int) (*EquipmentStep, []EquipmentItem, int) {
+	next := 0
+	answer := func(options int) (int, bool) {
+		if next >= len(answers) {
+			return 0, false
+		}
+		next++
+		return min(max(answers[next-1], 0), options-1), true
+	}
+
+	if se.Gold > 0 {
+		step := &EquipmentStep{
+			Prompt:  "Take the starting equipment or the gold?",
+			Options: []string{"Take the equipment", fmt.Sprintf("Take %d GP instead", se.Gold)},
+		}
+		choice, ok := answer(len(step.Options))
+		if !ok {
+			return step, nil, 0
+		}
+		if choice == 1 {
+			return nil, nil, se.Gold
+		}
+	}
+
+	var items []EquipmentItem
+	for _, line := range se.Lines {
+		bundle := line.Options[0]
+		if len(line.Options) > 1 {
+			step := &EquipmentStep{Prompt: "Choose: " + line.Entry}
+			for _, option := range line.Options {
+				step.Options = append(step.Options, option.Label)
+			}
+			choice, ok := answer(len(step.Options))
+			if !ok {
+				return step, nil, 0
+			}
+			bundle = line.Options[choice]
+		}
+
+		for _, item := range bundle.Items {
+			if len(item.Choices) == 0 {
+				items = append(items, item)
+				continue
+			}
+			for i := 1; i <= item.Quantity; i++ {
+				step := &EquipmentStep{Prompt: "Choose your " + item.Name, Options: item.Choices}
+				if item.Quantity > 1 {
+					step.Prompt += fmt.Sprintf(" (%d of %d)", i, item.Quantity)
+				}
+				choice, ok := answer(len(step.Options))
+				if !ok {
+					return step, nil, 0
+				}
+				items = append(items, EquipmentItem{Name: item.Choices[choice], Quantity: 1})
+			}
+		}
+	}
+	return nil, items, 0
+}
+
+// PackContents returns the items a pack unpacks into, or nil if the item isn't a pack
+func PackContents(name string) []EquipmentItem {
+	def := GetItemDefinitionByName(name)
+	if def == nil {
+		return nil
+	}
+	var contents []EquipmentItem
+	for _, entry := range def.Contents {
+		contents = append(contents, parseEquipmentItem(entry))
+	}
+	return contents
+}
+
+// Apply adds the resolved items (packs unpacked) or the gold through the benefit
+// applier, tracked under the equipment's source
+func (se StartingEquipment) Apply(char *Character, items []EquipmentItem, gold int) {
+	applier := NewBenefitApplier(char)
+	if gold > 0 {
+		applier.AddItem(se.Source, fmt.Sprintf("%d GP", gold), 1)
+	}
+	for _, item := range items {
+		contents := PackContents(item.Name)
+		if contents == nil {
+			applier.AddItem(se.Source, item.Name, item.Quantity)
+			continue
+		}
+		for range item.Quantity {
+			for _, content := range contents {
+				applier.AddItem(se.Source, content.Name, content.Quantity)
+			}
+		}
+	}
+}
+
+// RemoveClassEquipment removes the starting equipment of the character's classes
+func RemoveClassEquipment(char *Character) {
+	remover := NewBenefitRemover(char)
+	for _, cl := range char.GetClasses() {
+		remover.RemoveAllBenefits(equipmentSourceType, cl.Class)
+	}
+}
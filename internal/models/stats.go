@@ -1,7 +1,10 @@
 // internal/models/stats.go
 package models
 
-import "math"
+import (
+	"math"
+	"strings"
+)
 
 // AbilityType represents one of the six core abilities
 type AbilityType string
@@ -15,6 +18,46 @@ const (
 	Charisma     AbilityType = "CHA"
 )
 
+// ParseAbilityType converts an ability name ("Dexterity", "dex", "DEX") to an AbilityType
+func ParseAbilityType(name string) (AbilityType, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "strength", "str":
+		return Strength, true
+	case "dexterity", "dex":
+		return Dexterity, true
+	case "constitution", "con":
+		return Constitution, true
+	case "intelligence", "int":
+		return Intelligence, true
+	case "wisdom", "wis":
+		return Wisdom, true
+	case "charisma", "cha":
+		return Charisma, true
+	default:
+		return "", false
+	}
+}
+
+// FullName returns the ability's full name, e.g. "Dexterity" for DEX
+func (a AbilityType) FullName() string {
+	switch a {
+	case Strength:
+		return "Strength"
+	case Dexterity:
+		return "Dexterity"
+	case Constitution:
+		return "Constitution"
+	case Intelligence:
+		return "Intelligence"
+	case Wisdom:
+		return "Wisdom"
+	case Charisma:
+		return "Charisma"
+	default:
+		return string(a)
+	}
+}
+
 // AbilityScores holds all six ability scores
 type AbilityScores struct {
 	Strength     int `json:"strength"`
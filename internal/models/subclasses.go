@@ -0,0 +1,226 @@
+// internal/models/subclasses.go
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
+)
+
+// SubclassSourceType is the benefit source type of subclass grants; features show
+// their source as "Subclass: Champion"
+const SubclassSourceType = "Subclass"
+
+// Subclass is a class specialization (Primal Path, Arcane Tradition, ...) chosen at
+// the class's subclass_choice level
+type Subclass struct {
+	Name        string          `json:"name"`
+	Class       string          `json:"class"`
+	Description string          `json:"description"`
+	Levels      []SubclassLevel `json:"levels"`
+}
+
+// SubclassLevel is what a subclass grants at a class level
+type SubclassLevel struct {
+	Level               int                  `json:"level"`
+	Features            []ClassFeature       `json:"features,omitempty"`
+	Spells              []string             `json:"spells,omitempty"` // Always prepared
+	ArmorProficiencies  []string             `json:"armor_proficiencies,omitempty"`
+	WeaponProficiencies []string             `json:"weapon_proficiencies,omitempty"`
+	ToolProficiencies   []string             `json:"tool_proficiencies,omitempty"`
+	SkillProficiencies  []string             `json:"skill_proficiencies,omitempty"`
+	Resources           []ResourceDefinition `json:"resources,omitempty"` // Pools gained or changed, like a class level's
+}
+
+// SubclassSource returns the benefit source of a subclass
+func SubclassSource(subclassName string) BenefitSource {
+	return BenefitSource{Type: SubclassSourceType, Name: subclassName}
+}
+
+// GetLevel returns what the subclass grants at a class level, or nil
+func (s *Subclass) GetLevel(level int) *SubclassLevel {
+	for i := range s.Levels {
+		if s.Levels[i].Level == level {
+			return &s.Levels[i]
+		}
+	}
+	return nil
+}
+
+var cachedSubclasses []Subclass
+
+// LoadSubclassesFromJSON loads all subclasses from the JSON files in a directory
+func LoadSubclassesFromJSON(dirpath string) ([]Subclass, error) {
+	files, err := os.ReadDir(dirpath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subclasses directory: %w", err)
+	}
+
+	subclasses := []Subclass{}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		filePath := filepath.Join(dirpath, file.Name())
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			diagnostics.Errorf(filePath, 0, "failed to read subclass file: %v", err)
+			continue
+		}
+
+		var subclass Subclass
+		if err := json.Unmarshal(data, &subclass); err != nil {
+			diagnostics.ReportJSONError(filePath, data, err)
+			continue
+		}
+		subclasses = append(subclasses, subclass)
+	}
+
+	sort.Slice(subclasses, func(i, j int) bool {
+		if subclasses[i].Class != subclasses[j].Class {
+			return subclasses[i].Class < subclasses[j].Class
+		}
+		return subclasses[i].Name < subclasses[j].Name
+	})
+	cachedSubclasses = subclasses
+	return subclasses, nil
+}
+
+// GetAllSubclasses returns all available subclasses
+func GetAllSubclasses() []Subclass {
+	if cachedSubclasses == nil {
+		if _, err := LoadSubclassesFromJSON("data/subclasses"); err != nil {
+			diagnostics.Errorf("data/subclasses", 0, "%v", err)
+			return []Subclass{}
+		}
+	}
+	return cachedSubclasses
+}
+
+// GetSubclassesForClass returns the subclasses of a class
+func GetSubclassesForClass(className string) []Subclass {
+	var subclasses []Subclass
+	for _, subclass := range GetAllSubclasses() {
+		if strings.EqualFold(subclass.Class, className) {
+			subclasses = append(subclasses, subclass)
+		}
+	}
+	return subclasses
+}
+
+// GetSubclass returns a subclass of a class by name, or nil
+func GetSubclass(className, subclassName string) *Subclass {
+	subclasses := GetAllSubclasses()
+	for i := range subclasses {
+		if strings.EqualFold(subclasses[i].Class, className) && strings.EqualFold(subclasses[i].Name, subclassName) {
+			return &subclasses[i]
+		}
+	}
+	return nil
+}
+
+// SubclassChoiceLevel returns the class level whose features include the subclass choice, or 0
+func SubclassChoiceLevel(class *Class) int {
+	for _, entry := range class.LevelProgression {
+		for _, feature := range entry.Features {
+			if feature.SubclassChoice {
+				return entry.Level
+			}
+		}
+	}
+	return 0
+}
+
+// PendingSubclasses returns the classes the character has reached the subclass choice
+// level in without choosing a subclass
+func PendingSubclasses(char *Character) []string {
+	var pending []string
+	for _, cl := range char.GetClasses() {
+		class := GetClassByName(cl.Class)
+		if class == nil || cl.Subclass != "" {
+			continue
+		}
+		if choice := SubclassChoiceLevel(class); choice > 0 && cl.Level >= choice {
+			pending = append(pending, cl.Class)
+		}
+	}
+	return pending
+}
+
+// ChooseSubclass records the subclass of a class and grants everything it gives up to
+// the character's level in that class. Subclasses not in the catalog are only recorded.
+func ChooseSubclass(char *Character, className, subclassName string) error {
+	EnsureClassLevels(char)
+	classLevels := char.GetClassLevels(className)
+	if classLevels == nil {
+		return fmt.Errorf("no levels in %s", className)
+	}
+	if classLevels.Subclass != "" {
+		return fmt.Errorf("%s already has the subclass %s", className, classLevels.Subclass)
+	}
+	classLevels.Subclass = subclassName
+
+	subclass := GetSubclass(className, subclassName)
+	if subclass == nil {
+		return nil
+	}
+	classLevels.Subclass = subclass.Name
+	for level := 1; level <= classLevels.Level; level++ {
+		ApplySubclassLevel(char, subclass, level)
+	}
+	SyncClassResources(char)
+	return nil
+}
+
+// ApplySubclassLevel grants the features, always-prepared spells and proficiencies of a
+// subclass at a class level, tracked under the subclass source
+func ApplySubclassLevel(char *Character, subclass *Subclass, level int) {
+	entry := subclass.GetLevel(level)
+	if entry == nil {
+		return
+	}
+	source := SubclassSource(subclass.Name)
+	applier := NewBenefitApplier(char)
+
+	for _, feature := range entry.Features {
+		applier.AddFeature(source, feature.ToFeatureDefinition())
+	}
+
+	ability := ""
+	if class := GetClassByName(subclass.Class); class != nil && class.Spellcasting != nil {
+		ability = class.Spellcasting.Ability
+	}
+	for _, spell := range entry.Spells {
+		applier.AddSpellGrant(source, SpellGrant{SpellName: spell, Ability: ability})
+	}
+
+	for _, armor := range entry.ArmorProficiencies {
+		applier.AddArmorProficiency(source, armor)
+	}
+	for _, weapon := range entry.WeaponProficiencies {
+		applier.AddWeaponProficiency(source, weapon)
+	}
+	for _, tool := range entry.ToolProficiencies {
+		applier.AddToolProficiency(source, tool)
+	}
+	for _, skill := range entry.SkillProficiencies {
+		if s := char.Skills.GetSkill(SkillType(skill)); s != nil && s.Proficiency > 0 {
+			continue // Don't turn an existing proficiency into expertise
+		}
+		applier.AddSkillProficiency(source, skill)
+	}
+	char.UpdateDerivedStats()
+}
+
+// HasSubclassFeatures returns true if the subclass defines features at a class level,
+// replacing the class's generic "Subclass Feature" entry
+func (s *Subclass) HasSubclassFeatures(level int) bool {
+	entry := s.GetLevel(level)
+	return entry != nil && len(entry.Features) > 0
+}
@@ -0,0 +1,373 @@
+// internal/models/wizard_spellbook.go
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// ScribeCostPerLevel is the gold cost to copy a spell into a spellbook, per spell level
+	ScribeCostPerLevel = 50
+	// ScribeHoursPerLevel is the downtime needed to copy a spell, per spell level
+	ScribeHoursPerLevel = 2
+	// BackupCostPerLevel is the gold cost to copy a spell from your own book into a backup
+	BackupCostPerLevel = 10
+	// BackupHoursPerLevel is the downtime needed to copy a spell into a backup book
+	BackupHoursPerLevel = 1
+
+	// SpellbookItem is the inventory item holding the wizard's spellbook
+	SpellbookItem = "Spellbook"
+	// BackupSpellbookItem is the inventory item holding a backup copy of the spellbook
+	BackupSpellbookItem = "Spellbook (Backup)"
+
+	// StartingBookSpells is the number of level 1 spells a new spellbook holds
+	StartingBookSpells = 6
+	// BookSpellsPerLevel is the number of spells added to the spellbook on each later level
+	BookSpellsPerLevel = 2
+)
+
+// DowntimeEntry records time spent on a downtime activity
+type DowntimeEntry struct {
+	Activity string `json:"activity"`
+	Hours    int    `json:"hours"`
+	Cost     int    `json:"cost,omitempty"` // Gold spent
+	Date     string `json:"date"`           // YYYY-MM-DD
+}
+
+// LogDowntime appends an entry to the character's downtime log
+func (c *Character) LogDowntime(activity string, hours, cost int) {
+	c.Downtime = append(c.Downtime, DowntimeEntry{
+		Activity: activity,
+		Hours:    hours,
+		Cost:     cost,
+		Date:     time.Now().Format("2006-01-02"),
+	})
+}
+
+// UsesSpellbook returns true if one of the character's classes prepares spells from a spellbook
+func UsesSpellbook(char *Character) bool {
+	return SpellbookClass(char) != ""
+}
+
+// SpellbookClass returns the character's class that prepares spells from a spellbook, or ""
+func SpellbookClass(char *Character) string {
+	for _, cl := range char.GetClasses() {
+		class := GetClassByName(cl.Class)
+		if class != nil && class.Spellcasting != nil && class.Spellcasting.Spellbook {
+			return class.Name
+		}
+	}
+	return ""
+}
+
+// InBook checks if a spell has been copied into the spellbook
+func (sb *SpellBook) InBook(name string) bool {
+	for _, spell := range sb.Book {
+		if strings.EqualFold(spell.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddToBook adds a spell to the spellbook without cost (spells learned on level up)
+func (sb *SpellBook) AddToBook(spell Spell) {
+	if sb.InBook(spell.Name) {
+		return
+	}
+	spell.Prepared = false
+	sb.Book = append(sb.Book, spell)
+}
+
+// BookSpellsAt returns how many spells are added to the spellbook for free on reaching a
+// class level: the starting spells at level 1, then two per level
+func BookSpellsAt(classLevel int) int {
+	if classLevel <= 1 {
+		return StartingBookSpells
+	}
+	return BookSpellsPerLevel
+}
+
+// maxBookSpellLevel returns the highest spell level a class has slots for at a class level
+func maxBookSpellLevel(class *Class, classLevel int) int {
+	highest := 0
+	if entry := class.GetLevel(classLevel); entry != nil && entry.SpellcastingInfo != nil {
+		for slotLevel, count := range entry.SpellcastingInfo.Slots() {
+			if count > 0 && slotLevel > highest {
+				highest = slotLevel
+			}
+		}
+	}
+	return highest
+}
+
+// BookSpellOptions returns the spells a spellbook class can add to the book for free at a
+// class level: spells of its list, up to the highest level it has slots for, not already
+// in the book
+func BookSpellOptions(char *Character, className string, classLevel int) []Spell {
+	class := GetClassByName(className)
+	if class == nil {
+		return nil
+	}
+	var options []Spell
+	for level := 1; level <= maxBookSpellLevel(class, classLevel); level++ {
+		for _, spell := range GetSpellsForClass(class.Name, level) {
+			if !char.SpellBook.InBook(spell.Name) {
+				options = append(options, spell)
+			}
+		}
+	}
+	return options
+}
+
+// CheckBookSpells checks spells chosen to add to the spellbook on reaching a class level
+// and returns them from the catalog. Nothing is changed, so a level up can check its
+// choices before applying them.
+func CheckBookSpells(char *Character, className string, classLevel int, names []string) ([]Spell, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	class := GetClassByName(className)
+	if class == nil || class.Spellcasting == nil || !class.Spellcasting.Spellbook {
+		return nil, fmt.Errorf("%s does not keep a spellbook", className)
+	}
+	if char.SpellBook.BookLost {
+		return nil, fmt.Errorf("your spellbook is lost")
+	}
+	if allowed := BookSpellsAt(classLevel); len(names) > allowed {
+		return nil, fmt.Errorf("only %d spells are added to the spellbook at %s level %d", allowed, class.Name, classLevel)
+	}
+
+	options := BookSpellOptions(char, class.Name, classLevel)
+	var spells []Spell
+	for _, name := range names {
+		var found *Spell
+		for i := range options {
+			if strings.EqualFold(options[i].Name, name) {
+				found = &options[i]
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("%s can't be added to the spellbook at %s level %d", name, class.Name, classLevel)
+		}
+		for _, spell := range spells {
+			if spell.Name == found.Name {
+				return nil, fmt.Errorf("%s is chosen twice", found.Name)
+			}
+		}
+		spells = append(spells, *found)
+	}
+	return spells, nil
+}
+
+// LearnBookSpells adds the spells learned for free at a class level to the spellbook
+func LearnBookSpells(char *Character, className string, classLevel int, names []string) error {
+	spells, err := CheckBookSpells(char, className, classLevel, names)
+	if err != nil {
+		return err
+	}
+	for _, spell := range spells {
+		char.SpellBook.AddToBook(spell)
+	}
+	return nil
+}
+
+// RemoveFromBook removes a spell from the spellbook and unprepares it
+func (sb *SpellBook) RemoveFromBook(name string) {
+	for i := range sb.Book {
+		if strings.EqualFold(sb.Book[i].Name, name) {
+			sb.RemoveSpell(sb.Book[i].Name)
+			sb.Book = append(sb.Book[:i], sb.Book[i+1:]...)
+			return
+		}
+	}
+}
+
+// EnsureSpellbook copies the spells of a spellbook class into an empty spellbook. Saves
+// from before the spellbook was tracked kept the wizard's spells only in the spell list,
+// and spells can only be prepared from the book.
+func EnsureSpellbook(char *Character) {
+	className := SpellbookClass(char)
+	if className == "" || len(char.SpellBook.Book) > 0 || char.SpellBook.BookLost {
+		return
+	}
+	for _, spell := range char.SpellBook.Spells {
+		if spell.Level == 0 || spell.SpellcastingAbility != "" {
+			continue // Cantrips aren't kept in the book; species and feat spells aren't the class's
+		}
+		for _, class := range spell.Classes {
+			if strings.EqualFold(class, className) {
+				char.SpellBook.AddToBook(spell)
+				break
+			}
+		}
+	}
+}
+
+// CopySpellToBook scribes a spell into the spellbook, paying gold and logging downtime
+func CopySpellToBook(char *Character, spell Spell) error {
+	if !UsesSpellbook(char) {
+		return fmt.Errorf("%s does not keep a spellbook", char.Class)
+	}
+	if char.SpellBook.BookLost {
+		return fmt.Errorf("your spellbook is lost")
+	}
+	if spell.Level == 0 {
+		return fmt.Errorf("cantrips are not copied into a spellbook")
+	}
+	if char.SpellBook.InBook(spell.Name) {
+		return fmt.Errorf("%s is already in your spellbook", spell.Name)
+	}
+	if slot := char.SpellBook.GetSlotByLevel(spell.Level); slot == nil || slot.Maximum == 0 {
+		return fmt.Errorf("you have no level %d spell slots to copy %s", spell.Level, spell.Name)
+	}
+
+	cost := spell.Level * ScribeCostPerLevel
+	if char.Inventory.Coins.Value() < cost*CopperPerGold {
+		return fmt.Errorf("copying %s costs %d gp, you have %s", spell.Name, cost, char.Inventory.Coins)
+	}
+
+	char.Inventory.Pay(cost*CopperPerGold, fmt.Sprintf("Copied %s into spellbook", spell.Name))
+	char.SpellBook.AddToBook(spell)
+	char.LogDowntime(fmt.Sprintf("Copied %s into spellbook", spell.Name), spell.Level*ScribeHoursPerLevel, cost)
+	return nil
+}
+
+// PrepareSpell prepares a spell; spellbook casters can only prepare spells from their book
+func PrepareSpell(char *Character, name string) error {
+	if UsesSpellbook(char) {
+		if char.SpellBook.BookLost {
+			return fmt.Errorf("your spellbook is lost")
+		}
+		if !char.SpellBook.InBook(name) {
+			return fmt.Errorf("%s is not in your spellbook", name)
+		}
+	}
+
+	for i := range char.SpellBook.Spells {
+		if strings.EqualFold(char.SpellBook.Spells[i].Name, name) {
+			char.SpellBook.Spells[i].Prepared = true
+			return nil
+		}
+	}
+
+	for _, spell := range char.SpellBook.Book {
+		if strings.EqualFold(spell.Name, name) {
+			spell.Prepared = true
+			char.SpellBook.AddSpell(spell)
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not known", name)
+}
+
+// UnprepareSpell removes a spell from the prepared list.
+// Book spells leave the spell list entirely; they remain in the spellbook.
+func UnprepareSpell(char *Character, name string) {
+	for i := range char.SpellBook.Spells {
+		if !strings.EqualFold(char.SpellBook.Spells[i].Name, name) {
+			continue
+		}
+		if char.SpellBook.InBook(name) {
+			char.SpellBook.RemoveSpell(char.SpellBook.Spells[i].Name)
+		} else {
+			char.SpellBook.Spells[i].Prepared = false
+		}
+		return
+	}
+}
+
+// IsSpellPrepared checks if a spell is currently prepared
+func (sb *SpellBook) IsSpellPrepared(name string) bool {
+	for _, spell := range sb.Spells {
+		if strings.EqualFold(spell.Name, name) {
+			return spell.Prepared
+		}
+	}
+	return false
+}
+
+// HasBackupSpellbook checks if the character carries a backup spellbook
+func HasBackupSpellbook(char *Character) bool {
+	for _, item := range char.Inventory.Items {
+		if item.Name == BackupSpellbookItem {
+			return true
+		}
+	}
+	return false
+}
+
+// CopySpellToBackup copies a spell from the spellbook into the backup book
+func CopySpellToBackup(char *Character, name string) error {
+	if !HasBackupSpellbook(char) {
+		return fmt.Errorf("you need a %s in your inventory", BackupSpellbookItem)
+	}
+	if char.SpellBook.BookLost {
+		return fmt.Errorf("your spellbook is lost")
+	}
+
+	var spell *Spell
+	for i := range char.SpellBook.Book {
+		if strings.EqualFold(char.SpellBook.Book[i].Name, name) {
+			spell = &char.SpellBook.Book[i]
+			break
+		}
+	}
+	if spell == nil {
+		return fmt.Errorf("%s is not in your spellbook", name)
+	}
+	for _, backup := range char.SpellBook.BackupBook {
+		if strings.EqualFold(backup, name) {
+			return fmt.Errorf("%s is already in your backup book", name)
+		}
+	}
+
+	cost := spell.Level * BackupCostPerLevel
+	if char.Inventory.Coins.Value() < cost*CopperPerGold {
+		return fmt.Errorf("copying %s to your backup costs %d gp, you have %s", spell.Name, cost, char.Inventory.Coins)
+	}
+
+	char.Inventory.Pay(cost*CopperPerGold, fmt.Sprintf("Copied %s into backup spellbook", spell.Name))
+	char.SpellBook.BackupBook = append(char.SpellBook.BackupBook, spell.Name)
+	char.LogDowntime(fmt.Sprintf("Copied %s into backup spellbook", spell.Name), spell.Level*BackupHoursPerLevel, cost)
+	return nil
+}
+
+// LoseSpellbook marks the spellbook as lost. Prepared spells stay prepared.
+func LoseSpellbook(char *Character) {
+	char.SpellBook.BookLost = true
+	char.Inventory.RemoveItem(SpellbookItem, 1)
+}
+
+// RecoverSpellbook replaces a lost spellbook with the backup book.
+// Only spells copied into the backup are recovered. Returns the number of spells recovered.
+func RecoverSpellbook(char *Character) (int, error) {
+	if !char.SpellBook.BookLost {
+		return 0, fmt.Errorf("your spellbook isn't lost")
+	}
+	if !HasBackupSpellbook(char) {
+		return 0, fmt.Errorf("you have no %s to recover from", BackupSpellbookItem)
+	}
+
+	var recovered []Spell
+	for _, name := range char.SpellBook.BackupBook {
+		for _, spell := range char.SpellBook.Book {
+			if strings.EqualFold(spell.Name, name) {
+				recovered = append(recovered, spell)
+				break
+			}
+		}
+	}
+
+	char.SpellBook.Book = recovered
+	char.SpellBook.BackupBook = nil
+	char.SpellBook.BookLost = false
+
+	// The backup becomes the new spellbook
+	char.Inventory.RemoveItem(BackupSpellbookItem, 1)
+	char.Inventory.AddItem(Item{Name: SpellbookItem, Type: Gear, Quantity: 1, Weight: 3, Value: 50})
+	return len(recovered), nil
+}
@@ -49,6 +49,34 @@ func (s *Storage) Load() (*models.Character, error) {
 		character.BenefitTracker = models.NewBenefitTracker()
 	}
 
+	// Record class levels (older saves predate multiclassing)
+	models.EnsureClassLevels(&character)
+
+	// Initialize class resources (older saves predate resource pools)
+	if character.Resources == nil {
+		models.SyncClassResources(&character)
+	}
+
+	// Backfill the HP history (older saves assumed the fixed value for every level)
+	if character.HPHistory == nil {
+		models.EnsureHPHistory(&character)
+	}
+
+	// Record the species speed (older saves stored only the total)
+	models.EnsureBaseSpeed(&character)
+
+	// Give charged items saved before charges were tracked their full charges
+	models.EnsureItemCharges(&character)
+
+	// Copy a wizard's spells into the spellbook (older saves predate the book)
+	models.EnsureSpellbook(&character)
+
+	// Pact Magic slots have their own pool (older saves mixed them into the slots)
+	models.EnsurePactSlots(&character)
+
+	// Give containers an ID so they can hold items
+	character.Inventory.EnsureContainerIDs()
+
 	return &character, nil
 }
 
@@ -122,3 +150,12 @@ func GetDefaultPath() string {
 	}
 	return filepath.Join(homeDir, ".lazydndplayer", "character.json")
 }
+
+// GetDefaultShopPath returns the default path of the DM's shop file
+func GetDefaultShopPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "data/shop.json"
+	}
+	return filepath.Join(homeDir, ".lazydndplayer", "shop.json")
+}
@@ -3,10 +3,14 @@ package ui
 
 import (
 	"fmt"
+	"math"
+	"os"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
+	"github.com/marcozingoni/lazydndplayer/internal/leveling"
 	"github.com/marcozingoni/lazydndplayer/internal/models"
 	"github.com/marcozingoni/lazydndplayer/internal/storage"
 	"github.com/marcozingoni/lazydndplayer/internal/ui/components"
@@ -61,6 +65,7 @@ const (
 type Model struct {
 	character    *models.Character
 	storage      *storage.Storage
+	shopFile     string // The DM's shop file
 
 	// UI Components
 	tabs             *components.Tabs
@@ -77,10 +82,22 @@ type Model struct {
 	toolSelector          *components.ToolSelector
 	itemSelector          *components.ItemSelector
 	classSelector         *components.ClassSelector
+	subclassSelector      *components.SubclassSelector
+	xpPopup               *components.XPPopup
+	levelHistory          *components.LevelHistory
+	statBreakdown         *components.StatBreakdown
+	containerPicker       *components.ContainerPicker
+	pursePopup            *components.PursePopup
+	shopPopup             *components.ShopPopup
+	loadoutPopup          *components.LoadoutPopup
+	equipmentSelector     *components.EquipmentSelector
 	classSkillSelector    *components.ClassSkillSelector
 	statGenerator         *components.StatGenerator
 	abilityRoller         *components.AbilityRoller
 	abilityChoiceSelector *components.AbilityChoiceSelector
+	spellbookView         *components.SpellbookView
+	fontOfMagicPopup      *components.FontOfMagicPopup
+	levelUpWizard         *components.LevelUpWizard
 
 	// Main Panels (switchable)
 	statsPanel     *panels.StatsPanel
@@ -105,7 +122,11 @@ type Model struct {
 	message            string
 	quitting           bool
 	pendingFeat        *models.Feat   // Temporarily store feat while choosing ability
+	pendingFeatASILevel int           // ASI level the pending feat replaces, 0 if none
 	pendingOrigin      *models.Origin // Temporarily store origin while choosing ability
+	pendingLevelDown   bool           // 'D' was pressed once and waits for a second press
+	subclassAfterEquipment bool       // Open the subclass choice once starting equipment is chosen
+	respecReplay       []leveling.LevelUpOptions // Levels to replay once the respec level up is confirmed
 }
 
 // NewModel creates a new application model
@@ -127,10 +148,22 @@ func NewModel(char *models.Character, store *storage.Storage) *Model {
 		toolSelector:          components.NewToolSelector(),
 		itemSelector:          components.NewItemSelector(),
 		classSelector:         components.NewClassSelector(),
+		subclassSelector:      components.NewSubclassSelector(),
+		xpPopup:               components.NewXPPopup(),
+		levelHistory:          components.NewLevelHistory(),
+		statBreakdown:         components.NewStatBreakdown(),
+		containerPicker:       components.NewContainerPicker(),
+		pursePopup:            components.NewPursePopup(),
+		shopPopup:             components.NewShopPopup(),
+		loadoutPopup:          components.NewLoadoutPopup(),
+		equipmentSelector:     components.NewEquipmentSelector(),
 		classSkillSelector:    components.NewClassSkillSelector(),
 		statGenerator:         components.NewStatGenerator(),
 		abilityRoller:         components.NewAbilityRoller(),
 		abilityChoiceSelector: components.NewAbilityChoiceSelector(),
+		spellbookView:         components.NewSpellbookView(),
+		fontOfMagicPopup:      components.NewFontOfMagicPopup(),
+		levelUpWizard:         components.NewLevelUpWizard(),
 		statsPanel:            panels.NewStatsPanel(char),
 		skillsPanel:           panels.NewSkillsPanel(char),
 		inventoryPanel:        panels.NewInventoryPanel(char),
@@ -170,6 +203,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Level-up wizard is modal and has a text field, so it takes keys before global shortcuts
+		if m.levelUpWizard.IsVisible() {
+			return m.handleLevelUpWizardKeys(msg)
+		}
+
+		// XP popup has text fields too
+		if m.xpPopup.IsVisible() {
+			return m.handleXPPopupKeys(msg)
+		}
+
 		// Global keys
 		switch msg.String() {
 		case "q", "ctrl+c":
@@ -234,6 +277,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleAbilityRollerKeys(msg)
 		}
 
+		// Spellbook uses Tab to switch modes (BEFORE tab handling)
+		if m.spellbookView.IsVisible() {
+			return m.handleSpellbookViewKeys(msg)
+		}
+
 		// Panel navigation (only when focused on main and no popups)
 		switch msg.String() {
 		case "tab":
@@ -251,6 +299,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Check if Font of Magic popup is active
+		if m.fontOfMagicPopup.IsVisible() {
+			return m.handleFontOfMagicKeys(msg)
+		}
+
 		// Check if spell selector is active
 		if m.spellSelector.IsVisible() {
 			return m.handleSpellSelectorKeys(msg)
@@ -306,6 +359,46 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleItemSelectorKeys(msg)
 		}
 
+		// Check if subclass selector is active
+		if m.subclassSelector.IsVisible() {
+			return m.handleSubclassSelectorKeys(msg)
+		}
+
+		// Check if level history is active
+		if m.levelHistory.IsVisible() {
+			return m.handleLevelHistoryKeys(msg)
+		}
+
+		// Check if stat breakdown is active
+		if m.statBreakdown.IsVisible() {
+			return m.handleStatBreakdownKeys(msg)
+		}
+
+		// Check if container picker is active
+		if m.containerPicker.IsVisible() {
+			return m.handleContainerPickerKeys(msg)
+		}
+
+		// Check if purse popup is active
+		if m.pursePopup.IsVisible() {
+			return m.handlePursePopupKeys(msg)
+		}
+
+		// Check if shop is active
+		if m.shopPopup.IsVisible() {
+			return m.handleShopKeys(msg)
+		}
+
+		// Check if loadout popup is active
+		if m.loadoutPopup.IsVisible() {
+			return m.handleLoadoutPopupKeys(msg)
+		}
+
+		// Check if starting equipment selector is active
+		if m.equipmentSelector.IsVisible() {
+			return m.handleEquipmentSelectorKeys(msg)
+		}
+
 		// Check if class skill selector is active (highest priority in class flow)
 		if m.classSkillSelector.IsVisible() {
 			return m.handleClassSkillSelectorKeys(msg)
@@ -338,6 +431,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "R": // Shift+R for rest
 			m.character.LongRest()
 			m.message = "Long rest completed! HP, spells, and abilities restored."
+			if recharged := m.rechargeItems(); recharged != "" {
+				m.message += " Recharged: " + recharged
+			}
 			return m, nil
 		}
 	}
@@ -389,6 +485,10 @@ func (m *Model) handleStatsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Roll ability check for selected ability
 		selectedAbility := m.statsPanel.GetSelectedAbility()
 		m.rollAbilityCheck(selectedAbility)
+	case "w":
+		// Explain the selected ability score
+		m.statBreakdown.Show(m.character, m.statsPanel.GetSelectedAbility().FullName())
+		m.message = "Stat breakdown..."
 	}
 	return m, nil
 }
@@ -396,37 +496,10 @@ func (m *Model) handleStatsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // rollSavingThrow rolls a saving throw for the given ability
 func (m *Model) rollSavingThrow(ability models.AbilityType) {
 	char := m.character
-	modifier := char.AbilityScores.GetModifier(ability)
-
-	// Check if proficient in this saving throw
-	isProficient := false
-	abilityFullName := ""
-	switch ability {
-	case models.Strength:
-		abilityFullName = "Strength"
-	case models.Dexterity:
-		abilityFullName = "Dexterity"
-	case models.Constitution:
-		abilityFullName = "Constitution"
-	case models.Intelligence:
-		abilityFullName = "Intelligence"
-	case models.Wisdom:
-		abilityFullName = "Wisdom"
-	case models.Charisma:
-		abilityFullName = "Charisma"
-	}
-
-	for _, prof := range char.SavingThrowProficiencies {
-		if strings.EqualFold(prof, abilityFullName) {
-			isProficient = true
-			break
-		}
-	}
 
-	// Add proficiency bonus if proficient
-	if isProficient {
-		modifier += char.ProficiencyBonus
-	}
+	// Ability modifier, proficiency and bonuses such as a Ring of Protection
+	modifier := char.SavingThrowBonus(ability)
+	isProficient := char.IsSaveProficient(ability)
 
 	// Roll 1d20 + modifier
 	expression := fmt.Sprintf("1d20%+d", modifier)
@@ -436,7 +509,7 @@ func (m *Model) rollSavingThrow(ability models.AbilityType) {
 	if isProficient {
 		profStr = " (proficient)"
 	}
-	m.message = fmt.Sprintf("Rolled %s saving throw%s: %s", abilityFullName, profStr, expression)
+	m.message = fmt.Sprintf("Rolled %s saving throw%s: %s", ability.FullName(), profStr, expression)
 }
 
 // rollAbilityCheck rolls an ability check for the given ability
@@ -583,7 +656,15 @@ func (m *Model) handleInventoryPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			def := models.GetItemDefinitionByName(item.Name)
 			if def != nil && models.IsEquippable(*def) {
 				// Only check proficiency when EQUIPPING (not unequipping)
+				note := ""
 				if !item.Equipped {
+					// Check the hands are free to hold a weapon or shield
+					var err error
+					if note, err = m.character.CanHold(item); err != nil {
+						m.message = fmt.Sprintf("⚠ Cannot equip %s: %v", item.Name, err)
+						return m, nil
+					}
+
 					// Check armor proficiency
 					if item.Type == models.Armor {
 						// Get armor subcategory (Light, Medium, Heavy, Shield)
@@ -612,13 +693,20 @@ func (m *Model) handleInventoryPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					}
 				}
 
+				// Equipped items are worn or held, not packed away
+				if !item.Equipped {
+					item.ContainerID = 0
+				}
 				m.inventoryPanel.ToggleEquipped()
 
-				// Recalculate AC after equipping/unequipping
-				m.character.UpdateDerivedStats()
+				// Recalculate AC and magic item effects after equipping/unequipping
+				models.SyncItemEffects(m.character)
 
 				if item.Equipped {
 					m.message = fmt.Sprintf("%s equipped (AC: %d)", item.Name, m.character.AC)
+					if note != "" {
+						m.message += " - " + note
+					}
 				} else {
 					m.message = fmt.Sprintf("%s unequipped (AC: %d)", item.Name, m.character.AC)
 				}
@@ -647,6 +735,7 @@ func (m *Model) handleInventoryPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.character.UpdateDerivedStats()
 				m.message += fmt.Sprintf(" (AC: %d)", m.character.AC)
 			}
+			models.SyncItemEffects(m.character)
 			m.storage.Save(m.character)
 		}
 	case "D":
@@ -664,8 +753,93 @@ func (m *Model) handleInventoryPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.character.UpdateDerivedStats()
 				m.message += fmt.Sprintf(" (AC: %d)", m.character.AC)
 			}
+			models.SyncItemEffects(m.character)
 			m.storage.Save(m.character)
 		}
+	case "t":
+		// Attune to or end attunement with the selected magic item
+		item := m.inventoryPanel.GetSelectedItem()
+		if item == nil {
+			return m, nil
+		}
+		var err error
+		if item.Attuned {
+			err = m.character.Unattune(item)
+			m.message = fmt.Sprintf("Ended attunement to %s", item.Name)
+		} else {
+			err = m.character.Attune(item)
+			m.message = fmt.Sprintf("Attuned to %s (%d/%d)", item.Name,
+				len(m.character.Inventory.AttunedItems()), models.MaxAttunedItems)
+			if def := models.GetItemDefinitionByName(item.Name); def != nil && models.IsEquippable(*def) && !item.Equipped {
+				m.message += " - equip it for its effects"
+			}
+		}
+		if err != nil {
+			m.message = "⚠ " + err.Error()
+			return m, nil
+		}
+		m.storage.Save(m.character)
+	case "u":
+		// Drink, eat or read a consumable, or spend a charge of a wand, staff or ring
+		if item := m.inventoryPanel.GetSelectedItem(); item != nil && models.ItemUseOf(item) != nil {
+			m.useConsumable(item)
+		} else {
+			m.useItemCharge()
+		}
+	case " ":
+		// Expand or collapse the selected container
+		if !m.inventoryPanel.ToggleCollapsed() {
+			m.message = "Not a container"
+		}
+	case "w":
+		// Swap to the next loadout
+		loadout, missing, err := m.character.NextLoadout()
+		if err != nil {
+			m.message = "⚠ " + err.Error()
+			if len(m.character.Inventory.Loadouts) == 0 {
+				m.message += " - press W to save what is equipped"
+			}
+			return m, nil
+		}
+		m.message = m.loadoutMessage(loadout.Name, missing)
+		m.storage.Save(m.character)
+	case "W":
+		// Manage loadouts
+		m.loadoutPopup.Show(m.character)
+		m.message = "Loadouts..."
+	case "m":
+		// Move the selected item into (or out of) a container
+		item := m.inventoryPanel.GetSelectedItem()
+		if item == nil {
+			return m, nil
+		}
+		if !m.containerPicker.Show(m.character, item) {
+			m.message = "No container to move it to"
+		}
+	case "x":
+		// End potion and spell effects whose duration has run out
+		if len(m.character.Effects) == 0 {
+			m.message = "No active effects"
+			return m, nil
+		}
+		m.character.EndEffects()
+		m.message = "Active effects ended"
+		m.storage.Save(m.character)
+	case "r":
+		// Dawn: charged items regain charges
+		recharged := m.rechargeItems()
+		if recharged == "" {
+			m.message = "Dawn: no items to recharge"
+			return m, nil
+		}
+		m.message = "Dawn: " + recharged
+		m.storage.Save(m.character)
+	case "$":
+		// Record income and expenses, split loot and see the ledger
+		m.pursePopup.Show(m.character)
+	case "b":
+		// Buy and sell at the DM's shop, or the item catalog if there is no shop file
+		m.openShop(true)
 	case "a":
 		// Open item selector to add items
 		m.itemSelector.Show(m.character)
@@ -674,10 +848,136 @@ func (m *Model) handleInventoryPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// useConsumable uses up one of a potion, scroll or food item. Healing and a scroll's
+// spell are rolled through the dice roller so the results show in the roll history.
+func (m *Model) useConsumable(item *models.Item) {
+	use := models.ItemUseOf(item)
+
+	healed := 0
+	if use.Heal != "" {
+		result, err := m.dicePanel.RollResult(use.Heal)
+		if err != nil {
+			m.message = fmt.Sprintf("Error rolling %s: %v", use.Heal, err)
+			return
+		}
+		healed = result.Total
+	}
+
+	cast := ""
+	if use.Spell != "" {
+		spell := models.GetSpellByName(use.Spell)
+		if spell == nil {
+			m.message = fmt.Sprintf("⚠ %s: unknown spell %s", item.Name, use.Spell)
+			return
+		}
+		level := use.SpellLevel
+		if level == 0 {
+			level = spell.Level
+		}
+		// The scroll's own save DC and attack bonus; healing uses the reader's modifier
+		saveDC, attackBonus := models.ScrollSpellStats(level)
+		spellMod, _, _ := m.character.SpellcastingFor(spell)
+		cast = fmt.Sprintf("cast %s", spell.Name)
+		summary := spell.MechanicsSummary(level, m.character.Level, &models.SpellBook{SpellSaveDC: saveDC, SpellAttackBonus: attackBonus})
+		if expr := spell.RollExpression(level, m.character.Level, spellMod); expr != "" {
+			m.dicePanel.Roll(expr)
+			summary = fmt.Sprintf("%s → %s", summary, m.dicePanel.LastMessage)
+		}
+		if summary != "" {
+			cast += ": " + summary
+		}
+		if formula := models.SpellACFormula(spell); formula != nil {
+			m.character.AddEffect(models.Effect{Name: spell.Name, Source: "Spell: " + spell.Name, ACFormula: formula})
+		}
+	}
+
+	result, err := m.character.UseItem(item, healed)
+	if err != nil {
+		m.message = "⚠ " + err.Error()
+		return
+	}
+	m.message = result
+	if use.Heal != "" {
+		m.message += " [" + m.dicePanel.LastMessage + "]"
+	}
+	if cast != "" {
+		m.message += ", " + cast
+	}
+	m.storage.Save(m.character)
+}
+
+// useItemCharge spends a charge of the selected item. Spending the last charge of an
+// item that can be destroyed (e.g. a wand) rolls a d20 for it in the dice roller.
+func (m *Model) useItemCharge() {
+	item := m.inventoryPanel.GetSelectedItem()
+	if item == nil {
+		return
+	}
+	empty, err := item.UseCharge(1)
+	if err != nil {
+		m.message = "⚠ " + err.Error()
+		return
+	}
+	m.message = fmt.Sprintf("Used a charge of %s (%d/%d left)", item.Name, item.Charges, item.MaxCharges)
+
+	if destroyOn := models.DestroyOn(item); empty && destroyOn > 0 {
+		result, err := m.dicePanel.RollResult("1d20")
+		if err != nil {
+			m.message = fmt.Sprintf("Error rolling for %s: %v", item.Name, err)
+			return
+		}
+		if result.Total <= destroyOn {
+			name := item.Name
+			m.character.Inventory.RemoveOne(item)
+			models.SyncItemEffects(m.character)
+			m.message = fmt.Sprintf("Spent the last charge of %s and rolled %d: it is destroyed!", name, result.Total)
+		} else {
+			m.message += fmt.Sprintf(" - last charge spent, rolled %d: it survives", result.Total)
+		}
+	}
+	m.storage.Save(m.character)
+}
+
+// rechargeItems rolls the charges each charged item regains at dawn through the dice
+// roller, so every roll shows in the roll history, and describes what was regained
+func (m *Model) rechargeItems() string {
+	var results []string
+	for _, item := range models.ItemsToRecharge(m.character) {
+		expression := models.RechargeExpression(item)
+		result, err := m.dicePanel.RollResult(expression)
+		if err != nil {
+			results = append(results, fmt.Sprintf("%s (%v)", item.Name, err))
+			continue
+		}
+		gained := item.Recharge(result.Total)
+		results = append(results, fmt.Sprintf("%s +%d [%s = %d] (%d/%d)",
+			item.Name, gained, expression, result.Total, item.Charges, item.MaxCharges))
+	}
+	return strings.Join(results, ", ")
+}
+
 
 // handleSpellsPanel handles spells panel specific keys
 func (m *Model) handleSpellsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
+	case "up", "k":
+		m.spellsPanel.Prev()
+	case "down", "j":
+		m.spellsPanel.Next()
+	case ">", ".":
+		m.spellsPanel.RaiseCastLevel()
+	case "<", ",":
+		m.spellsPanel.LowerCastLevel()
+	case "c":
+		m.castSelectedSpell()
+	case " ":
+		m.toggleSelectedSpellPrepared()
+	case "b":
+		if !models.UsesSpellbook(m.character) {
+			m.message = fmt.Sprintf("%s does not keep a spellbook", m.character.Class)
+			return m, nil
+		}
+		m.spellbookView.Show(m.character)
 	case "r":
 		m.character.SpellBook.LongRest()
 		m.message = "Spell slots restored!"
@@ -700,6 +1000,79 @@ func (m *Model) handleSpellsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// toggleSelectedSpellPrepared prepares or unprepares the selected spell
+func (m *Model) toggleSelectedSpellPrepared() {
+	spell := m.spellsPanel.GetSelectedSpell()
+	if spell == nil {
+		m.message = "No spell selected"
+		return
+	}
+	if spell.Level == 0 {
+		m.message = "Cantrips are always prepared"
+		return
+	}
+
+	if m.character.SpellBook.IsSpellPrepared(spell.Name) {
+		models.UnprepareSpell(m.character, spell.Name)
+		m.message = fmt.Sprintf("Unprepared %s", spell.Name)
+	} else if err := models.PrepareSpell(m.character, spell.Name); err != nil {
+		m.message = "⚠ " + err.Error()
+		return
+	} else {
+		m.message = fmt.Sprintf("Prepared %s", spell.Name)
+	}
+	m.storage.Save(m.character)
+}
+
+// castSelectedSpell spends a slot for the selected spell and rolls its damage or healing
+func (m *Model) castSelectedSpell() {
+	spell := m.spellsPanel.GetSelectedSpell()
+	if spell == nil {
+		m.message = "No spell selected"
+		return
+	}
+
+	components := models.CheckSpellComponents(m.character, spell)
+	if !components.OK {
+		m.message = "⚠ " + components.Warning
+		return
+	}
+
+	castLevel := m.spellsPanel.GetCastLevel()
+	if spell.Level > 0 {
+		if !m.character.SpellBook.UseSlot(castLevel) {
+			m.message = fmt.Sprintf("No level %d spell slots remaining", castLevel)
+			return
+		}
+	}
+
+	spellMod, saveDC, attackBonus := m.character.SpellcastingFor(spell)
+	stats := &models.SpellBook{SpellSaveDC: saveDC, SpellAttackBonus: attackBonus}
+	summary := spell.MechanicsSummary(castLevel, m.character.Level, stats)
+	if expr := spell.RollExpression(castLevel, m.character.Level, spellMod); expr != "" {
+		m.dicePanel.Roll(expr)
+		summary = fmt.Sprintf("%s → %s", summary, m.dicePanel.LastMessage)
+	}
+
+	if spell.Level > 0 {
+		m.message = fmt.Sprintf("Cast %s at level %d", spell.Name, castLevel)
+	} else {
+		m.message = fmt.Sprintf("Cast %s", spell.Name)
+	}
+	if summary != "" {
+		m.message += ": " + summary
+	}
+	if components.ConsumeItem != "" {
+		models.ConsumeSpellComponents(m.character, components)
+		m.message += fmt.Sprintf(" (consumed %dx %s)", components.ConsumeQty, components.ConsumeItem)
+	}
+	if formula := models.SpellACFormula(spell); formula != nil {
+		m.character.AddEffect(models.Effect{Name: spell.Name, Source: "Spell: " + spell.Name, ACFormula: formula})
+		m.message += fmt.Sprintf(" (AC %d while unarmored)", formula.Value(m.character))
+	}
+	m.storage.Save(m.character)
+}
+
 // handleFeaturesPanel handles features panel specific keys
 func (m *Model) handleFeaturesPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -716,20 +1089,43 @@ func (m *Model) handleFeaturesPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "ctrl+e":
 		m.featuresPanel.ScrollDown()
 	case "u":
-		// Use feature (decrement uses)
-		m.featuresPanel.UseFeature()
-		m.message = "Feature used"
+		// Use feature (decrement uses) or spend one resource point
+		if res := m.featuresPanel.GetSelectedResource(); res != nil {
+			if m.featuresPanel.UseFeature() {
+				m.message = fmt.Sprintf("Spent 1 %s (%d/%d left)", res.Name, res.Current, res.Max)
+			} else {
+				m.message = fmt.Sprintf("No %s left", res.Name)
+			}
+		} else {
+			m.featuresPanel.UseFeature()
+			m.message = "Feature used"
+		}
 		m.storage.Save(m.character)
 	case "+", "=":
-		// Restore one use
-		m.featuresPanel.RestoreFeature()
-		m.message = "Feature restored"
+		// Restore one use or one resource point
+		if res := m.featuresPanel.GetSelectedResource(); res != nil {
+			m.featuresPanel.RestoreFeature()
+			m.message = fmt.Sprintf("Restored 1 %s (%d/%d)", res.Name, res.Current, res.Max)
+		} else {
+			m.featuresPanel.RestoreFeature()
+			m.message = "Feature restored"
+		}
 		m.storage.Save(m.character)
 	case "d", "delete":
 		// Delete feature
-		m.featuresPanel.RemoveFeature()
-		m.message = "Feature removed"
-		m.storage.Save(m.character)
+		if m.featuresPanel.RemoveFeature() {
+			m.message = "Feature removed"
+			m.storage.Save(m.character)
+		} else {
+			m.message = "Class resources can't be removed"
+		}
+	case "m":
+		// Font of Magic: convert between sorcery points and spell slots
+		if m.character.GetResource(models.SorceryPoints) == nil {
+			m.message = "You have no sorcery points"
+			return m, nil
+		}
+		m.fontOfMagicPopup.Show(m.character)
 	case "a":
 		// Add feature (simplified - in real app would show a form)
 		m.message = "Add feature (not yet implemented)"
@@ -742,7 +1138,40 @@ func (m *Model) handleFeaturesPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Long rest
 		m.character.LongRest()
 		m.message = "Long rest completed - all features recovered"
+		if recharged := m.rechargeItems(); recharged != "" {
+			m.message += " • Recharged: " + recharged
+		}
+		m.storage.Save(m.character)
+	}
+	return m, nil
+}
+
+// handleFontOfMagicKeys handles Font of Magic popup keys
+func (m *Model) handleFontOfMagicKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.fontOfMagicPopup.Prev()
+	case "down", "j":
+		m.fontOfMagicPopup.Next()
+	case "enter":
+		option := m.fontOfMagicPopup.GetSelectedOption()
+		if option.CreateSlot {
+			if err := models.CreateSpellSlot(m.character, option.SlotLevel); err != nil {
+				m.message = "⚠ " + err.Error()
+				return m, nil
+			}
+			m.message = fmt.Sprintf("Created a level %d spell slot for %d sorcery points",
+				option.SlotLevel, models.FontOfMagicSlotCost[option.SlotLevel])
+		} else {
+			if err := models.ConvertSpellSlot(m.character, option.SlotLevel); err != nil {
+				m.message = "⚠ " + err.Error()
+				return m, nil
+			}
+			m.message = fmt.Sprintf("Converted a level %d spell slot into %d sorcery points", option.SlotLevel, option.SlotLevel)
+		}
 		m.storage.Save(m.character)
+	case "esc":
+		m.fontOfMagicPopup.Hide()
 	}
 	return m, nil
 }
@@ -836,81 +1265,791 @@ func (m *Model) handleTraitsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleCharStatsPanelKeys handles character stats panel specific keys
-func (m *Model) handleCharStatsPanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	editMode := m.characterStatsPanel.GetEditMode()
+// handleCharStatsPanelKeys handles character stats panel specific keys
+func (m *Model) handleCharStatsPanelKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	editMode := m.characterStatsPanel.GetEditMode()
+
+	// If in edit mode, handle save/cancel
+	if editMode != panels.CharStatsNormal {
+		switch msg.String() {
+		case "enter":
+			if editMode == panels.CharStatsEditName {
+				m.characterStatsPanel.SaveName()
+				m.message = "Name updated"
+			} else if editMode == panels.CharStatsEditRace {
+				m.characterStatsPanel.SaveRace()
+				m.message = "Race updated"
+			} else if editMode == panels.CharStatsEditHP {
+				amount, err := m.characterStatsPanel.SaveHP()
+				if err != nil {
+					m.message = fmt.Sprintf("Invalid HP value: %v", err)
+				} else {
+					m.message = fmt.Sprintf("HP adjusted by %+d. Current: %d/%d", amount, m.character.CurrentHP, m.character.MaxHP)
+				}
+			}
+			return m, nil
+		case "esc":
+			m.characterStatsPanel.CancelEdit()
+			m.message = "Edit cancelled"
+			return m, nil
+		default:
+			// Pass key to input field
+			return m, m.characterStatsPanel.HandleInput(msg)
+		}
+	}
+
+	// In normal mode, allow class change
+	switch msg.String() {
+	case "c":
+		m.classSelector.Show()
+		m.message = "Select a class..."
+		return m, nil
+	}
+
+	// A level down waits for 'D' to be pressed twice; any other key disarms it
+	levelDownArmed := m.pendingLevelDown
+	m.pendingLevelDown = false
+
+	// Normal mode - handle actions
+	switch msg.String() {
+	case "n":
+		m.characterStatsPanel.EditName()
+		m.message = "Editing name..."
+	case "r":
+		m.speciesSelector.Show()
+		m.message = "Select a species..."
+	case "h":
+		m.characterStatsPanel.EditHP()
+		m.message = "Enter HP change (+/- amount)..."
+	case "+", "=":
+		m.characterStatsPanel.AddHP(1)
+		m.message = fmt.Sprintf("HP: %d/%d", m.character.CurrentHP, m.character.MaxHP)
+	case "-", "_":
+		m.characterStatsPanel.RemoveHP(1)
+		m.message = fmt.Sprintf("HP: %d/%d", m.character.CurrentHP, m.character.MaxHP)
+	case "u":
+		if m.character.IsMulticlassed() {
+			m.classSelector.ShowForLevelUp(m.character)
+			m.message = "Choose the class that gains a level..."
+		} else {
+			m.startLevelUp("")
+		}
+	case "M":
+		if !m.character.CanLevelUp() {
+			m.startLevelUp("") // Explains the XP needed
+		} else {
+			m.classSelector.ShowForMulticlass(m.character)
+			m.message = "Choose a class to multiclass into..."
+		}
+	case "S":
+		m.startPendingSubclass()
+	case "X":
+		m.xpPopup.Show(m.character)
+		m.message = "Award XP..."
+		if m.character.MilestoneLeveling {
+			m.message = "Grant a milestone level..."
+		}
+	case "L":
+		m.character.SetMilestoneLeveling(!m.character.MilestoneLeveling)
+		if m.character.MilestoneLeveling {
+			m.message = "Milestone levelling: the DM grants levels (press 'X')"
+		} else {
+			m.message = "XP levelling"
+		}
+		m.storage.Save(m.character)
+	case "D":
+		if m.character.Level <= 1 {
+			m.message = "Level 1 can't be removed"
+		} else if !levelDownArmed {
+			m.pendingLevelDown = true
+			m.message = fmt.Sprintf("Press 'D' again to remove level %d and everything it granted", m.character.Level)
+		} else {
+			m.levelDown()
+		}
+	case "V":
+		var records []models.LevelRecord
+		for level := 2; level <= m.character.Level; level++ {
+			records = append(records, leveling.RecordForLevel(m.character, level))
+		}
+		m.levelHistory.Show(m.character, records)
+		m.message = "Level history..."
+	case "w":
+		m.statBreakdown.Show(m.character, models.TargetAC)
+		m.message = "Stat breakdown..."
+	case "a":
+		m.startPendingASI()
+	case "A":
+		m.undoLastASI()
+	case "i":
+		// Roll initiative
+		initMod := m.characterStatsPanel.GetInitiativeModifier()
+		expr := fmt.Sprintf("1d20%+d", initMod)
+		m.dicePanel.Roll(expr)
+		m.message = fmt.Sprintf("Initiative rolled: %s", m.dicePanel.LastMessage)
+	case "I":
+		// Toggle inspiration
+		m.characterStatsPanel.ToggleInspiration()
+		if m.character.Inspiration {
+			m.message = "✨ Inspiration gained!"
+		} else {
+			m.message = "Inspiration used"
+		}
+		m.storage.Save(m.character)
+	}
+	return m, nil
+}
+
+// startLevelUp opens the level-up wizard for the next level in a class
+// (the starting class if className is empty)
+func (m *Model) startLevelUp(className string) {
+	if !m.character.CanLevelUp() {
+		if m.character.Level >= 20 {
+			m.message = "Already at maximum level"
+		} else if m.character.MilestoneLeveling {
+			m.message = fmt.Sprintf("Level %d hasn't been granted yet (press 'X' for a milestone)", m.character.Level+1)
+		} else {
+			m.message = fmt.Sprintf("Need %d XP to reach level %d (have %d)",
+				m.character.GetNextLevelXP(), m.character.Level+1, m.character.Experience)
+		}
+		return
+	}
+
+	if className == "" {
+		className = m.character.Class
+	}
+	entry, err := leveling.GetNextLevel(m.character, className)
+	if err != nil {
+		m.message = fmt.Sprintf("Cannot level up: %v", err)
+		return
+	}
+	m.levelUpWizard.Show(m.character, className, entry)
+	m.message = fmt.Sprintf("Level up to %s %d...", className, entry.Level)
+}
+
+// handleLevelUpWizardKeys handles level-up wizard keys
+func (m *Model) handleLevelUpWizardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.levelUpWizard.Hide()
+		if m.levelUpWizard.IsBookOnly() {
+			m.message = "Spellbook skipped"
+			m.afterStartingSpellbook()
+			return m, nil
+		}
+		m.message = "Level up cancelled"
+		if m.respecReplay != nil {
+			if err := m.finishRespec(m.respecReplay); err != nil {
+				m.message = "⚠ Respec cancelled, but restoring the levels failed: " + err.Error()
+			} else {
+				m.message = fmt.Sprintf("Respec cancelled: back to level %d", m.character.Level)
+			}
+		}
+		return m, nil
+	case "enter":
+		if m.levelUpWizard.IsRollSelected() {
+			// Roll through the dice roller so the hit die shows in the roll history
+			result, err := m.dicePanel.RollResult(fmt.Sprintf("1d%d", m.levelUpWizard.HitDie()))
+			if err != nil {
+				m.message = fmt.Sprintf("Error rolling HP: %v", err)
+				return m, nil
+			}
+			m.levelUpWizard.SetRolledHP(result.Total)
+			m.message = fmt.Sprintf("Hit die: %s", m.dicePanel.LastMessage)
+			return m, nil
+		}
+
+		if m.levelUpWizard.CurrentStep() != components.LevelUpStepConfirm {
+			if problem := m.levelUpWizard.Confirm(); problem != "" {
+				m.message = problem
+			}
+			return m, nil
+		}
+
+		if m.levelUpWizard.IsASIOnly() {
+			m.applyPendingASI()
+			return m, nil
+		}
+		if m.levelUpWizard.IsBookOnly() {
+			m.applyStartingSpellbook()
+			return m, nil
+		}
+
+		if err := leveling.PerformLevelUp(m.character, m.levelUpWizard.GetOptions()); err != nil {
+			m.message = fmt.Sprintf("Error leveling up: %v", err)
+			m.levelUpWizard.Hide()
+			if m.respecReplay != nil {
+				m.finishRespec(m.respecReplay) // Put the previous choices back
+			}
+			return m, nil
+		}
+		m.levelUpWizard.Hide()
+		m.message = fmt.Sprintf("🎉 Reached level %d! (HP: %d/%d)", m.character.Level, m.character.CurrentHP, m.character.MaxHP)
+		if m.respecReplay != nil {
+			if err := m.finishRespec(m.respecReplay[1:]); err != nil {
+				m.message = fmt.Sprintf("⚠ Respec stopped at level %d: %v", m.character.Level, err)
+			} else {
+				m.message = fmt.Sprintf("Respec complete: back to level %d (HP: %d/%d)", m.character.Level, m.character.CurrentHP, m.character.MaxHP)
+			}
+		}
+		m.storage.Save(m.character)
+
+		if m.levelUpWizard.WantsFeat() {
+			m.featSelector.ShowForASI(m.character, m.character.Level)
+			m.message += " Choose your feat..."
+		}
+		return m, nil
+	}
+
+	if m.levelUpWizard.IsTyping() {
+		return m, m.levelUpWizard.HandleInput(msg)
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		m.levelUpWizard.Prev()
+	case "down", "j":
+		m.levelUpWizard.Next()
+	case "backspace":
+		m.levelUpWizard.ResetASI()
+	}
+	return m, nil
+}
+
+// applyPendingASI applies an Ability Score Improvement chosen outside a level up
+func (m *Model) applyPendingASI() {
+	level := m.levelUpWizard.ASILevel()
+	m.levelUpWizard.Hide()
+
+	if m.levelUpWizard.WantsFeat() {
+		m.featSelector.ShowForASI(m.character, level)
+		m.message = fmt.Sprintf("Choose a General feat for your level %d ASI...", level)
+		return
+	}
+
+	if err := leveling.ApplyAbilityScoreImprovement(m.character, level, m.levelUpWizard.GetOptions().AbilityIncrease); err != nil {
+		m.message = "⚠ " + err.Error()
+		return
+	}
+	m.message = fmt.Sprintf("Level %d Ability Score Improvement applied", level)
+	m.storage.Save(m.character)
+}
+
+// handleXPPopupKeys handles XP award and milestone popup keys
+func (m *Model) handleXPPopupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.xpPopup.Hide()
+		m.message = "XP popup closed"
+		return m, nil
+	case "tab":
+		m.xpPopup.NextField()
+		return m, nil
+	case "enter":
+		couldLevelUp := m.character.CanLevelUp()
+		if m.character.MilestoneLeveling {
+			if err := m.character.GrantMilestone(m.xpPopup.Reason()); err != nil {
+				m.message = "⚠ " + err.Error()
+				return m, nil
+			}
+			m.message = fmt.Sprintf("Milestone: level %d granted", m.character.Level+1)
+		} else {
+			amount, err := m.xpPopup.Amount()
+			if err == nil {
+				err = m.character.AwardXP(amount, m.xpPopup.Reason())
+			}
+			if err != nil {
+				m.message = "⚠ " + err.Error()
+				return m, nil
+			}
+			m.message = fmt.Sprintf("%+d XP: %s (total %d)", amount, m.xpPopup.Reason(), m.character.Experience)
+		}
+		m.xpPopup.Hide()
+		m.storage.Save(m.character)
+
+		if !couldLevelUp && m.character.CanLevelUp() {
+			m.message = fmt.Sprintf("🎉 %s — level %d available! Press 'u' to level up", m.message, m.character.Level+1)
+		}
+		return m, nil
+	}
+	return m, m.xpPopup.HandleInput(msg)
+}
+
+// startPendingSubclass opens the subclass selector for the first class that reached its
+// subclass choice level without a subclass
+func (m *Model) startPendingSubclass() {
+	pending := models.PendingSubclasses(m.character)
+	if len(pending) == 0 {
+		m.message = "No subclass to choose"
+		return
+	}
+	m.subclassSelector.Show(pending[0])
+	m.message = fmt.Sprintf("Choose your %s subclass...", pending[0])
+}
+
+// handleSubclassSelectorKeys handles subclass selector keys
+func (m *Model) handleSubclassSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.subclassSelector.Prev()
+	case "down", "j":
+		m.subclassSelector.Next()
+	case "enter":
+		name := m.subclassSelector.GetSelectedSubclass()
+		if name == "" {
+			return m, nil
+		}
+		className := m.subclassSelector.ClassName()
+		m.subclassSelector.Hide()
+		if err := models.ChooseSubclass(m.character, className, name); err != nil {
+			m.message = "⚠ " + err.Error()
+			return m, nil
+		}
+		m.message = fmt.Sprintf("%s subclass: %s", className, name)
+		m.storage.Save(m.character)
+	case "esc":
+		m.subclassSelector.Hide()
+		m.message = "Subclass choice postponed (press 'S' on Character Info to choose)"
+	}
+	return m, nil
+}
+
+// levelDown removes the character's highest level and everything it granted
+func (m *Model) levelDown() {
+	level := m.character.Level
+	if err := leveling.LevelDown(m.character); err != nil {
+		m.message = "⚠ " + err.Error()
+		return
+	}
+	m.message = fmt.Sprintf("Removed level %d: now level %d (HP: %d/%d)", level, m.character.Level, m.character.CurrentHP, m.character.MaxHP)
+	m.storage.Save(m.character)
+}
+
+// handleLevelHistoryKeys handles level history keys
+func (m *Model) handleLevelHistoryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.levelHistory.Prev()
+	case "down", "j":
+		m.levelHistory.Next()
+	case "enter":
+		level := m.levelHistory.GetSelectedLevel()
+		if level == 0 {
+			return m, nil
+		}
+		m.levelHistory.Hide()
+		m.startRespec(level)
+	case "esc":
+		m.levelHistory.Hide()
+		m.message = "Level history closed"
+	}
+	return m, nil
+}
+
+// handleStatBreakdownKeys handles keys while the stat breakdown is shown
+func (m *Model) handleStatBreakdownKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.statBreakdown.Prev()
+	case "down", "j":
+		m.statBreakdown.Next()
+	case "esc", "w":
+		m.statBreakdown.Hide()
+	}
+	return m, nil
+}
+
+// handleContainerPickerKeys handles keys while choosing a container to move an item to
+func (m *Model) handleContainerPickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.containerPicker.Prev()
+	case "down", "j":
+		m.containerPicker.Next()
+	case "esc":
+		m.containerPicker.Hide()
+	case "enter":
+		item, container := m.containerPicker.Item(), m.containerPicker.Selected()
+		m.message = fmt.Sprintf("Took %s out", item.Name)
+		if container != nil {
+			m.message = fmt.Sprintf("Moved %s into %s", item.Name, container.Name)
+		}
+		m.containerPicker.Hide()
+		if err := m.character.Inventory.MoveToContainer(item, container); err != nil {
+			m.message = "⚠ " + err.Error()
+			return m, nil
+		}
+		// A moved item is unequipped
+		models.SyncItemEffects(m.character)
+		m.storage.Save(m.character)
+	}
+	return m, nil
+}
+
+// startClassEquipment offers the starting equipment of a newly chosen class, then the
+// subclass choice for classes that choose at level 1
+func (m *Model) startClassEquipment(className string) {
+	m.subclassAfterEquipment = true
+	if class := models.GetClassByName(className); class != nil {
+		m.startStartingEquipment(className, models.ClassStartingEquipment(class))
+		return
+	}
+	m.afterStartingEquipment()
+}
 
-	// If in edit mode, handle save/cancel
-	if editMode != panels.CharStatsNormal {
-		switch msg.String() {
-		case "enter":
-			if editMode == panels.CharStatsEditName {
-				m.characterStatsPanel.SaveName()
-				m.message = "Name updated"
-			} else if editMode == panels.CharStatsEditRace {
-				m.characterStatsPanel.SaveRace()
-				m.message = "Race updated"
-			} else if editMode == panels.CharStatsEditHP {
-				amount, err := m.characterStatsPanel.SaveHP()
-				if err != nil {
-					m.message = fmt.Sprintf("Invalid HP value: %v", err)
-				} else {
-					m.message = fmt.Sprintf("HP adjusted by %+d. Current: %d/%d", amount, m.character.CurrentHP, m.character.MaxHP)
+// startStartingEquipment opens the starting equipment choices, or adds the equipment
+// straight away when there is nothing to choose
+func (m *Model) startStartingEquipment(title string, equipment models.StartingEquipment) {
+	if m.equipmentSelector.Show(title+" Starting Equipment", equipment) {
+		m.message = fmt.Sprintf("Choose your %s starting equipment...", title)
+		return
+	}
+	m.applyStartingEquipment()
+}
+
+// applyStartingEquipment adds the chosen equipment, or the gold taken instead
+func (m *Model) applyStartingEquipment() {
+	equipment := m.equipmentSelector.Equipment()
+	items, gold := m.equipmentSelector.Result()
+	if len(items) > 0 || gold > 0 {
+		equipment.Apply(m.character, items, gold)
+		models.SyncItemEffects(m.character)
+		m.storage.Save(m.character)
+		m.message = fmt.Sprintf("%s starting equipment added", equipment.Source.Name)
+		if gold > 0 {
+			m.message = fmt.Sprintf("Took %d GP instead of the %s starting equipment", gold, equipment.Source.Name)
+		}
+	}
+	m.afterStartingEquipment()
+}
+
+// afterStartingEquipment continues with the spellbook and the subclass choice if a class
+// was just chosen
+func (m *Model) afterStartingEquipment() {
+	if !m.subclassAfterEquipment {
+		return
+	}
+	m.subclassAfterEquipment = false
+	if className := models.SpellbookClass(m.character); className != "" && len(m.character.SpellBook.Book) == 0 {
+		m.levelUpWizard.ShowSpellbook(m.character, className)
+		m.message = fmt.Sprintf("Choose the %d spells your spellbook starts with...", models.StartingBookSpells)
+		return
+	}
+	m.afterStartingSpellbook()
+}
+
+// afterStartingSpellbook opens the subclass choice for classes that choose at level 1
+// (Cleric, Sorcerer and Warlock)
+func (m *Model) afterStartingSpellbook() {
+	if len(models.PendingSubclasses(m.character)) > 0 {
+		m.startPendingSubclass()
+	}
+}
+
+// applyStartingSpellbook adds the spells chosen for a new spellbook
+func (m *Model) applyStartingSpellbook() {
+	m.levelUpWizard.Hide()
+	options := m.levelUpWizard.GetOptions()
+	if err := models.LearnBookSpells(m.character, options.Class, 1, options.BookSpells); err != nil {
+		m.message = "⚠ " + err.Error()
+		return
+	}
+	m.message = fmt.Sprintf("%d spells written into your spellbook", len(options.BookSpells))
+	m.storage.Save(m.character)
+	m.afterStartingSpellbook()
+}
+
+// handleEquipmentSelectorKeys handles keys while choosing starting equipment
+func (m *Model) handleEquipmentSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.equipmentSelector.Prev()
+	case "down", "j":
+		m.equipmentSelector.Next()
+	case "backspace":
+		m.equipmentSelector.Back()
+	case "enter":
+		if m.equipmentSelector.Choose() {
+			m.equipmentSelector.Hide()
+			m.applyStartingEquipment()
+		}
+	case "esc":
+		m.equipmentSelector.Hide()
+		m.message = "Starting equipment skipped"
+		m.afterStartingEquipment()
+	}
+	return m, nil
+}
+
+// handlePursePopupKeys handles keys while recording income, expenses and split loot
+func (m *Model) handlePursePopupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.pursePopup.Hide()
+		return m, nil
+	case "up":
+		m.pursePopup.PrevAction()
+		return m, nil
+	case "down":
+		m.pursePopup.NextAction()
+		return m, nil
+	case "tab":
+		m.pursePopup.NextField()
+		return m, nil
+	case "enter":
+		inv := &m.character.Inventory
+		reason := m.pursePopup.Reason()
+		amount, err := m.pursePopup.Amount()
+		if err == nil {
+			switch m.pursePopup.Action() {
+			case components.PurseIncome:
+				err = inv.Receive(amount, reason)
+				m.message = fmt.Sprintf("Received %s: %s", amount, reason)
+			case components.PurseExpense:
+				err = inv.Pay(amount.Value(), reason)
+				m.message = fmt.Sprintf("Paid %s: %s", models.FormatCopper(amount.Value()), reason)
+			case components.PurseSplit:
+				var members, share int
+				members, err = m.pursePopup.Members()
+				if err == nil {
+					share, err = inv.SplitLoot(amount, members, reason)
 				}
+				m.message = fmt.Sprintf("Split %s among %d: %s each", amount, members, models.FormatCopper(share))
 			}
+		}
+		if err != nil {
+			m.message = "⚠ " + err.Error()
 			return m, nil
-		case "esc":
-			m.characterStatsPanel.CancelEdit()
-			m.message = "Edit cancelled"
-			return m, nil
-		default:
-			// Pass key to input field
-			return m, m.characterStatsPanel.HandleInput(msg)
 		}
+		m.message += fmt.Sprintf(" (purse: %s)", inv.Coins)
+		m.pursePopup.Hide()
+		m.storage.Save(m.character)
+		return m, nil
 	}
+	return m, m.pursePopup.HandleInput(msg)
+}
 
-	// In normal mode, allow class change
+// handleLoadoutPopupKeys handles keys while swapping, saving and deleting loadouts
+func (m *Model) handleLoadoutPopupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "c":
-		m.classSelector.Show()
-		m.message = "Select a class..."
+	case "esc":
+		m.loadoutPopup.Hide()
+		return m, nil
+	case "up":
+		m.loadoutPopup.Prev()
+		return m, nil
+	case "down":
+		m.loadoutPopup.Next()
+		return m, nil
+	case "ctrl+d":
+		name := m.loadoutPopup.Selected()
+		if name == "" {
+			return m, nil
+		}
+		if err := m.character.DeleteLoadout(name); err != nil {
+			m.message = "⚠ " + err.Error()
+			return m, nil
+		}
+		m.loadoutPopup.ClampSelection()
+		m.message = fmt.Sprintf("Loadout %s deleted", name)
+		m.storage.Save(m.character)
+		return m, nil
+	case "enter":
+		if name := m.loadoutPopup.Name(); name != "" {
+			if err := m.character.SaveLoadout(name); err != nil {
+				m.message = "⚠ " + err.Error()
+				return m, nil
+			}
+			m.message = fmt.Sprintf("Loadout %s saved", name)
+		} else {
+			name = m.loadoutPopup.Selected()
+			if name == "" {
+				m.message = "Name the loadout to save what is equipped"
+				return m, nil
+			}
+			missing, err := m.character.ApplyLoadout(name)
+			if err != nil {
+				m.message = "⚠ " + err.Error()
+				return m, nil
+			}
+			m.message = m.loadoutMessage(name, missing)
+		}
+		m.loadoutPopup.Hide()
+		m.storage.Save(m.character)
 		return m, nil
 	}
+	return m, m.loadoutPopup.HandleInput(msg)
+}
 
-	// Normal mode - handle actions
+// loadoutMessage reports a swap to a loadout and the items it couldn't find
+func (m *Model) loadoutMessage(name string, missing []string) string {
+	message := fmt.Sprintf("Swapped to %s (AC: %d)", name, m.character.AC)
+	if note, _ := models.CheckHands(m.character, m.character.Inventory.HeldItems()); note != "" {
+		message += " - " + note
+	}
+	if len(missing) > 0 {
+		message += " ⚠ missing: " + strings.Join(missing, ", ")
+	}
+	return message
+}
+
+// openShop opens the DM's shop file, or the item catalog if local is false or there is
+// no shop file
+func (m *Model) openShop(local bool) {
+	if local {
+		shop, err := models.LoadShop(m.shopFile)
+		if err == nil {
+			m.shopPopup.Show(m.character, shop, true)
+			m.message = fmt.Sprintf("Welcome to %s", shop.Name)
+			return
+		}
+		if !os.IsNotExist(err) {
+			m.shopPopup.Show(m.character, models.CatalogShop(m.character), false)
+			m.message = "⚠ " + err.Error()
+			return
+		}
+	}
+	m.shopPopup.Show(m.character, models.CatalogShop(m.character), false)
+	m.message = "Shopping from the item catalog"
+}
+
+// handleShopKeys handles keys while buying and selling items
+func (m *Model) handleShopKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	shop := m.shopPopup.Shop()
 	switch msg.String() {
-	case "n":
-		m.characterStatsPanel.EditName()
-		m.message = "Editing name..."
-	case "r":
-		m.speciesSelector.Show()
-		m.message = "Select a species..."
-	case "h":
-		m.characterStatsPanel.EditHP()
-		m.message = "Enter HP change (+/- amount)..."
-	case "+", "=":
-		m.characterStatsPanel.AddHP(1)
-		m.message = fmt.Sprintf("HP: %d/%d", m.character.CurrentHP, m.character.MaxHP)
-	case "-", "_":
-		m.characterStatsPanel.RemoveHP(1)
-		m.message = fmt.Sprintf("HP: %d/%d", m.character.CurrentHP, m.character.MaxHP)
-	case "i":
-		// Roll initiative
-		initMod := m.characterStatsPanel.GetInitiativeModifier()
-		expr := fmt.Sprintf("1d20%+d", initMod)
-		m.dicePanel.Roll(expr)
-		m.message = fmt.Sprintf("Initiative rolled: %s", m.dicePanel.LastMessage)
-	case "I":
-		// Toggle inspiration
-		m.characterStatsPanel.ToggleInspiration()
-		if m.character.Inspiration {
-			m.message = "✨ Inspiration gained!"
+	case "esc":
+		m.shopPopup.Hide()
+		return m, nil
+	case "up":
+		m.shopPopup.Prev()
+		return m, nil
+	case "down":
+		m.shopPopup.Next()
+		return m, nil
+	case "tab":
+		m.shopPopup.ToggleMode()
+		return m, nil
+	case "ctrl+l":
+		m.openShop(!m.shopPopup.IsLocal())
+		return m, nil
+	case "+", "-":
+		if !m.shopPopup.IsSelling() {
+			break
+		}
+		step := 0.05
+		if msg.String() == "-" {
+			step = -step
+		}
+		shop.SetRate(min(max(math.Round((shop.Rate()+step)*100)/100, 0), 1))
+		m.message = fmt.Sprintf("%s pays %.0f%% of the price", shop.Name, shop.Rate()*100)
+		if !m.shopPopup.IsLocal() {
+			// The catalog's rate is a setting of the character
+			m.character.CatalogSellRate = shop.SellRate
+			m.storage.Save(m.character)
+			return m, nil
+		}
+		m.saveShop()
+		return m, nil
+	case "enter":
+		if m.shopPopup.IsSelling() {
+			item := m.shopPopup.SelectedInventoryItem()
+			if item == nil {
+				return m, nil
+			}
+			name := item.Name
+			price, err := shop.Sell(m.character, item)
+			if err != nil {
+				m.message = "⚠ " + err.Error()
+				return m, nil
+			}
+			m.shopPopup.ClampSelection()
+			models.SyncItemEffects(m.character)
+			m.message = fmt.Sprintf("Sold %s for %s", name, models.FormatCopper(price))
 		} else {
-			m.message = "Inspiration used"
+			index := m.shopPopup.SelectedShopItem()
+			if index < 0 {
+				return m, nil
+			}
+			item := shop.Items[index]
+			if err := shop.Buy(m.character, index, 1); err != nil {
+				m.message = "⚠ " + err.Error()
+				return m, nil
+			}
+			m.message = fmt.Sprintf("Bought %s for %s", item.Name, models.FormatCopper(item.Price()))
 		}
+		m.message += fmt.Sprintf(" (purse: %s)", m.character.Inventory.Coins)
+		m.saveShop()
 		m.storage.Save(m.character)
+		return m, nil
 	}
-	return m, nil
+	return m, m.shopPopup.HandleInput(msg)
+}
+
+// saveShop writes the stock and sell rate back to the shop file
+func (m *Model) saveShop() {
+	if !m.shopPopup.IsLocal() {
+		return
+	}
+	if err := m.shopPopup.Shop().Save(m.shopFile); err != nil {
+		m.message = "⚠ Error saving the shop: " + err.Error()
+	}
+}
+
+// startRespec rolls the character back to before a level and opens the level up for
+// it again; the levels after it are replayed once the new choices are confirmed
+func (m *Model) startRespec(level int) {
+	replay, err := leveling.Respec(m.character, level)
+	if err != nil {
+		m.message = "⚠ " + err.Error()
+		return
+	}
+	m.respecReplay = replay
+
+	className := replay[0].Class
+	if className == "" {
+		className = m.character.Class
+	}
+	entry, err := leveling.GetNextLevel(m.character, className)
+	if err != nil {
+		m.finishRespec(replay)
+		m.message = fmt.Sprintf("Cannot respec: %v", err)
+		return
+	}
+	m.levelUpWizard.Show(m.character, className, entry)
+	m.message = fmt.Sprintf("Respec: choose again for level %d (%s %d)...", level, className, entry.Level)
+}
+
+// finishRespec replays the levels kept from before a respec and saves the character
+func (m *Model) finishRespec(replay []leveling.LevelUpOptions) error {
+	m.respecReplay = nil
+	err := leveling.ReplayLevelUps(m.character, replay)
+	m.storage.Save(m.character)
+	return err
+}
+
+// startPendingASI opens the wizard for the earliest unspent Ability Score Improvement
+func (m *Model) startPendingASI() {
+	pending := leveling.PendingAbilityScoreImprovements(m.character)
+	if len(pending) == 0 {
+		m.message = "No Ability Score Improvement to choose"
+		return
+	}
+	m.levelUpWizard.ShowASI(m.character, pending[0])
+	m.message = fmt.Sprintf("Choose your level %d Ability Score Improvement...", pending[0])
+}
+
+// undoLastASI removes the ability increases or feat of the most recent Ability Score Improvement
+func (m *Model) undoLastASI() {
+	level := leveling.LastAbilityScoreImprovement(m.character)
+	if level == 0 {
+		m.message = "No Ability Score Improvement to undo"
+		return
+	}
+	if err := leveling.UndoAbilityScoreImprovement(m.character, level); err != nil {
+		m.message = "⚠ " + err.Error()
+		return
+	}
+	m.message = fmt.Sprintf("Level %d Ability Score Improvement undone (press a to choose again)", level)
+	m.storage.Save(m.character)
 }
 
 // handleStatGeneratorKeys handles stat generator specific keys
@@ -1342,6 +2481,25 @@ func (m *Model) handleClassSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.classSelector.Next()
 	case "enter":
 		selectedClassName := m.classSelector.GetSelectedClass()
+		switch m.classSelector.Purpose() {
+		case components.ClassSelectLevelUp:
+			m.classSelector.Hide()
+			if selectedClassName != "" {
+				m.startLevelUp(selectedClassName)
+			}
+			return m, nil
+		case components.ClassSelectMulticlass:
+			if selectedClassName == "" {
+				return m, nil
+			}
+			if err := models.CheckMulticlassPrerequisites(m.character, selectedClassName); err != nil {
+				m.message = "⚠ " + err.Error()
+				return m, nil
+			}
+			m.classSelector.Hide()
+			m.startLevelUp(selectedClassName)
+			return m, nil
+		}
 		if selectedClassName != "" {
 			// Get the full class data to check skill choices
 			classData := models.GetClassByName(selectedClassName)
@@ -1367,6 +2525,9 @@ func (m *Model) handleClassSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 				m.storage.Save(m.character)
 				m.classSelector.Hide()
+				if err == nil {
+					m.startClassEquipment(selectedClassName)
+				}
 			}
 		}
 	case "esc":
@@ -1412,6 +2573,7 @@ func (m *Model) handleClassSkillSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd
 			m.storage.Save(m.character)
 			m.classSkillSelector.Hide()
 			m.message = fmt.Sprintf("Class changed to: %s with %d skill proficiencies (HP: %d/%d)", selectedClassName, len(selectedSkills), m.character.CurrentHP, m.character.MaxHP)
+			m.startClassEquipment(selectedClassName)
 		} else {
 			m.message = fmt.Sprintf("Please select %d more skill(s)", m.classSkillSelector.MaxChoices-len(m.classSkillSelector.SelectedSkills))
 		}
@@ -1502,6 +2664,73 @@ func (m *Model) handleSkillSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleSpellbookViewKeys handles spellbook popup keys
+func (m *Model) handleSpellbookViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.spellbookView.Prev()
+		return m, nil
+	case "down", "j":
+		m.spellbookView.Next()
+		return m, nil
+	case "tab":
+		m.spellbookView.ToggleMode()
+		return m, nil
+	case "esc":
+		m.spellbookView.Hide()
+		return m, nil
+	case "enter":
+		spell := m.spellbookView.GetSelectedSpell()
+		if spell == nil {
+			return m, nil
+		}
+		if m.spellbookView.GetMode() == components.SpellbookModeCopy {
+			if err := models.CopySpellToBook(m.character, *spell); err != nil {
+				m.message = "⚠ " + err.Error()
+				return m, nil
+			}
+			m.message = fmt.Sprintf("Copied %s into your spellbook (%d gp, %dh downtime)",
+				spell.Name, spell.Level*models.ScribeCostPerLevel, spell.Level*models.ScribeHoursPerLevel)
+		} else if m.character.SpellBook.IsSpellPrepared(spell.Name) {
+			models.UnprepareSpell(m.character, spell.Name)
+			m.message = fmt.Sprintf("Unprepared %s", spell.Name)
+		} else {
+			if err := models.PrepareSpell(m.character, spell.Name); err != nil {
+				m.message = "⚠ " + err.Error()
+				return m, nil
+			}
+			m.message = fmt.Sprintf("Prepared %s", spell.Name)
+		}
+	case "b":
+		spell := m.spellbookView.GetSelectedSpell()
+		if spell == nil || m.spellbookView.GetMode() != components.SpellbookModeBook {
+			return m, nil
+		}
+		if err := models.CopySpellToBackup(m.character, spell.Name); err != nil {
+			m.message = "⚠ " + err.Error()
+			return m, nil
+		}
+		m.message = fmt.Sprintf("Copied %s into your backup spellbook (%d gp, %dh downtime)",
+			spell.Name, spell.Level*models.BackupCostPerLevel, spell.Level*models.BackupHoursPerLevel)
+	case "L":
+		models.LoseSpellbook(m.character)
+		m.message = "Spellbook lost! Recover it from a backup book with R"
+	case "R":
+		recovered, err := models.RecoverSpellbook(m.character)
+		if err != nil {
+			m.message = "⚠ " + err.Error()
+			return m, nil
+		}
+		m.message = fmt.Sprintf("Spellbook recovered from backup: %d spells", recovered)
+	default:
+		return m, nil
+	}
+
+	m.spellbookView.Refresh()
+	m.storage.Save(m.character)
+	return m, nil
+}
+
 // handleSpellSelectorKeys handles spell selector specific keys
 func (m *Model) handleSpellSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -1587,7 +2816,7 @@ func (m *Model) handleFeatSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			} else {
 				// Check if the feat can be selected (prerequisites met)
 				if !m.featSelector.CanSelectCurrentFeat() {
-					m.message = fmt.Sprintf("Cannot select %s: Prerequisites not met!", selectedFeat.Name)
+					m.message = fmt.Sprintf("Cannot select %s: missing %s", selectedFeat.Name, strings.Join(m.featSelector.UnmetPrerequisites(), ", "))
 					return m, nil
 				}
 
@@ -1606,6 +2835,7 @@ func (m *Model) handleFeatSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 						if models.HasAbilityChoice(*selectedFeat) {
 							// Store the feat and show ability choice selector
 							m.pendingFeat = selectedFeat
+							m.pendingFeatASILevel = m.featSelector.ASILevel()
 							m.featSelector.Hide()
 							choices := models.GetAbilityChoices(*selectedFeat)
 							m.abilityChoiceSelector.Show(selectedFeat.Name, choices, m.character)
@@ -1613,6 +2843,9 @@ func (m *Model) handleFeatSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 						} else {
 							// Apply feat benefits automatically (no ability choice)
 							models.ApplyFeatBenefits(m.character, *selectedFeat, "")
+							if level := m.featSelector.ASILevel(); level > 0 {
+								leveling.RecordFeatForASI(m.character, level, selectedFeat.Name, "")
+							}
 							m.message = fmt.Sprintf("Feat gained: %s!", selectedFeat.Name)
 							// Save character after feat selection
 							m.storage.Save(m.character)
@@ -1625,6 +2858,9 @@ func (m *Model) handleFeatSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "esc":
 		m.featSelector.Hide()
 		m.message = "Feat selection cancelled"
+		if level := m.featSelector.ASILevel(); level > 0 {
+			m.message = fmt.Sprintf("Feat selection cancelled (press a on Character Info to choose your level %d ASI)", level)
+		}
 	}
 	return m, nil
 }
@@ -1682,6 +2918,7 @@ func (m *Model) handleOriginSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.storage.Save(m.character)
 				m.originSelector.Hide()
 				m.message = fmt.Sprintf("Origin changed to: %s", selectedOrigin.Name)
+				m.startStartingEquipment(selectedOrigin.Name, models.OriginStartingEquipment(selectedOrigin))
 			}
 		}
 	case "esc":
@@ -1708,6 +2945,10 @@ func (m *Model) handleAbilityChoiceSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.
 		if m.pendingFeat != nil {
 			// Apply feat benefits with the chosen ability
 			models.ApplyFeatBenefits(m.character, *m.pendingFeat, chosenAbility)
+			if m.pendingFeatASILevel > 0 {
+				leveling.RecordFeatForASI(m.character, m.pendingFeatASILevel, m.pendingFeat.Name, chosenAbility)
+				m.pendingFeatASILevel = 0
+			}
 			m.message = fmt.Sprintf("Feat gained: %s (+1 %s)!", m.pendingFeat.Name, chosenAbility)
 			m.storage.Save(m.character)
 			m.pendingFeat = nil
@@ -1729,6 +2970,7 @@ func (m *Model) handleAbilityChoiceSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.
 			models.ApplyOriginBenefits(m.character, *m.pendingOrigin, chosenAbility)
 			m.message = fmt.Sprintf("Origin changed to: %s (+1 %s)!", m.pendingOrigin.Name, chosenAbility)
 			m.storage.Save(m.character)
+			m.startStartingEquipment(m.pendingOrigin.Name, models.OriginStartingEquipment(m.pendingOrigin))
 			m.pendingOrigin = nil
 			m.abilityChoiceSelector.Hide()
 		}
@@ -1745,6 +2987,7 @@ func (m *Model) handleAbilityChoiceSelectorKeys(msg tea.KeyMsg) (tea.Model, tea.
 			m.storage.Save(m.character)
 			m.message = "Feat selection cancelled"
 			m.pendingFeat = nil
+			m.pendingFeatASILevel = 0
 		}
 
 		if m.pendingOrigin != nil {
@@ -1794,6 +3037,26 @@ func (m *Model) getContextualHelp() (string, []components.HelpBinding) {
 	return "Stats", components.GetStatsBindings()
 }
 
+// dataErrorBadge summarizes the data file errors for the status bar, or returns "" if none
+func dataErrorBadge(problems []diagnostics.Problem) string {
+	count := diagnostics.Errors(problems)
+	if count == 0 {
+		return ""
+	}
+	var first diagnostics.Problem
+	for _, p := range problems {
+		if p.Severity == diagnostics.SeverityError {
+			first = p
+			break
+		}
+	}
+	badge := " ⚠ " + first.String()
+	if count > 1 {
+		badge += fmt.Sprintf(" (+%d more)", count-1)
+	}
+	return badge + " • run 'lazydndplayer validate' "
+}
+
 // buildStatusBar creates the status bar with contextual information
 func (m *Model) buildStatusBar() string {
 	panelNameStyle := lipgloss.NewStyle().
@@ -1816,19 +3079,19 @@ func (m *Model) buildStatusBar() string {
 		switch m.currentPanel {
 		case StatsPanel:
 			panelName = "Stats"
-			contextHelp = "[r] Roll Stats • [e] Edit Modifiers • [t] Test/Save"
+			contextHelp = "[r] Roll Stats • [e] Edit Modifiers • [t] Test/Save • [w] Why?"
 		case SkillsPanel:
 			panelName = "Skills"
 			contextHelp = "[↑/↓] Navigate • [r] Roll • [e] Toggle Prof"
 		case InventoryPanel:
 			panelName = "Inventory"
-			contextHelp = "[a] Add Item • [e] Equip • [d] Remove 1 • [D] Remove All"
+			contextHelp = "[a] Add Item • [b] Shop • [$] Purse • [e] Equip • [w] Swap Loadout • [W] Loadouts • [m] Move • [Space] Expand • [t] Attune • [u] Use • [r] Dawn • [x] End Effects • [d] Remove 1 • [D] Remove All"
 		case SpellsPanel:
 			panelName = "Spells"
-			contextHelp = "[a] Add • [r] Rest"
+			contextHelp = "[↑/↓] Navigate • [c] Cast • [</>] Slot Level • [Space] Prepare • [b] Spellbook • [r] Rest"
 		case FeaturesPanel:
 			panelName = "Features"
-			contextHelp = "[↑/↓] Navigate • [u] Use • [+] Restore"
+			contextHelp = "[↑/↓] Navigate • [u] Use/Spend • [+] Restore • [m] Font of Magic"
 		case TraitsPanel:
 			panelName = "Traits"
 			contextHelp = "[↑/↓] Navigate • [l] Add Lang • [L] Del Lang • [f] Add Feat • [F] Del Feat"
@@ -1838,7 +3101,7 @@ func (m *Model) buildStatusBar() string {
 		}
 	case FocusCharStats:
 		panelName = "Character Info"
-		contextHelp = "[n] Name • [r] Species • [h] HP • [+/-] ±1 • [i] Init"
+		contextHelp = "[n] Name • [r] Species • [h] HP • [+/-] ±1 • [i] Init • [u] Level Up • [M] Multiclass • [S] Subclass • [a/A] ASI/Undo • [D] Level Down • [V] History/Respec • [X] XP • [L] Milestone • [w] Why?"
 	case FocusActions:
 		panelName = "Actions"
 		contextHelp = "[↑/↓] Navigate • [Enter] Activate"
@@ -1861,6 +3124,20 @@ func (m *Model) buildStatusBar() string {
 		leftSection += helpStyle.Render(" "+contextHelp+" ")
 	}
 
+	// Level-up prompt, until the level is taken
+	if m.character.CanLevelUp() {
+		leftSection += keyStyle.Bold(true).Render(fmt.Sprintf(" ⬆ Level %d available [u] ", m.character.Level+1))
+	}
+
+	// Data file errors found at startup, until the files are fixed
+	if badge := dataErrorBadge(diagnostics.Problems()); badge != "" {
+		leftSection += lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214")).
+			Background(lipgloss.Color("235")).
+			Bold(true).
+			Render(badge)
+	}
+
 	// Build right section: global shortcuts
 	rightSection := keyStyle.Render("[Tab]") + helpStyle.Render(" Switch tabs • ") +
 		keyStyle.Render("[p/P]") + helpStyle.Render(" Focus • ") +
@@ -2081,6 +3358,26 @@ func (m *Model) View() string {
 		return m.abilityRoller.View(popupSmallWidth, popupSmallHeight, m.character)
 	}
 
+	// Level-up wizard (Large)
+	if m.levelUpWizard.IsVisible() {
+		return m.levelUpWizard.View(popupLargeWidth, popupLargeHeight)
+	}
+
+	// XP popup (Medium)
+	if m.xpPopup.IsVisible() {
+		return m.xpPopup.View(popupMediumWidth, popupMediumHeight)
+	}
+
+	// Font of Magic (Medium)
+	if m.fontOfMagicPopup.IsVisible() {
+		return m.fontOfMagicPopup.View(popupMediumWidth, popupMediumHeight)
+	}
+
+	// Spellbook (Medium)
+	if m.spellbookView.IsVisible() {
+		return m.spellbookView.View(popupMediumWidth, popupMediumHeight)
+	}
+
 	// Spell selector takes high priority (Large)
 	if m.spellSelector.IsVisible() {
 		return m.spellSelector.View(popupLargeWidth, popupLargeHeight)
@@ -2136,6 +3433,46 @@ func (m *Model) View() string {
 		return m.itemSelector.View(popupLargeWidth, popupLargeHeight)
 	}
 
+	// Subclass selector (Large)
+	if m.subclassSelector.IsVisible() {
+		return m.subclassSelector.View(popupLargeWidth, popupLargeHeight)
+	}
+
+	// Level history (Large)
+	if m.levelHistory.IsVisible() {
+		return m.levelHistory.View(popupLargeWidth, popupLargeHeight)
+	}
+
+	// Stat breakdown (Large)
+	if m.statBreakdown.IsVisible() {
+		return m.statBreakdown.View(popupLargeWidth, popupLargeHeight)
+	}
+
+	// Container picker (Small)
+	if m.containerPicker.IsVisible() {
+		return m.containerPicker.View(popupSmallWidth, popupSmallHeight)
+	}
+
+	// Purse (Medium)
+	if m.pursePopup.IsVisible() {
+		return m.pursePopup.View(popupMediumWidth, popupMediumHeight)
+	}
+
+	// Shop (Large)
+	if m.shopPopup.IsVisible() {
+		return m.shopPopup.View(popupLargeWidth, popupLargeHeight)
+	}
+
+	// Loadouts (Medium)
+	if m.loadoutPopup.IsVisible() {
+		return m.loadoutPopup.View(popupMediumWidth, popupMediumHeight)
+	}
+
+	// Starting equipment selector (Medium)
+	if m.equipmentSelector.IsVisible() {
+		return m.equipmentSelector.View(popupMediumWidth, popupMediumHeight)
+	}
+
 	// Class skill selector takes sixth priority (Medium)
 	if m.classSkillSelector.IsVisible() {
 		return m.classSkillSelector.View(m.width, m.height)
@@ -2161,9 +3498,11 @@ func (m *Model) View() string {
 }
 
 // Run runs the application
-func Run(char *models.Character, store *storage.Storage) error {
+func Run(char *models.Character, store *storage.Storage, shopFile string) error {
+	m := NewModel(char, store)
+	m.shopFile = shopFile
 	p := tea.NewProgram(
-		NewModel(char, store),
+		m,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
@@ -133,18 +133,8 @@ func (a *AbilityRoller) GetRollExpression(char *models.Character) string {
 	modifier := char.AbilityScores.GetModifier(ability)
 
 	if rollType == RollSavingThrow {
-		// Add proficiency bonus if proficient (check class proficiencies)
-		isProficient := false
-		for _, prof := range char.SavingThrowProficiencies {
-			if strings.EqualFold(prof, string(ability)) {
-				isProficient = true
-				break
-			}
-		}
-
-		if isProficient {
-			modifier += char.ProficiencyBonus
-		}
+		// Proficiency and bonuses such as a Ring of Protection
+		modifier = char.SavingThrowBonus(ability)
 	}
 
 	return fmt.Sprintf("1d20%+d", modifier)
@@ -235,7 +225,7 @@ func (a *AbilityRoller) View(width, height int, char *models.Character) string {
 	for i, ability := range abilities {
 		score := char.AbilityScores.GetScore(ability)
 		modifier := char.AbilityScores.GetModifier(ability)
-		isProficient := char.SavingThrows.IsProficient(ability)
+		isProficient := char.IsSaveProficient(ability)
 
 		profMarker := " "
 		if isProficient {
@@ -266,7 +256,7 @@ func (a *AbilityRoller) View(width, height int, char *models.Character) string {
 	// Calculate what the roll will be
 	ability := a.GetSelectedAbility()
 	baseModifier := char.AbilityScores.GetModifier(ability)
-	isProficient := char.SavingThrows.IsProficient(ability)
+	isProficient := char.IsSaveProficient(ability)
 
 	// Ability Check
 	checkLine := fmt.Sprintf("Ability Check     - 1d20%+d (modifier only)", baseModifier)
@@ -277,11 +267,15 @@ func (a *AbilityRoller) View(width, height int, char *models.Character) string {
 	}
 
 	// Saving Throw
-	saveModifier := baseModifier
+	saveModifier := char.SavingThrowBonus(ability)
 	profText := ""
+	other := saveModifier - baseModifier
 	if isProficient {
-		saveModifier += char.ProficiencyBonus
 		profText = fmt.Sprintf(" + %d prof", char.ProficiencyBonus)
+		other -= char.ProficiencyBonus
+	}
+	if other != 0 {
+		profText += fmt.Sprintf(" %+d bonuses", other)
 	}
 	saveLine := fmt.Sprintf("Saving Throw      - 1d20%+d (modifier%s)", saveModifier, profText)
 	if a.selectedType == 1 && !a.focusOnAbility {
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
 )
 
 // ClassInfo represents a D&D 5e class
@@ -16,9 +17,21 @@ type ClassInfo struct {
 	PrimaryAbility string
 }
 
+// ClassSelectorPurpose identifies what the selected class is used for
+type ClassSelectorPurpose int
+
+const (
+	ClassSelectChange     ClassSelectorPurpose = iota // Set the character's class
+	ClassSelectLevelUp                                // Pick which of the character's classes gains a level
+	ClassSelectMulticlass                             // Pick a new class to multiclass into
+)
+
 // ClassSelector is a component for selecting a class
 type ClassSelector struct {
 	classes       []ClassInfo
+	allClasses    []ClassInfo
+	notes         map[string]string // Class name -> note shown next to it (levels, unmet prerequisites)
+	purpose       ClassSelectorPurpose
 	selectedIndex int
 	visible       bool
 }
@@ -43,6 +56,7 @@ func NewClassSelector() *ClassSelector {
 
 	return &ClassSelector{
 		classes:       classes,
+		allClasses:    classes,
 		selectedIndex: 0,
 		visible:       false,
 	}
@@ -50,10 +64,51 @@ func NewClassSelector() *ClassSelector {
 
 // Show displays the class selector
 func (c *ClassSelector) Show() {
+	c.classes = c.allClasses
+	c.notes = nil
+	c.purpose = ClassSelectChange
 	c.visible = true
 	c.selectedIndex = 0
 }
 
+// ShowForLevelUp lists the character's classes to choose which one gains a level
+func (c *ClassSelector) ShowForLevelUp(char *models.Character) {
+	c.Show()
+	c.purpose = ClassSelectLevelUp
+	c.classes = nil
+	c.notes = map[string]string{}
+	for _, cl := range char.GetClasses() {
+		for _, info := range c.allClasses {
+			if info.Name == cl.Class {
+				c.classes = append(c.classes, info)
+				c.notes[info.Name] = fmt.Sprintf("level %d → %d", cl.Level, cl.Level+1)
+			}
+		}
+	}
+}
+
+// ShowForMulticlass lists the classes the character has no levels in, noting unmet prerequisites
+func (c *ClassSelector) ShowForMulticlass(char *models.Character) {
+	c.Show()
+	c.purpose = ClassSelectMulticlass
+	c.classes = nil
+	c.notes = map[string]string{}
+	for _, info := range c.allClasses {
+		if char.ClassLevel(info.Name) > 0 {
+			continue
+		}
+		c.classes = append(c.classes, info)
+		if err := models.CheckMulticlassPrerequisites(char, info.Name); err != nil {
+			c.notes[info.Name] = "✗ " + err.Error()
+		}
+	}
+}
+
+// Purpose returns what the selected class is used for
+func (c *ClassSelector) Purpose() ClassSelectorPurpose {
+	return c.purpose
+}
+
 // Hide closes the class selector
 func (c *ClassSelector) Hide() {
 	c.visible = false
@@ -122,12 +177,22 @@ func (c *ClassSelector) View(width, height int) string {
 
 	// Build content
 	var content []string
-	content = append(content, titleStyle.Render("SELECT CLASS"))
+	switch c.purpose {
+	case ClassSelectLevelUp:
+		content = append(content, titleStyle.Render("LEVEL UP WHICH CLASS?"))
+	case ClassSelectMulticlass:
+		content = append(content, titleStyle.Render("MULTICLASS INTO"))
+	default:
+		content = append(content, titleStyle.Render("SELECT CLASS"))
+	}
 	content = append(content, "")
 
 	// List all classes
 	for i, class := range c.classes {
 		className := fmt.Sprintf(" %s", class.Name)
+		if note := c.notes[class.Name]; note != "" {
+			className += "  " + note
+		}
 		if i == c.selectedIndex {
 			content = append(content, selectedStyle.Render(className))
 		} else {
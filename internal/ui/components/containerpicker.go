@@ -0,0 +1,149 @@
+// internal/ui/components/containerpicker.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// ContainerPicker chooses the container to move an inventory item into, or to take it
+// out of its container
+type ContainerPicker struct {
+	character     *models.Character
+	item          *models.Item
+	options       []*models.Item // nil takes the item out to be carried directly
+	selectedIndex int
+	visible       bool
+}
+
+// NewContainerPicker creates a new container picker
+func NewContainerPicker() *ContainerPicker {
+	return &ContainerPicker{}
+}
+
+// Show lists the containers an item can be moved to, reporting whether there are any
+func (cp *ContainerPicker) Show(char *models.Character, item *models.Item) bool {
+	cp.character = char
+	cp.item = item
+	cp.options = nil
+	cp.selectedIndex = 0
+
+	inv := &char.Inventory
+	current := inv.ContainerOf(item)
+	if current != nil {
+		cp.options = append(cp.options, nil)
+	}
+	for i := range inv.Items {
+		container := &inv.Items[i]
+		if !models.IsContainer(container) || container == item || container == current || inv.IsInside(container, item) {
+			continue
+		}
+		cp.options = append(cp.options, container)
+	}
+
+	cp.visible = len(cp.options) > 0
+	return cp.visible
+}
+
+// Hide hides the picker
+func (cp *ContainerPicker) Hide() {
+	cp.visible = false
+}
+
+// IsVisible returns whether the picker is visible
+func (cp *ContainerPicker) IsVisible() bool {
+	return cp.visible
+}
+
+// Next moves to the next container
+func (cp *ContainerPicker) Next() {
+	if cp.selectedIndex < len(cp.options)-1 {
+		cp.selectedIndex++
+	}
+}
+
+// Prev moves to the previous container
+func (cp *ContainerPicker) Prev() {
+	if cp.selectedIndex > 0 {
+		cp.selectedIndex--
+	}
+}
+
+// Item returns the item being moved
+func (cp *ContainerPicker) Item() *models.Item {
+	return cp.item
+}
+
+// Selected returns the chosen container, or nil to carry the item directly
+func (cp *ContainerPicker) Selected() *models.Item {
+	if cp.selectedIndex < len(cp.options) {
+		return cp.options[cp.selectedIndex]
+	}
+	return nil
+}
+
+// View renders the picker
+func (cp *ContainerPicker) View(width, height int) string {
+	if !cp.visible || cp.item == nil {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("237"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86"))
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	inv := &cp.character.Inventory
+	var lines []string
+	lines = append(lines, titleStyle.Render("MOVE ITEM"))
+	lines = append(lines, "")
+	lines = append(lines, infoStyle.Render(fmt.Sprintf("%s x%d (%.1f lbs)", cp.item.Name, cp.item.Quantity, inv.CarriedWeight(cp.item))))
+	lines = append(lines, "")
+
+	for i, container := range cp.options {
+		line := "Carried (no container)"
+		if container != nil {
+			line = container.Name
+			if parent := inv.ContainerOf(container); parent != nil {
+				line += " (in " + parent.Name + ")"
+			}
+			if spec := models.ContainerSpecOf(container); spec != nil {
+				line += fmt.Sprintf("  %.1f/%.0f lbs", inv.ContentsWeight(container), spec.Capacity)
+			}
+		}
+		if i == cp.selectedIndex {
+			lines = append(lines, selectedStyle.Render("▶ "+line))
+		} else {
+			lines = append(lines, normalStyle.Render("  "+line))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, helpStyle.Render("[↑/↓] Navigate • [Enter] Move • [Esc] Cancel"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(width - 8).
+		Background(lipgloss.Color("235"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,160 @@
+// internal/ui/components/equipmentselector.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// EquipmentSelector walks through the choices of a class's or background's starting
+// equipment: the gold alternative, "A or B" lines and weapon picks
+type EquipmentSelector struct {
+	title         string
+	equipment     models.StartingEquipment
+	answers       []int
+	step          *models.EquipmentStep
+	selectedIndex int
+	visible       bool
+}
+
+// NewEquipmentSelector creates a new equipment selector
+func NewEquipmentSelector() *EquipmentSelector {
+	return &EquipmentSelector{}
+}
+
+// Show starts resolving starting equipment, reporting whether there is a choice to
+// make; if not, Result is ready straight away
+func (es *EquipmentSelector) Show(title string, equipment models.StartingEquipment) bool {
+	es.title = title
+	es.equipment = equipment
+	es.answers = nil
+	es.resolve()
+	es.visible = es.step != nil
+	return es.visible
+}
+
+// Hide hides the selector
+func (es *EquipmentSelector) Hide() {
+	es.visible = false
+}
+
+// IsVisible returns whether the selector is visible
+func (es *EquipmentSelector) IsVisible() bool {
+	return es.visible
+}
+
+// Equipment returns the equipment being resolved
+func (es *EquipmentSelector) Equipment() models.StartingEquipment {
+	return es.equipment
+}
+
+// Next moves to the next option
+func (es *EquipmentSelector) Next() {
+	if es.step != nil && es.selectedIndex < len(es.step.Options)-1 {
+		es.selectedIndex++
+	}
+}
+
+// Prev moves to the previous option
+func (es *EquipmentSelector) Prev() {
+	if es.selectedIndex > 0 {
+		es.selectedIndex--
+	}
+}
+
+// Choose answers the current step, reporting whether every choice is now made
+func (es *EquipmentSelector) Choose() bool {
+	if es.step == nil {
+		return true
+	}
+	es.answers = append(es.answers, es.selectedIndex)
+	es.resolve()
+	return es.step == nil
+}
+
+// Back undoes the last answer, reporting whether there was one
+func (es *EquipmentSelector) Back() bool {
+	if len(es.answers) == 0 {
+		return false
+	}
+	last := es.answers[len(es.answers)-1]
+	es.answers = es.answers[:len(es.answers)-1]
+	es.resolve()
+	es.selectedIndex = last
+	return true
+}
+
+// Result returns the chosen items, or the gold taken instead
+func (es *EquipmentSelector) Result() ([]models.EquipmentItem, int) {
+	_, items, gold := es.equipment.Resolve(es.answers)
+	return items, gold
+}
+
+// resolve moves to the next unanswered step
+func (es *EquipmentSelector) resolve() {
+	es.step, _, _ = es.equipment.Resolve(es.answers)
+	es.selectedIndex = 0
+}
+
+// View renders the selector
+func (es *EquipmentSelector) View(width, height int) string {
+	if !es.visible || es.step == nil {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("237"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86"))
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(strings.ToUpper(es.title)))
+	lines = append(lines, helpStyle.Render(fmt.Sprintf("Choice %d", len(es.answers)+1)))
+	lines = append(lines, "")
+	for _, line := range wrapText(es.step.Prompt, width-12) {
+		lines = append(lines, infoStyle.Render(line))
+	}
+	lines = append(lines, "")
+
+	maxRows := max(height-12, 3)
+	start := 0
+	if es.selectedIndex > maxRows/2 && len(es.step.Options) > maxRows {
+		start = min(es.selectedIndex-maxRows/2, len(es.step.Options)-maxRows)
+	}
+	for i := start; i < min(start+maxRows, len(es.step.Options)); i++ {
+		option := es.step.Options[i]
+		if i == es.selectedIndex {
+			lines = append(lines, selectedStyle.Render("▶ "+option))
+		} else {
+			lines = append(lines, normalStyle.Render("  "+option))
+		}
+	}
+	lines = append(lines, "")
+	lines = append(lines, helpStyle.Render("[↑/↓] Navigate • [Enter] Choose • [Backspace] Back • [Esc] Skip equipment"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(width - 8).
+		Background(lipgloss.Color("235"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(strings.Join(lines, "\n")))
+}
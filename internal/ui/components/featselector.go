@@ -22,6 +22,7 @@ type FeatSelector struct {
 	deleteMode     bool   // If true, shows known feats for deletion
 	categoryFilter string // Current category filter ("All", "General", etc.)
 	categories     []string
+	asiLevel       int // Level of the Ability Score Improvement the feat replaces, 0 if none
 }
 
 // NewFeatSelector creates a new feat selector
@@ -35,7 +36,7 @@ func NewFeatSelector() *FeatSelector {
 		filterOrigin:   false,
 		deleteMode:     false,
 		categoryFilter: "All",
-		categories:     []string{"All", "General", "Combat", "Magic", "Skill"},
+		categories:     []string{"All", "Origin", "General", "Combat", "Magic", "Skill", models.FeatCategoryEpicBoon},
 	}
 }
 
@@ -45,6 +46,8 @@ func (f *FeatSelector) Show(char *models.Character, originFeat bool) {
 	f.filterOrigin = originFeat
 	f.deleteMode = false
 	f.categoryFilter = "All"
+	f.categories = []string{"All", "Origin", "General", "Combat", "Magic", "Skill", models.FeatCategoryEpicBoon}
+	f.asiLevel = 0
 
 	if originFeat {
 		f.title = "SELECT ORIGIN FEAT (HUMAN)"
@@ -73,11 +76,33 @@ func (f *FeatSelector) Show(char *models.Character, originFeat bool) {
 	f.visible = true
 }
 
+// ShowForASI displays General feats to take in place of the Ability Score Improvement at a level;
+// from level 19 Epic Boon feats are offered first
+func (f *FeatSelector) ShowForASI(char *models.Character, level int) {
+	f.Show(char, false)
+	f.title = fmt.Sprintf("SELECT GENERAL FEAT (LEVEL %d ASI)", level)
+	f.categories = []string{"General"}
+	f.categoryFilter = "General"
+	if level >= models.EpicBoonLevel {
+		f.title = fmt.Sprintf("SELECT EPIC BOON OR GENERAL FEAT (LEVEL %d)", level)
+		f.categories = []string{models.FeatCategoryEpicBoon, "General"}
+		f.categoryFilter = models.FeatCategoryEpicBoon
+	}
+	f.asiLevel = level
+	f.applyFilter()
+}
+
+// ASILevel returns the level of the Ability Score Improvement the feat replaces, or 0
+func (f *FeatSelector) ASILevel() int {
+	return f.asiLevel
+}
+
 // ShowForDeletion displays the feat selector with known feats (for deleting)
 func (f *FeatSelector) ShowForDeletion(char *models.Character) {
 	f.character = char
 	f.filterOrigin = false
 	f.deleteMode = true
+	f.asiLevel = 0
 	f.title = "SELECT FEAT TO REMOVE"
 
 	// Get all feats and filter to only show ones the character has
@@ -169,7 +194,25 @@ func (f *FeatSelector) CanSelectCurrentFeat() bool {
 	}
 
 	feat := f.feats[f.selectedIndex]
-	return models.CanTakeFeat(f.character, feat)
+	return models.CanTakeFeatAtLevel(f.character, feat, f.prerequisiteLevel())
+}
+
+// UnmetPrerequisites describes the prerequisites of the selected feat the character doesn't meet
+func (f *FeatSelector) UnmetPrerequisites() []string {
+	feat := f.GetSelectedFeat()
+	if feat == nil || f.deleteMode {
+		return nil
+	}
+	return models.UnmetFeatPrerequisites(f.character, *feat, f.prerequisiteLevel())
+}
+
+// prerequisiteLevel is the character level prerequisites are checked at: the level of
+// the Ability Score Improvement being replaced, else the current level
+func (f *FeatSelector) prerequisiteLevel() int {
+	if f.asiLevel > 0 {
+		return f.asiLevel
+	}
+	return f.character.Level
 }
 
 // IsVisible returns whether the selector is visible
@@ -336,7 +379,7 @@ func (f *FeatSelector) View(width, height int) string {
 		feat := f.feats[i]
 
 		// Check if character can take this feat
-		canTake := f.deleteMode || models.CanTakeFeat(f.character, feat)
+		canTake := f.deleteMode || models.CanTakeFeatAtLevel(f.character, feat, f.prerequisiteLevel())
 
 		featLine := fmt.Sprintf(" %s", feat.Name)
 
@@ -391,12 +434,15 @@ func (f *FeatSelector) View(width, height int) string {
 
 		// Prerequisite
 		if selectedFeat.Prerequisite != "None" && selectedFeat.Prerequisite != "" {
-			canTake := f.deleteMode || models.CanTakeFeat(f.character, *selectedFeat)
+			unmet := f.UnmetPrerequisites()
 			prereqText := "Prerequisite: " + selectedFeat.Prerequisite
-			if canTake {
+			if len(unmet) == 0 {
 				featDetails = append(featDetails, prerequisiteStyle.Render(prereqText+" ✓"))
 			} else {
 				featDetails = append(featDetails, prerequisiteUnmetStyle.Render(prereqText+" ✗ NOT MET"))
+				for _, requirement := range unmet {
+					featDetails = append(featDetails, prerequisiteUnmetStyle.Render("  • Missing: "+requirement))
+				}
 			}
 			featDetails = append(featDetails, "")
 		}
@@ -0,0 +1,138 @@
+// internal/ui/components/fontofmagic.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// FontOfMagicOption is one conversion offered by Font of Magic
+type FontOfMagicOption struct {
+	CreateSlot bool // true: sorcery points → slot, false: slot → sorcery points
+	SlotLevel  int
+}
+
+// FontOfMagicPopup converts between sorcery points and spell slots
+type FontOfMagicPopup struct {
+	character     *models.Character
+	options       []FontOfMagicOption
+	selectedIndex int
+	visible       bool
+}
+
+// NewFontOfMagicPopup creates a new Font of Magic popup
+func NewFontOfMagicPopup() *FontOfMagicPopup {
+	return &FontOfMagicPopup{}
+}
+
+// Show displays the conversions available to a character
+func (fp *FontOfMagicPopup) Show(char *models.Character) {
+	fp.character = char
+	fp.options = []FontOfMagicOption{}
+	for level := 1; level <= 5; level++ {
+		fp.options = append(fp.options, FontOfMagicOption{CreateSlot: true, SlotLevel: level})
+	}
+	for level := 1; level <= 9; level++ {
+		if slot := char.SpellBook.GetSlotByLevel(level); slot != nil && slot.Maximum > 0 {
+			fp.options = append(fp.options, FontOfMagicOption{SlotLevel: level})
+		}
+	}
+	fp.selectedIndex = 0
+	fp.visible = true
+}
+
+// Hide hides the popup
+func (fp *FontOfMagicPopup) Hide() {
+	fp.visible = false
+}
+
+// IsVisible returns whether the popup is visible
+func (fp *FontOfMagicPopup) IsVisible() bool {
+	return fp.visible
+}
+
+// Next moves to the next option
+func (fp *FontOfMagicPopup) Next() {
+	if fp.selectedIndex < len(fp.options)-1 {
+		fp.selectedIndex++
+	}
+}
+
+// Prev moves to the previous option
+func (fp *FontOfMagicPopup) Prev() {
+	if fp.selectedIndex > 0 {
+		fp.selectedIndex--
+	}
+}
+
+// GetSelectedOption returns the selected conversion
+func (fp *FontOfMagicPopup) GetSelectedOption() FontOfMagicOption {
+	if fp.selectedIndex < 0 || fp.selectedIndex >= len(fp.options) {
+		return FontOfMagicOption{}
+	}
+	return fp.options[fp.selectedIndex]
+}
+
+// View renders the popup
+func (fp *FontOfMagicPopup) View(width, height int) string {
+	if !fp.visible || fp.character == nil {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("237"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86"))
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("FONT OF MAGIC"))
+	if points := fp.character.GetResource(models.SorceryPoints); points != nil {
+		lines = append(lines, infoStyle.Render(points.String()))
+	}
+	lines = append(lines, "")
+
+	for i, option := range fp.options {
+		slot := fp.character.SpellBook.GetSlotByLevel(option.SlotLevel)
+		var line string
+		if option.CreateSlot {
+			line = fmt.Sprintf("Create level %d slot (%d SP)", option.SlotLevel, models.FontOfMagicSlotCost[option.SlotLevel])
+		} else {
+			line = fmt.Sprintf("Convert level %d slot → %d SP (%d/%d slots)", option.SlotLevel, option.SlotLevel, slot.Current, slot.Maximum)
+		}
+
+		if i == fp.selectedIndex {
+			lines = append(lines, selectedStyle.Render("▶ "+line))
+		} else {
+			lines = append(lines, normalStyle.Render("  "+line))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, helpStyle.Render("[↑/↓] Navigate • [Enter] Convert • [Esc] Close"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(width - 8).
+		Background(lipgloss.Color("235"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(strings.Join(lines, "\n")))
+}
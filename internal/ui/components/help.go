@@ -60,6 +60,7 @@ func GetOverviewBindings() []HelpBinding {
 func GetStatsBindings() []HelpBinding {
 	return []HelpBinding{
 		{"e", "Edit ability scores (not yet implemented)"},
+		{"w", "Why? Show what makes up the selected ability score"},
 		{"Shift+R", "Long rest"},
 	}
 }
@@ -79,7 +80,17 @@ func GetInventoryBindings() []HelpBinding {
 	return []HelpBinding{
 		{"↑/↓ or j/k", "Navigate items"},
 		{"a", "Add new item"},
+		{"b", "Shop: buy from the DM's shop file or the item catalog, sell at half price"},
+		{"$", "Purse: income, expenses, split loot and ledger"},
 		{"e", "Toggle equipped status"},
+		{"w", "Swap to the next loadout"},
+		{"W", "Loadouts: save what is equipped, swap or delete"},
+		{"m", "Move item into or out of a container"},
+		{"Space", "Expand/collapse container"},
+		{"t", "Attune / end attunement (max 3 items)"},
+		{"u", "Use a potion, scroll or ration, or a charge of a wand, staff or ring"},
+		{"r", "Dawn: roll recharges for charged items"},
+		{"x", "End active potion and spell effects"},
 		{"d", "Delete selected item"},
 		{"Shift+R", "Long rest"},
 	}
@@ -88,6 +99,11 @@ func GetInventoryBindings() []HelpBinding {
 // GetSpellsBindings returns spells panel bindings
 func GetSpellsBindings() []HelpBinding {
 	return []HelpBinding{
+		{"↑/↓ or j/k", "Navigate spells"},
+		{"c", "Cast selected spell (uses slot, rolls damage/healing)"},
+		{"</>", "Change slot level for upcasting"},
+		{"Space", "Prepare/unprepare selected spell"},
+		{"b", "Open spellbook (Wizard: prepare, copy, backup)"},
 		{"a", "Add new spell"},
 		{"r", "Restore spell slots (short rest)"},
 		{"Shift+R", "Long rest (restore all slots)"},
@@ -102,6 +118,16 @@ func GetCharacterStatsBindings() []HelpBinding {
 		{"h", "Adjust HP (popup)"},
 		{"+/-", "Quick HP adjust (±1)"},
 		{"i", "Roll initiative (1d20 + DEX)"},
+		{"u", "Level up (when you have enough XP)"},
+		{"M", "Multiclass: take a level in a new class"},
+		{"S", "Choose a pending subclass"},
+		{"D", "Level down: remove the highest level (press twice)"},
+		{"V", "Level history: respec from a chosen level"},
+		{"X", "Award XP with a reason (grant a level in milestone mode)"},
+		{"L", "Toggle XP / milestone levelling"},
+		{"w", "Why? Break down AC, initiative, speed, HP and other derived numbers"},
+		{"a", "Choose an unspent Ability Score Improvement"},
+		{"A", "Undo the latest Ability Score Improvement"},
 		{"Shift+I", "Toggle Inspiration"},
 	}
 }
@@ -134,9 +160,10 @@ func GetFeaturesBindings() []HelpBinding {
 		{"↑/↓ or j/k", "Navigate features"},
 		{"Ctrl+D/U", "Page down/up"},
 		{"Ctrl+E/Y", "Scroll down/up"},
-		{"u", "Use feature (consume charge)"},
-		{"+/=", "Restore one use"},
+		{"u", "Use feature or spend one resource point"},
+		{"+/=", "Restore one use or resource point"},
 		{"d", "Delete feature"},
+		{"m", "Font of Magic (sorcery points ↔ spell slots)"},
 		{"a", "Add feature"},
 		{"r", "Short rest (recover short rest features)"},
 		{"Shift+R", "Long rest (recover all features)"},
@@ -116,6 +116,16 @@ func (p *ItemDetailPopup) View(width, height int) string {
 	content.WriteString(valueStyle.Render(fmt.Sprintf("%.1f lbs (%.1f total)", p.item.Weight, p.item.TotalWeight())))
 	content.WriteString("\n")
 
+	if p.item.HasCharges() {
+		charges := fmt.Sprintf("%d/%d", p.item.Charges, p.item.MaxCharges)
+		if recharge := models.RechargeExpression(p.item); recharge != "" {
+			charges += fmt.Sprintf(" (regains %s at dawn)", recharge)
+		}
+		content.WriteString(labelStyle.Render("Charges: "))
+		content.WriteString(valueStyle.Render(charges))
+		content.WriteString("\n")
+	}
+
 	if p.def != nil {
 		content.WriteString(labelStyle.Render("Value: "))
 		content.WriteString(valueStyle.Render(fmt.Sprintf("%.2f gp each", p.def.PriceGP)))
@@ -149,6 +159,38 @@ func (p *ItemDetailPopup) View(width, height int) string {
 			}
 		}
 
+		if p.def.Use != nil {
+			content.WriteString("\n")
+			content.WriteString(labelStyle.Render("Use: "))
+			content.WriteString(valueStyle.Render(p.def.Use.String() + " (press 'u' in the inventory)"))
+			content.WriteString("\n")
+		}
+
+		if p.def.RequiresAttunement {
+			content.WriteString("\n")
+			content.WriteString(labelStyle.Render("Attunement: "))
+			if p.item.Attuned {
+				content.WriteString(equippedStyle.Render("Attuned"))
+			} else {
+				content.WriteString(valueStyle.Render("Required (press 't' in the inventory)"))
+			}
+			content.WriteString("\n")
+		}
+
+		if p.def.Effects != nil {
+			if effects := p.def.Effects.String(); effects != "" {
+				if !p.def.RequiresAttunement {
+					content.WriteString("\n")
+				}
+				content.WriteString(labelStyle.Render("Effects: "))
+				content.WriteString(valueStyle.Render(effects))
+				if !models.ItemEffectsActive(p.item) {
+					content.WriteString(helpStyle.Render(" (inactive)"))
+				}
+				content.WriteString("\n")
+			}
+		}
+
 		if len(p.def.Properties) > 0 {
 			content.WriteString("\n")
 			content.WriteString(labelStyle.Render("Properties: "))
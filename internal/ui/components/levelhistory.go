@@ -0,0 +1,124 @@
+// internal/ui/components/levelhistory.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// LevelHistory lists the choices made at each level gained and picks the level a
+// respec starts from
+type LevelHistory struct {
+	character     *models.Character
+	records       []models.LevelRecord // Levels 2 and up
+	selectedIndex int
+	visible       bool
+}
+
+// NewLevelHistory creates a new level history popup
+func NewLevelHistory() *LevelHistory {
+	return &LevelHistory{}
+}
+
+// Show displays the records of the levels gained after level 1, selecting the latest
+func (lh *LevelHistory) Show(char *models.Character, records []models.LevelRecord) {
+	lh.character = char
+	lh.records = records
+	lh.selectedIndex = max(len(records)-1, 0)
+	lh.visible = true
+}
+
+// Hide hides the popup
+func (lh *LevelHistory) Hide() {
+	lh.visible = false
+}
+
+// IsVisible returns whether the popup is visible
+func (lh *LevelHistory) IsVisible() bool {
+	return lh.visible
+}
+
+// Next moves to the next level
+func (lh *LevelHistory) Next() {
+	if lh.selectedIndex < len(lh.records)-1 {
+		lh.selectedIndex++
+	}
+}
+
+// Prev moves to the previous level
+func (lh *LevelHistory) Prev() {
+	if lh.selectedIndex > 0 {
+		lh.selectedIndex--
+	}
+}
+
+// GetSelectedLevel returns the selected character level, or 0 if there is none
+func (lh *LevelHistory) GetSelectedLevel() int {
+	if lh.selectedIndex >= 0 && lh.selectedIndex < len(lh.records) {
+		return lh.records[lh.selectedIndex].Level
+	}
+	return 0
+}
+
+// View renders the level history
+func (lh *LevelHistory) View(width, height int) string {
+	if !lh.visible || lh.character == nil {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("237"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86"))
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("LEVEL HISTORY"))
+	lines = append(lines, "")
+	lines = append(lines, infoStyle.Render(fmt.Sprintf("Level 1 — %s", lh.character.Class)))
+
+	if len(lh.records) == 0 {
+		lines = append(lines, helpStyle.Render("  No levels gained yet"))
+	}
+	for i, record := range lh.records {
+		line := fmt.Sprintf("Level %d — %s", record.Level, record.Summary())
+		if i == lh.selectedIndex {
+			lines = append(lines, selectedStyle.Render("▶ "+line))
+		} else {
+			lines = append(lines, normalStyle.Render("  "+line))
+		}
+	}
+	lines = append(lines, "")
+
+	if level := lh.GetSelectedLevel(); level > 0 {
+		lines = append(lines, helpStyle.Render(fmt.Sprintf(
+			"Respec rolls back to level %d, opens the level up for level %d and replays the levels after it", level-1, level)))
+		lines = append(lines, "")
+	}
+	lines = append(lines, helpStyle.Render("[↑/↓] Navigate • [Enter] Respec from this level • [Esc] Close"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(width - 8).
+		Background(lipgloss.Color("235"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,650 @@
+// internal/ui/components/levelupwizard.go
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/leveling"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// LevelUpStep identifies a page of the level-up wizard
+type LevelUpStep int
+
+const (
+	LevelUpStepHP        LevelUpStep = iota // Average or rolled hit points
+	LevelUpStepASI                          // Ability Score Improvement or feat
+	LevelUpStepSubclass                     // Subclass at the subclass_choice level
+	LevelUpStepChoice                       // Feature option (Fighting Style, Pact Boon)
+	LevelUpStepSkill                        // Skill proficiency granted when multiclassing
+	LevelUpStepBookSpell                    // Spell added to the spellbook (Wizard)
+	LevelUpStepConfirm                      // Summary before applying
+)
+
+// levelUpPage is one step of the wizard; feature is set for choice steps
+type levelUpPage struct {
+	step    LevelUpStep
+	feature *models.ClassFeature
+}
+
+// LevelUpWizard walks the player through the choices of their next class level
+type LevelUpWizard struct {
+	character     *models.Character
+	className     string // Class gaining the level
+	entry         *models.ClassLevel
+	pages         []levelUpPage
+	pageIndex     int
+	selectedIndex int
+	visible       bool
+
+	hitDie   int
+	conMod   int
+	options  leveling.LevelUpOptions
+	takeFeat bool
+	asiLevel int  // Level whose Ability Score Improvement is being chosen
+	asiOnly  bool // Only the ASI of an earlier level, not a level up
+	bookOnly bool // Only the starting spellbook of a new character, not a level up
+
+	subclassSelector *SubclassSelector // Catalog subclasses of the class
+	subclassInput    textinput.Model   // Free-form name when the catalog has none
+}
+
+// NewLevelUpWizard creates a new level-up wizard
+func NewLevelUpWizard() *LevelUpWizard {
+	subclassInput := textinput.New()
+	subclassInput.Placeholder = "e.g. School of Evocation"
+	subclassInput.CharLimit = 40
+	subclassInput.Width = 40
+
+	return &LevelUpWizard{
+		subclassSelector: NewSubclassSelector(),
+		subclassInput:    subclassInput,
+	}
+}
+
+// Show starts the wizard for the character's next level in a class. A class the
+// character has no levels in is a multiclass.
+func (w *LevelUpWizard) Show(char *models.Character, className string, entry *models.ClassLevel) {
+	w.character = char
+	w.className = className
+	w.entry = entry
+	w.hitDie = leveling.GetHitDie(className)
+	w.conMod = char.AbilityScores.GetModifier(models.Constitution)
+	w.options = leveling.LevelUpOptions{
+		Class:           className,
+		AbilityIncrease: map[models.AbilityType]int{},
+		FeatureChoices:  map[string]string{},
+	}
+	w.takeFeat = false
+	w.asiLevel = char.Level + 1
+	w.asiOnly = false
+	w.bookOnly = false
+
+	w.pages = []levelUpPage{{step: LevelUpStepHP}}
+	if char.ClassLevel(className) == 0 {
+		w.addMulticlassSkillPages(char, className)
+	}
+	if leveling.CanIncreaseAbilityScores(className, entry.Level) {
+		w.pages = append(w.pages, levelUpPage{step: LevelUpStepASI})
+	}
+	for i := range entry.Features {
+		feature := &entry.Features[i]
+		if feature.SubclassChoice {
+			w.pages = append(w.pages, levelUpPage{step: LevelUpStepSubclass, feature: feature})
+		} else if len(feature.Choices) > 0 {
+			w.pages = append(w.pages, levelUpPage{step: LevelUpStepChoice, feature: feature})
+		}
+	}
+	w.addBookSpellPages(char, className, entry.Level)
+	w.pages = append(w.pages, levelUpPage{step: LevelUpStepConfirm})
+
+	w.pageIndex = 0
+	w.selectedIndex = 0
+	w.subclassSelector.Load(className)
+	w.subclassInput.SetValue("")
+	w.subclassInput.Blur()
+	w.visible = true
+}
+
+// addMulticlassSkillPages adds one skill page per skill a new class grants when multiclassing
+func (w *LevelUpWizard) addMulticlassSkillPages(char *models.Character, className string) {
+	class := models.GetClassByName(className)
+	if class == nil || class.Multiclass == nil || class.Multiclass.SkillChoices == nil {
+		return
+	}
+	var available []string
+	for _, skill := range class.Multiclass.SkillChoices.From {
+		if s := char.Skills.GetSkill(models.SkillType(skill)); s == nil || s.Proficiency == 0 {
+			available = append(available, skill)
+		}
+	}
+	feature := &models.ClassFeature{
+		Name:        "Multiclass Skill Proficiency",
+		Description: fmt.Sprintf("Multiclassing into %s grants proficiency in a skill.", className),
+		Choices:     available,
+	}
+	for i := 0; i < class.Multiclass.SkillChoices.Choose; i++ {
+		w.pages = append(w.pages, levelUpPage{step: LevelUpStepSkill, feature: feature})
+	}
+}
+
+// addBookSpellPages adds one page per spell a spellbook class adds to the book at a class level
+func (w *LevelUpWizard) addBookSpellPages(char *models.Character, className string, classLevel int) {
+	class := models.GetClassByName(className)
+	if class == nil || class.Spellcasting == nil || !class.Spellcasting.Spellbook || char.SpellBook.BookLost {
+		return
+	}
+	var available []string
+	for _, spell := range models.BookSpellOptions(char, className, classLevel) {
+		available = append(available, spell.Name)
+	}
+	feature := &models.ClassFeature{
+		Name:        "Spellbook",
+		Description: fmt.Sprintf("Add a %s spell you have spell slots for to your spellbook, free of cost.", className),
+		Choices:     available,
+	}
+	for i := 0; i < min(models.BookSpellsAt(classLevel), len(available)); i++ {
+		w.pages = append(w.pages, levelUpPage{step: LevelUpStepBookSpell, feature: feature})
+	}
+}
+
+// ShowSpellbook shows only the spells a new character's spellbook starts with
+func (w *LevelUpWizard) ShowSpellbook(char *models.Character, className string) {
+	w.character = char
+	w.className = className
+	w.entry = &models.ClassLevel{Level: 1}
+	w.options = leveling.LevelUpOptions{
+		Class:           className,
+		AbilityIncrease: map[models.AbilityType]int{},
+		FeatureChoices:  map[string]string{},
+	}
+	w.takeFeat = false
+	w.asiOnly = false
+	w.bookOnly = true
+	w.pages = nil
+	w.addBookSpellPages(char, className, 1)
+	w.pages = append(w.pages, levelUpPage{step: LevelUpStepConfirm})
+	w.pageIndex = 0
+	w.selectedIndex = 0
+	w.subclassInput.Blur()
+	w.visible = true
+}
+
+// IsBookOnly returns true if the wizard only chooses the spells of a new spellbook
+func (w *LevelUpWizard) IsBookOnly() bool {
+	return w.bookOnly
+}
+
+// ShowASI shows only the Ability Score Improvement of a level already reached
+// (after an undo, or one skipped by an older save)
+func (w *LevelUpWizard) ShowASI(char *models.Character, level int) {
+	w.character = char
+	w.className, _ = char.ClassAtLevel(level)
+	w.entry = &models.ClassLevel{Level: level, AbilityScoreImprovement: true}
+	w.options = leveling.LevelUpOptions{
+		AbilityIncrease: map[models.AbilityType]int{},
+		FeatureChoices:  map[string]string{},
+	}
+	w.takeFeat = false
+	w.asiLevel = level
+	w.asiOnly = true
+	w.bookOnly = false
+	w.pages = []levelUpPage{{step: LevelUpStepASI}, {step: LevelUpStepConfirm}}
+	w.pageIndex = 0
+	w.selectedIndex = 0
+	w.subclassInput.Blur()
+	w.visible = true
+}
+
+// IsASIOnly returns true if the wizard only chooses an Ability Score Improvement
+func (w *LevelUpWizard) IsASIOnly() bool {
+	return w.asiOnly
+}
+
+// ASILevel returns the level whose Ability Score Improvement is being chosen
+func (w *LevelUpWizard) ASILevel() int {
+	return w.asiLevel
+}
+
+// Hide hides the wizard
+func (w *LevelUpWizard) Hide() {
+	w.visible = false
+	w.subclassInput.Blur()
+}
+
+// IsVisible returns whether the wizard is visible
+func (w *LevelUpWizard) IsVisible() bool {
+	return w.visible
+}
+
+// CurrentStep returns the step being shown
+func (w *LevelUpWizard) CurrentStep() LevelUpStep {
+	return w.pages[w.pageIndex].step
+}
+
+// IsTyping returns true while a subclass name missing from the catalog is being entered
+func (w *LevelUpWizard) IsTyping() bool {
+	return w.CurrentStep() == LevelUpStepSubclass && !w.subclassSelector.HasOptions()
+}
+
+// optionCount returns the number of selectable options on the current step
+func (w *LevelUpWizard) optionCount() int {
+	page := w.pages[w.pageIndex]
+	switch page.step {
+	case LevelUpStepHP:
+		return 2
+	case LevelUpStepASI:
+		return len(abilityOrder) + 1
+	case LevelUpStepChoice, LevelUpStepSkill, LevelUpStepBookSpell:
+		return len(page.feature.Choices)
+	default:
+		return 0
+	}
+}
+
+// Next moves to the next option
+func (w *LevelUpWizard) Next() {
+	if w.CurrentStep() == LevelUpStepSubclass {
+		w.subclassSelector.Next()
+		return
+	}
+	if w.selectedIndex < w.optionCount()-1 {
+		w.selectedIndex++
+	}
+}
+
+// Prev moves to the previous option
+func (w *LevelUpWizard) Prev() {
+	if w.CurrentStep() == LevelUpStepSubclass {
+		w.subclassSelector.Prev()
+		return
+	}
+	if w.selectedIndex > 0 {
+		w.selectedIndex--
+	}
+}
+
+// HitDie returns the class hit die size
+func (w *LevelUpWizard) HitDie() int {
+	return w.hitDie
+}
+
+// AverageHP returns the fixed hit point gain for the level
+func (w *LevelUpWizard) AverageHP() int {
+	return max(models.FixedHitPoints(w.hitDie)+w.conMod, 1)
+}
+
+// hpGain returns the hit points gained with the chosen method
+func (w *LevelUpWizard) hpGain() int {
+	if w.options.HPRoll > 0 {
+		return max(w.options.HPRoll+w.conMod, 1)
+	}
+	return w.AverageHP()
+}
+
+// IsRollSelected returns true if the player chose to roll hit points
+func (w *LevelUpWizard) IsRollSelected() bool {
+	return w.CurrentStep() == LevelUpStepHP && w.selectedIndex == 1
+}
+
+// SetRolledHP records a hit die roll (before the CON modifier) and advances
+func (w *LevelUpWizard) SetRolledHP(roll int) {
+	w.options.HPRoll = roll
+	w.advance()
+}
+
+// Confirm records the selection on the current step and advances.
+// Returns an error message if the step can't be completed yet.
+func (w *LevelUpWizard) Confirm() string {
+	page := w.pages[w.pageIndex]
+	switch page.step {
+	case LevelUpStepHP:
+		w.options.HPRoll = 0
+	case LevelUpStepASI:
+		if w.selectedIndex == len(abilityOrder) {
+			w.options.AbilityIncrease = map[models.AbilityType]int{}
+			w.takeFeat = true
+			break
+		}
+
+		// Each press spends one of the 2 points: twice on one ability or once on two
+		ability := abilityOrder[w.selectedIndex]
+		increase := w.options.AbilityIncrease[ability] + 1
+		if w.character.AbilityScores.GetScore(ability)+increase > leveling.MaxAbilityScore {
+			return fmt.Sprintf("%s can't rise above %d", ability.FullName(), leveling.MaxAbilityScore)
+		}
+		w.options.AbilityIncrease[ability] = increase
+		w.takeFeat = false
+		if w.asiPointsSpent() < 2 {
+			return ""
+		}
+	case LevelUpStepSubclass:
+		name := strings.TrimSpace(w.subclassInput.Value())
+		if w.subclassSelector.HasOptions() {
+			name = w.subclassSelector.GetSelectedSubclass()
+		}
+		if name == "" {
+			return "Enter a subclass name"
+		}
+		w.options.Subclass = name
+	case LevelUpStepChoice:
+		w.options.FeatureChoices[page.feature.Name] = page.feature.Choices[w.selectedIndex]
+	case LevelUpStepSkill:
+		if len(page.feature.Choices) == 0 {
+			break // Already proficient in every skill on the list
+		}
+		skill := page.feature.Choices[w.selectedIndex]
+		if slices.Contains(w.options.Skills, skill) {
+			return fmt.Sprintf("%s is already chosen", skill)
+		}
+		w.options.Skills = append(w.options.Skills, skill)
+	case LevelUpStepBookSpell:
+		spell := page.feature.Choices[w.selectedIndex]
+		if slices.Contains(w.options.BookSpells, spell) {
+			return fmt.Sprintf("%s is already chosen", spell)
+		}
+		w.options.BookSpells = append(w.options.BookSpells, spell)
+	}
+	w.advance()
+	return ""
+}
+
+// asiPointsSpent returns how many of the 2 ASI points are allocated
+func (w *LevelUpWizard) asiPointsSpent() int {
+	total := 0
+	for _, increase := range w.options.AbilityIncrease {
+		total += increase
+	}
+	return total
+}
+
+// ResetASI clears the points allocated on the Ability Score Improvement step
+func (w *LevelUpWizard) ResetASI() {
+	if w.CurrentStep() == LevelUpStepASI {
+		w.options.AbilityIncrease = map[models.AbilityType]int{}
+	}
+}
+
+// bookPagesLeft returns how many spellbook pages follow the current one, including it
+func (w *LevelUpWizard) bookPagesLeft() int {
+	left := 0
+	for _, page := range w.pages[w.pageIndex:] {
+		if page.step == LevelUpStepBookSpell {
+			left++
+		}
+	}
+	return left
+}
+
+// advance moves to the next page
+func (w *LevelUpWizard) advance() {
+	if w.pageIndex < len(w.pages)-1 {
+		w.pageIndex++
+	}
+	w.selectedIndex = 0
+	if w.IsTyping() {
+		w.subclassInput.Focus()
+	} else {
+		w.subclassInput.Blur()
+	}
+}
+
+// HandleInput passes key messages to the subclass name field
+func (w *LevelUpWizard) HandleInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	w.subclassInput, cmd = w.subclassInput.Update(msg)
+	return cmd
+}
+
+// GetOptions returns the choices made in the wizard
+func (w *LevelUpWizard) GetOptions() leveling.LevelUpOptions {
+	return w.options
+}
+
+// featLabel names the feats the Ability Score Improvement can be traded for
+func (w *LevelUpWizard) featLabel() string {
+	if w.asiLevel >= models.EpicBoonLevel {
+		return "an Epic Boon or General feat"
+	}
+	return "a General feat"
+}
+
+// WantsFeat returns true if the player took a feat instead of the ability increase
+func (w *LevelUpWizard) WantsFeat() bool {
+	return w.takeFeat
+}
+
+// subclassGrants lists what the subclass (chosen now or earlier) grants on this level up
+func (w *LevelUpWizard) subclassGrants() []string {
+	name := w.options.Subclass
+	if cl := w.character.GetClassLevels(w.className); cl != nil && cl.Subclass != "" {
+		name = cl.Subclass
+	}
+	subclass := models.GetSubclass(w.className, name)
+	if subclass == nil || w.asiOnly || w.bookOnly {
+		return nil
+	}
+
+	// Choosing the subclass now also grants its earlier levels
+	from := w.entry.Level
+	if w.options.Subclass != "" {
+		from = 1
+	}
+	var lines []string
+	for level := from; level <= w.entry.Level; level++ {
+		entry := subclass.GetLevel(level)
+		if entry == nil {
+			continue
+		}
+		for _, feature := range entry.Features {
+			lines = append(lines, fmt.Sprintf("%s (Subclass: %s)", feature.Name, subclass.Name))
+		}
+		if len(entry.Spells) > 0 {
+			lines = append(lines, "Always prepared: "+strings.Join(entry.Spells, ", "))
+		}
+	}
+	return lines
+}
+
+// isSubclassFeature returns true if a feature of the level is the class's generic subclass feature
+func (w *LevelUpWizard) isSubclassFeature(name string) bool {
+	for _, feature := range w.entry.Features {
+		if feature.Name == name && feature.SubclassFeature {
+			return true
+		}
+	}
+	return false
+}
+
+// abilityOrder is the display order of the ability scores
+var abilityOrder = []models.AbilityType{
+	models.Strength, models.Dexterity, models.Constitution,
+	models.Intelligence, models.Wisdom, models.Charisma,
+}
+
+// View renders the wizard
+func (w *LevelUpWizard) View(width, height int) string {
+	if !w.visible || w.character == nil {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("237"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86"))
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	var lines []string
+	if w.asiOnly {
+		lines = append(lines, titleStyle.Render(fmt.Sprintf("ABILITY SCORE IMPROVEMENT: %s level %d",
+			w.className, w.asiLevel)))
+	} else if w.bookOnly {
+		lines = append(lines, titleStyle.Render(fmt.Sprintf("SPELLBOOK: %s level 1", w.className)))
+	} else {
+		lines = append(lines, titleStyle.Render(fmt.Sprintf("LEVEL UP: %s %d → %d (character level %d)",
+			w.className, w.entry.Level-1, w.entry.Level, w.character.Level+1)))
+	}
+	lines = append(lines, helpStyle.Render(fmt.Sprintf("Step %d of %d", w.pageIndex+1, len(w.pages))))
+	lines = append(lines, "")
+
+	var options []string
+	page := w.pages[w.pageIndex]
+	switch page.step {
+	case LevelUpStepHP:
+		lines = append(lines, infoStyle.Render(fmt.Sprintf("Hit Points (d%d, CON %+d)", w.hitDie, w.conMod)))
+		options = []string{
+			fmt.Sprintf("Take the fixed value: %d HP", w.AverageHP()),
+			fmt.Sprintf("Roll 1d%d%+d", w.hitDie, w.conMod),
+		}
+	case LevelUpStepASI:
+		lines = append(lines, infoStyle.Render(fmt.Sprintf("Ability Score Improvement: %d of 2 points spent", w.asiPointsSpent())))
+		lines = append(lines, helpStyle.Render("+2 to one ability or +1 to two (max 20), or "+w.featLabel()))
+		lines = append(lines, "")
+		for _, ability := range abilityOrder {
+			score := w.character.AbilityScores.GetScore(ability)
+			line := fmt.Sprintf("%-12s %2d", ability.FullName(), score)
+			if increase := w.options.AbilityIncrease[ability]; increase > 0 {
+				line += fmt.Sprintf(" → %d (+%d)", score+increase, increase)
+			}
+			options = append(options, line)
+		}
+		options = append(options, "Take "+w.featLabel()+" instead")
+	case LevelUpStepSubclass:
+		lines = append(lines, infoStyle.Render(page.feature.Name))
+		lines = append(lines, wrapText(page.feature.Description, width-12)...)
+		lines = append(lines, "")
+		if w.subclassSelector.HasOptions() {
+			lines = append(lines, w.subclassSelector.Body(width-12))
+		} else {
+			lines = append(lines, w.subclassInput.View())
+		}
+	case LevelUpStepChoice, LevelUpStepSkill:
+		lines = append(lines, infoStyle.Render(page.feature.Name))
+		lines = append(lines, wrapText(page.feature.Description, width-12)...)
+		lines = append(lines, "")
+		options = page.feature.Choices
+	case LevelUpStepBookSpell:
+		lines = append(lines, infoStyle.Render(fmt.Sprintf("%s: %d of %d spells chosen", page.feature.Name,
+			len(w.options.BookSpells), len(w.options.BookSpells)+w.bookPagesLeft())))
+		lines = append(lines, wrapText(page.feature.Description, width-12)...)
+		lines = append(lines, "")
+		for _, name := range page.feature.Choices {
+			option := name
+			if spell := models.GetSpellByName(name); spell != nil {
+				option = fmt.Sprintf("%s (level %d %s)", name, spell.Level, spell.School)
+			}
+			if slices.Contains(w.options.BookSpells, name) {
+				option += " ✓"
+			}
+			options = append(options, option)
+		}
+	case LevelUpStepConfirm:
+		lines = append(lines, infoStyle.Render("You gain:"))
+		if !w.asiOnly && !w.bookOnly {
+			if w.options.HPRoll > 0 {
+				lines = append(lines, normalStyle.Render(fmt.Sprintf("  +%d HP (rolled %d on d%d)", w.hpGain(), w.options.HPRoll, w.hitDie)))
+			} else {
+				lines = append(lines, normalStyle.Render(fmt.Sprintf("  +%d HP (fixed)", w.hpGain())))
+			}
+		}
+		for ability, increase := range w.options.AbilityIncrease {
+			lines = append(lines, normalStyle.Render(fmt.Sprintf("  +%d %s", increase, ability.FullName())))
+		}
+		if w.takeFeat {
+			lines = append(lines, normalStyle.Render("  Chosen next: "+w.featLabel()))
+		}
+		if class := models.GetClassByName(w.className); !w.asiOnly && !w.bookOnly && class != nil && class.Multiclass != nil && w.character.ClassLevel(w.className) == 0 {
+			var profs []string
+			profs = append(profs, class.Multiclass.ArmorProficiencies...)
+			profs = append(profs, class.Multiclass.WeaponProficiencies...)
+			profs = append(profs, class.Multiclass.ToolProficiencies...)
+			if len(profs) > 0 {
+				lines = append(lines, normalStyle.Render("  Multiclass proficiencies: "+strings.Join(profs, ", ")))
+			}
+		}
+		subclassGrants := w.subclassGrants()
+		for _, line := range leveling.DescribeLevel(w.entry) {
+			if line == "Ability Score Improvement" || line == "Epic Boon" || (len(subclassGrants) > 0 && w.isSubclassFeature(line)) {
+				continue
+			}
+			lines = append(lines, normalStyle.Render("  "+line))
+		}
+		if w.options.Subclass != "" {
+			lines = append(lines, normalStyle.Render("  Subclass: "+w.options.Subclass))
+		}
+		for _, line := range subclassGrants {
+			lines = append(lines, normalStyle.Render("  "+line))
+		}
+		for _, skill := range w.options.Skills {
+			lines = append(lines, normalStyle.Render("  Skill proficiency: "+skill))
+		}
+		for name, choice := range w.options.FeatureChoices {
+			lines = append(lines, normalStyle.Render(fmt.Sprintf("  %s: %s", name, choice)))
+		}
+		if len(w.options.BookSpells) > 0 {
+			lines = append(lines, normalStyle.Render("  Spellbook: "+strings.Join(w.options.BookSpells, ", ")))
+		}
+	}
+
+	// Long lists (a wizard's spells) scroll with the selection
+	maxRows := max(height-len(lines)-6, 3)
+	start := 0
+	if w.selectedIndex > maxRows/2 && len(options) > maxRows {
+		start = min(w.selectedIndex-maxRows/2, len(options)-maxRows)
+	}
+	for i := start; i < min(start+maxRows, len(options)); i++ {
+		option := options[i]
+		if i == w.selectedIndex {
+			lines = append(lines, selectedStyle.Render("▶ "+option))
+		} else {
+			lines = append(lines, normalStyle.Render("  "+option))
+		}
+	}
+
+	lines = append(lines, "")
+	switch page.step {
+	case LevelUpStepConfirm:
+		if w.asiOnly || w.bookOnly {
+			lines = append(lines, helpStyle.Render("[Enter] Apply • [Esc] Cancel"))
+		} else {
+			lines = append(lines, helpStyle.Render("[Enter] Apply level up • [Esc] Cancel"))
+		}
+	case LevelUpStepASI:
+		lines = append(lines, helpStyle.Render("[↑/↓] Navigate • [Enter] +1 / Select • [Backspace] Reset • [Esc] Cancel"))
+	case LevelUpStepSubclass:
+		if w.subclassSelector.HasOptions() {
+			lines = append(lines, helpStyle.Render("[↑/↓] Navigate • [Enter] Select • [Esc] Cancel"))
+		} else {
+			lines = append(lines, helpStyle.Render("[Enter] Continue • [Esc] Cancel"))
+		}
+	default:
+		lines = append(lines, helpStyle.Render("[↑/↓] Navigate • [Enter] Select • [Esc] Cancel"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(width - 8).
+		Background(lipgloss.Color("235"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,172 @@
+// internal/ui/components/loadoutpopup.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// LoadoutPopup lists the character's loadouts to swap to, saves what is equipped as a
+// loadout and deletes loadouts
+type LoadoutPopup struct {
+	character     *models.Character
+	selectedIndex int
+	nameInput     textinput.Model
+	visible       bool
+}
+
+// NewLoadoutPopup creates a new loadout popup
+func NewLoadoutPopup() *LoadoutPopup {
+	nameInput := textinput.New()
+	nameInput.Placeholder = "e.g. Sword and Board"
+	nameInput.CharLimit = 30
+	nameInput.Width = 30
+
+	return &LoadoutPopup{nameInput: nameInput}
+}
+
+// Show displays the popup for a character, with the active loadout selected
+func (lp *LoadoutPopup) Show(char *models.Character) {
+	lp.character = char
+	lp.selectedIndex = 0
+	for i, loadout := range char.Inventory.Loadouts {
+		if strings.EqualFold(loadout.Name, char.Inventory.ActiveLoadout) {
+			lp.selectedIndex = i
+		}
+	}
+	lp.nameInput.SetValue("")
+	lp.nameInput.Focus()
+	lp.visible = true
+}
+
+// Hide hides the popup
+func (lp *LoadoutPopup) Hide() {
+	lp.visible = false
+	lp.nameInput.Blur()
+}
+
+// IsVisible returns whether the popup is visible
+func (lp *LoadoutPopup) IsVisible() bool {
+	return lp.visible
+}
+
+// Next moves to the next loadout
+func (lp *LoadoutPopup) Next() {
+	if lp.selectedIndex < len(lp.character.Inventory.Loadouts)-1 {
+		lp.selectedIndex++
+	}
+}
+
+// Prev moves to the previous loadout
+func (lp *LoadoutPopup) Prev() {
+	if lp.selectedIndex > 0 {
+		lp.selectedIndex--
+	}
+}
+
+// ClampSelection keeps the selection on the list after a loadout is deleted
+func (lp *LoadoutPopup) ClampSelection() {
+	lp.selectedIndex = max(min(lp.selectedIndex, len(lp.character.Inventory.Loadouts)-1), 0)
+}
+
+// Selected returns the name of the selected loadout, or "" if there are none
+func (lp *LoadoutPopup) Selected() string {
+	if lp.selectedIndex >= len(lp.character.Inventory.Loadouts) {
+		return ""
+	}
+	return lp.character.Inventory.Loadouts[lp.selectedIndex].Name
+}
+
+// Name returns the name typed to save the equipped items under
+func (lp *LoadoutPopup) Name() string {
+	return strings.TrimSpace(lp.nameInput.Value())
+}
+
+// HandleInput passes key messages to the name field
+func (lp *LoadoutPopup) HandleInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	lp.nameInput, cmd = lp.nameInput.Update(msg)
+	return cmd
+}
+
+// View renders the popup
+func (lp *LoadoutPopup) View(width, height int) string {
+	if !lp.visible || lp.character == nil {
+		return ""
+	}
+	inv := &lp.character.Inventory
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("237"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86"))
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("LOADOUTS"))
+	lines = append(lines, "")
+
+	if len(inv.Loadouts) == 0 {
+		lines = append(lines, helpStyle.Render("No loadouts yet: equip your gear, name it below and press Enter"))
+	}
+	for i, loadout := range inv.Loadouts {
+		name := loadout.Name
+		if strings.EqualFold(name, inv.ActiveLoadout) {
+			name += " (active)"
+		}
+		if i == lp.selectedIndex {
+			lines = append(lines, selectedStyle.Render("▶ "+name))
+		} else {
+			lines = append(lines, normalStyle.Render("  "+name))
+		}
+	}
+
+	if name := lp.Selected(); name != "" {
+		loadout := inv.FindLoadout(name)
+		lines = append(lines, "")
+		equipped := "nothing"
+		if len(loadout.Equipped) > 0 {
+			equipped = strings.Join(loadout.Equipped, ", ")
+		}
+		for _, line := range wrapText("Equips: "+equipped, width-14) {
+			lines = append(lines, infoStyle.Render(line))
+		}
+		if len(loadout.Attuned) > 0 {
+			for _, line := range wrapText(fmt.Sprintf("Attuned: %s", strings.Join(loadout.Attuned, ", ")), width-14) {
+				lines = append(lines, infoStyle.Render(line))
+			}
+		}
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, infoStyle.Render("Save equipped as: ")+lp.nameInput.View())
+	lines = append(lines, "")
+	lines = append(lines, helpStyle.Render("[↑/↓] Navigate • [Enter] Swap to loadout, or save if named • [Ctrl+D] Delete • [Esc] Close"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(width - 8).
+		Background(lipgloss.Color("235"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,248 @@
+// internal/ui/components/pursepopup.go
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// purseLedgerLines is the number of ledger entries shown in the popup
+const purseLedgerLines = 10
+
+// PurseAction is what the purse popup does with the amount entered
+type PurseAction int
+
+const (
+	PurseIncome PurseAction = iota
+	PurseExpense
+	PurseSplit
+)
+
+var purseActionNames = []string{"Income", "Expense", "Split loot"}
+
+// PursePopup records income, expenses and split loot with a reason, and shows the
+// coins and the ledger
+type PursePopup struct {
+	character    *models.Character
+	action       PurseAction
+	amountInput  textinput.Model
+	reasonInput  textinput.Model
+	membersInput textinput.Model
+	visible      bool
+}
+
+// NewPursePopup creates a new purse popup
+func NewPursePopup() *PursePopup {
+	amountInput := textinput.New()
+	amountInput.Placeholder = "e.g. 15 gp or 2 gp 5 sp"
+	amountInput.CharLimit = 30
+	amountInput.Width = 24
+
+	reasonInput := textinput.New()
+	reasonInput.Placeholder = "e.g. Dragon hoard"
+	reasonInput.CharLimit = 60
+	reasonInput.Width = 40
+
+	membersInput := textinput.New()
+	membersInput.Placeholder = "e.g. 4"
+	membersInput.CharLimit = 2
+	membersInput.Width = 4
+
+	return &PursePopup{
+		amountInput:  amountInput,
+		reasonInput:  reasonInput,
+		membersInput: membersInput,
+	}
+}
+
+// Show displays the popup for a character
+func (pp *PursePopup) Show(char *models.Character) {
+	pp.character = char
+	pp.action = PurseIncome
+	pp.amountInput.SetValue("")
+	pp.reasonInput.SetValue("")
+	pp.membersInput.SetValue("")
+	pp.focus(&pp.amountInput)
+	pp.visible = true
+}
+
+// Hide hides the popup
+func (pp *PursePopup) Hide() {
+	pp.visible = false
+	pp.focus(nil)
+}
+
+// IsVisible returns whether the popup is visible
+func (pp *PursePopup) IsVisible() bool {
+	return pp.visible
+}
+
+// focus focuses one field (or none)
+func (pp *PursePopup) focus(field *textinput.Model) {
+	for _, input := range []*textinput.Model{&pp.amountInput, &pp.reasonInput, &pp.membersInput} {
+		if input == field {
+			input.Focus()
+		} else {
+			input.Blur()
+		}
+	}
+}
+
+// fields returns the fields of the current action, in Tab order
+func (pp *PursePopup) fields() []*textinput.Model {
+	fields := []*textinput.Model{&pp.amountInput, &pp.reasonInput}
+	if pp.action == PurseSplit {
+		fields = append(fields, &pp.membersInput)
+	}
+	return fields
+}
+
+// NextAction switches between income, expense and split loot
+func (pp *PursePopup) NextAction() {
+	pp.setAction((pp.action + 1) % PurseAction(len(purseActionNames)))
+}
+
+// PrevAction switches between income, expense and split loot backwards
+func (pp *PursePopup) PrevAction() {
+	pp.setAction((pp.action + PurseAction(len(purseActionNames)) - 1) % PurseAction(len(purseActionNames)))
+}
+
+func (pp *PursePopup) setAction(action PurseAction) {
+	pp.action = action
+	// Only split loot has the members field
+	if pp.membersInput.Focused() && action != PurseSplit {
+		pp.focus(&pp.amountInput)
+	}
+}
+
+// NextField moves to the next field
+func (pp *PursePopup) NextField() {
+	fields := pp.fields()
+	for i, input := range fields {
+		if input.Focused() {
+			pp.focus(fields[(i+1)%len(fields)])
+			return
+		}
+	}
+	pp.focus(fields[0])
+}
+
+// HandleInput passes key messages to the focused field
+func (pp *PursePopup) HandleInput(msg tea.Msg) tea.Cmd {
+	for _, input := range pp.fields() {
+		if input.Focused() {
+			var cmd tea.Cmd
+			*input, cmd = input.Update(msg)
+			return cmd
+		}
+	}
+	return nil
+}
+
+// Action returns the selected action
+func (pp *PursePopup) Action() PurseAction {
+	return pp.action
+}
+
+// Amount returns the coins entered
+func (pp *PursePopup) Amount() (models.Coins, error) {
+	return models.ParseCoins(pp.amountInput.Value())
+}
+
+// Reason returns the reason entered
+func (pp *PursePopup) Reason() string {
+	return strings.TrimSpace(pp.reasonInput.Value())
+}
+
+// Members returns the number of party members to split loot among
+func (pp *PursePopup) Members() (int, error) {
+	members, err := strconv.Atoi(strings.TrimSpace(pp.membersInput.Value()))
+	if err != nil || members < 1 {
+		return 0, fmt.Errorf("enter the number of party members")
+	}
+	return members, nil
+}
+
+// View renders the popup
+func (pp *PursePopup) View(width, height int) string {
+	if !pp.visible || pp.character == nil {
+		return ""
+	}
+	inv := &pp.character.Inventory
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("237"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86"))
+
+	currencyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("PURSE"))
+	lines = append(lines, "")
+	lines = append(lines, currencyStyle.Render(fmt.Sprintf("💰 PP: %d  GP: %d  EP: %d  SP: %d  CP: %d",
+		inv.Platinum, inv.Gold, inv.Electrum, inv.Silver, inv.Copper)))
+	lines = append(lines, normalStyle.Render(fmt.Sprintf("Worth %s • %d coins weigh %.1f lbs",
+		models.FormatCopper(inv.Coins.Value()), inv.Coins.Count(), inv.Coins.Weight())))
+	lines = append(lines, "")
+
+	var actions []string
+	for i, name := range purseActionNames {
+		if PurseAction(i) == pp.action {
+			actions = append(actions, selectedStyle.Render(" "+name+" "))
+		} else {
+			actions = append(actions, normalStyle.Render(" "+name+" "))
+		}
+	}
+	lines = append(lines, strings.Join(actions, " "))
+	lines = append(lines, "")
+
+	lines = append(lines, infoStyle.Render("Amount:  ")+pp.amountInput.View())
+	lines = append(lines, infoStyle.Render("Reason:  ")+pp.reasonInput.View())
+	if pp.action == PurseSplit {
+		lines = append(lines, infoStyle.Render("Members: ")+pp.membersInput.View())
+		lines = append(lines, helpStyle.Render("The loot goes into your purse and the other shares are paid out"))
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, infoStyle.Render("Ledger"))
+	if len(inv.Ledger) == 0 {
+		lines = append(lines, helpStyle.Render("  No transactions yet"))
+	}
+	for i := len(inv.Ledger) - 1; i >= max(len(inv.Ledger)-purseLedgerLines, 0); i-- {
+		lines = append(lines, normalStyle.Render("  "+inv.Ledger[i].String()))
+	}
+	lines = append(lines, "")
+	lines = append(lines, helpStyle.Render("[↑/↓] Income/Expense/Split • [Tab] Switch field • [Enter] Record • [Esc] Close"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(width - 8).
+		Background(lipgloss.Color("235"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,261 @@
+// internal/ui/components/shoppopup.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// ShopPopup buys items from a shop (a DM's shop file or the item catalog) and sells
+// inventory items to it
+type ShopPopup struct {
+	character     *models.Character
+	shop          *models.Shop
+	local         bool  // The shop was loaded from the shop file
+	selling       bool  // Sell mode lists the inventory instead of the shop
+	matches       []int // Indices of the shop items matching the search
+	selectedIndex int
+	searchInput   textinput.Model
+	visible       bool
+}
+
+// NewShopPopup creates a new shop popup
+func NewShopPopup() *ShopPopup {
+	searchInput := textinput.New()
+	searchInput.Placeholder = "Type to search..."
+	searchInput.CharLimit = 40
+	searchInput.Width = 30
+
+	return &ShopPopup{searchInput: searchInput}
+}
+
+// Show opens a shop for a character; local is true for a shop loaded from the shop file
+func (sp *ShopPopup) Show(char *models.Character, shop *models.Shop, local bool) {
+	sp.character = char
+	sp.shop = shop
+	sp.local = local
+	sp.selling = false
+	sp.searchInput.SetValue("")
+	sp.searchInput.Focus()
+	sp.filter()
+	sp.visible = true
+}
+
+// Hide hides the popup
+func (sp *ShopPopup) Hide() {
+	sp.visible = false
+	sp.searchInput.Blur()
+}
+
+// IsVisible returns whether the popup is visible
+func (sp *ShopPopup) IsVisible() bool {
+	return sp.visible
+}
+
+// Shop returns the open shop
+func (sp *ShopPopup) Shop() *models.Shop {
+	return sp.shop
+}
+
+// IsLocal returns whether the open shop was loaded from the shop file
+func (sp *ShopPopup) IsLocal() bool {
+	return sp.local
+}
+
+// IsSelling returns whether the popup is in sell mode
+func (sp *ShopPopup) IsSelling() bool {
+	return sp.selling
+}
+
+// ToggleMode switches between buying and selling
+func (sp *ShopPopup) ToggleMode() {
+	sp.selling = !sp.selling
+	sp.selectedIndex = 0
+	if sp.selling {
+		sp.searchInput.Blur()
+	} else {
+		sp.searchInput.Focus()
+	}
+}
+
+// Next moves to the next item
+func (sp *ShopPopup) Next() {
+	if sp.selectedIndex < sp.count()-1 {
+		sp.selectedIndex++
+	}
+}
+
+// Prev moves to the previous item
+func (sp *ShopPopup) Prev() {
+	if sp.selectedIndex > 0 {
+		sp.selectedIndex--
+	}
+}
+
+// count returns the number of items listed in the current mode
+func (sp *ShopPopup) count() int {
+	if sp.selling {
+		return len(sp.character.Inventory.Items)
+	}
+	return len(sp.matches)
+}
+
+// ClampSelection keeps the selection on the list after an item is sold
+func (sp *ShopPopup) ClampSelection() {
+	sp.selectedIndex = max(min(sp.selectedIndex, sp.count()-1), 0)
+}
+
+// HandleInput passes key messages to the search field while buying
+func (sp *ShopPopup) HandleInput(msg tea.Msg) tea.Cmd {
+	if sp.selling {
+		return nil
+	}
+	var cmd tea.Cmd
+	sp.searchInput, cmd = sp.searchInput.Update(msg)
+	sp.filter()
+	return cmd
+}
+
+// filter lists the shop items whose name contains the search
+func (sp *ShopPopup) filter() {
+	query := strings.ToLower(strings.TrimSpace(sp.searchInput.Value()))
+	sp.matches = nil
+	for i, item := range sp.shop.Items {
+		if strings.Contains(strings.ToLower(item.Name), query) {
+			sp.matches = append(sp.matches, i)
+		}
+	}
+	sp.selectedIndex = 0
+}
+
+// SelectedShopItem returns the index in the shop of the selected item, or -1
+func (sp *ShopPopup) SelectedShopItem() int {
+	if sp.selling || sp.selectedIndex >= len(sp.matches) {
+		return -1
+	}
+	return sp.matches[sp.selectedIndex]
+}
+
+// SelectedInventoryItem returns the selected inventory item in sell mode, or nil
+func (sp *ShopPopup) SelectedInventoryItem() *models.Item {
+	if !sp.selling || sp.selectedIndex >= len(sp.character.Inventory.Items) {
+		return nil
+	}
+	return &sp.character.Inventory.Items[sp.selectedIndex]
+}
+
+// View renders the popup
+func (sp *ShopPopup) View(width, height int) string {
+	if !sp.visible || sp.character == nil {
+		return ""
+	}
+	inv := &sp.character.Inventory
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("237"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86"))
+
+	currencyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	source := "item catalog"
+	if sp.local {
+		source = "shop file"
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(strings.ToUpper(sp.shop.Name))+helpStyle.Render(" ("+source+")"))
+	lines = append(lines, currencyStyle.Render("💰 Purse: "+inv.Coins.String()))
+	lines = append(lines, "")
+
+	var rows []string
+	if sp.selling {
+		lines = append(lines, infoStyle.Render(fmt.Sprintf("SELL • the shop pays %.0f%% of the price", sp.shop.Rate()*100)))
+		for _, item := range inv.Items {
+			line := fmt.Sprintf("%-28s %12s", truncateItemName(fmt.Sprintf("%s ×%d", item.Name, item.Quantity), 28),
+				models.FormatCopper(sp.shop.SellPrice(&item)))
+			if item.Equipped {
+				line += " (equipped)"
+			}
+			rows = append(rows, line)
+		}
+		if len(rows) == 0 {
+			lines = append(lines, helpStyle.Render("Nothing to sell"))
+		}
+	} else {
+		lines = append(lines, infoStyle.Render("BUY • Search: ")+sp.searchInput.View()+fmt.Sprintf(" (%d)", len(sp.matches)))
+		for _, index := range sp.matches {
+			item := sp.shop.Items[index]
+			stock := ""
+			if item.Stock != nil {
+				stock = fmt.Sprintf("%d left", *item.Stock)
+			}
+			rows = append(rows, fmt.Sprintf("%-28s %12s %8s", truncateItemName(item.Name, 28), models.FormatCopper(item.Price()), stock))
+		}
+		if len(rows) == 0 {
+			lines = append(lines, helpStyle.Render("No items found"))
+		}
+	}
+	lines = append(lines, "")
+
+	maxRows := max(height-14, 3)
+	start := 0
+	if sp.selectedIndex > maxRows/2 && len(rows) > maxRows {
+		start = min(sp.selectedIndex-maxRows/2, len(rows)-maxRows)
+	}
+	for i := start; i < min(start+maxRows, len(rows)); i++ {
+		switch {
+		case i == sp.selectedIndex:
+			lines = append(lines, selectedStyle.Render("▶ "+rows[i]))
+		case !sp.selling && !sp.affordable(sp.shop.Items[sp.matches[i]]):
+			lines = append(lines, dimStyle.Render("  "+rows[i]))
+		default:
+			lines = append(lines, normalStyle.Render("  "+rows[i]))
+		}
+	}
+	lines = append(lines, "")
+
+	if sp.selling {
+		lines = append(lines, helpStyle.Render("[↑/↓] Navigate • [Enter] Sell one • [+/-] Sell rate • [Tab] Buy • [Ctrl+L] Shop file/catalog • [Esc] Close"))
+	} else {
+		lines = append(lines, helpStyle.Render("[↑/↓] Navigate • [Enter] Buy one • [Tab] Sell • [Ctrl+L] Shop file/catalog • [Esc] Close"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(width - 8).
+		Background(lipgloss.Color("235"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(strings.Join(lines, "\n")))
+}
+
+// affordable reports whether the character can buy one of an item now
+func (sp *ShopPopup) affordable(item models.ShopItem) bool {
+	return item.InStock() && sp.character.Inventory.Coins.Value() >= item.Price()
+}
@@ -0,0 +1,228 @@
+// internal/ui/components/spellbookview.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// SpellbookMode determines what the spellbook popup lists
+type SpellbookMode int
+
+const (
+	SpellbookModeBook SpellbookMode = iota // Spells in the book (prepare, back up)
+	SpellbookModeCopy                      // Catalog spells that can be copied into the book
+)
+
+// SpellbookView manages a Wizard's spellbook: preparing, copying and backups
+type SpellbookView struct {
+	character     *models.Character
+	mode          SpellbookMode
+	spells        []models.Spell
+	selectedIndex int
+	visible       bool
+}
+
+// NewSpellbookView creates a new spellbook view
+func NewSpellbookView() *SpellbookView {
+	return &SpellbookView{}
+}
+
+// Show displays the spellbook for a character
+func (sv *SpellbookView) Show(char *models.Character) {
+	sv.character = char
+	sv.visible = true
+	sv.mode = SpellbookModeBook
+	sv.Refresh()
+}
+
+// Hide hides the spellbook view
+func (sv *SpellbookView) Hide() {
+	sv.visible = false
+}
+
+// IsVisible returns whether the view is visible
+func (sv *SpellbookView) IsVisible() bool {
+	return sv.visible
+}
+
+// GetMode returns the current mode
+func (sv *SpellbookView) GetMode() SpellbookMode {
+	return sv.mode
+}
+
+// ToggleMode switches between the book and the copy list
+func (sv *SpellbookView) ToggleMode() {
+	if sv.mode == SpellbookModeBook {
+		sv.mode = SpellbookModeCopy
+	} else {
+		sv.mode = SpellbookModeBook
+	}
+	sv.selectedIndex = 0
+	sv.Refresh()
+}
+
+// Refresh rebuilds the spell list for the current mode
+func (sv *SpellbookView) Refresh() {
+	sv.spells = []models.Spell{}
+	if sv.character == nil {
+		return
+	}
+
+	if sv.mode == SpellbookModeBook {
+		for level := 1; level <= 9; level++ {
+			for _, spell := range sv.character.SpellBook.Book {
+				if spell.Level == level {
+					sv.spells = append(sv.spells, spell)
+				}
+			}
+		}
+	} else {
+		for level := 1; level <= 9; level++ {
+			slot := sv.character.SpellBook.GetSlotByLevel(level)
+			if slot == nil || slot.Maximum == 0 {
+				continue
+			}
+			for _, spell := range models.GetSpellsForClass(models.SpellbookClass(sv.character), level) {
+				if !sv.character.SpellBook.InBook(spell.Name) {
+					sv.spells = append(sv.spells, spell)
+				}
+			}
+		}
+	}
+
+	if sv.selectedIndex >= len(sv.spells) {
+		sv.selectedIndex = max(len(sv.spells)-1, 0)
+	}
+}
+
+// Next moves to the next spell
+func (sv *SpellbookView) Next() {
+	if sv.selectedIndex < len(sv.spells)-1 {
+		sv.selectedIndex++
+	}
+}
+
+// Prev moves to the previous spell
+func (sv *SpellbookView) Prev() {
+	if sv.selectedIndex > 0 {
+		sv.selectedIndex--
+	}
+}
+
+// GetSelectedSpell returns the selected spell, or nil if the list is empty
+func (sv *SpellbookView) GetSelectedSpell() *models.Spell {
+	if sv.selectedIndex < 0 || sv.selectedIndex >= len(sv.spells) {
+		return nil
+	}
+	return &sv.spells[sv.selectedIndex]
+}
+
+// View renders the spellbook popup
+func (sv *SpellbookView) View(width, height int) string {
+	if !sv.visible || sv.character == nil {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("237"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86"))
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	sb := &sv.character.SpellBook
+	var lines []string
+
+	title := "SPELLBOOK"
+	if sv.mode == SpellbookModeCopy {
+		title = "COPY SPELL INTO SPELLBOOK"
+	}
+	lines = append(lines, titleStyle.Render(title))
+	lines = append(lines, infoStyle.Render(fmt.Sprintf("Purse: %s • Spells in book: %d • Backup copies: %d",
+		sv.character.Inventory.Coins, len(sb.Book), len(sb.BackupBook))))
+	if sb.BookLost {
+		lines = append(lines, warningStyle.Render("⚠ Your spellbook is lost! Press R to recover from your backup book."))
+	}
+	lines = append(lines, "")
+
+	if len(sv.spells) == 0 {
+		if sv.mode == SpellbookModeBook {
+			lines = append(lines, helpStyle.Render("Your spellbook is empty. Press Tab to copy spells."))
+		} else {
+			lines = append(lines, helpStyle.Render("No spells available to copy at your slot levels."))
+		}
+	} else {
+		// Keep the selection visible
+		visible := max(height-12, 5)
+		start := 0
+		if sv.selectedIndex >= visible {
+			start = sv.selectedIndex - visible + 1
+		}
+		end := min(start+visible, len(sv.spells))
+
+		for i := start; i < end; i++ {
+			spell := sv.spells[i]
+			var line string
+			if sv.mode == SpellbookModeBook {
+				marker := "○"
+				if sb.IsSpellPrepared(spell.Name) {
+					marker = "●"
+				}
+				backup := ""
+				for _, name := range sb.BackupBook {
+					if name == spell.Name {
+						backup = " [backup]"
+						break
+					}
+				}
+				line = fmt.Sprintf("%s L%d %s%s", marker, spell.Level, spell.Name, backup)
+			} else {
+				line = fmt.Sprintf("L%d %s — %d gp, %dh", spell.Level, spell.Name,
+					spell.Level*models.ScribeCostPerLevel, spell.Level*models.ScribeHoursPerLevel)
+			}
+
+			if i == sv.selectedIndex {
+				lines = append(lines, selectedStyle.Render("▶ "+line))
+			} else {
+				lines = append(lines, normalStyle.Render("  "+line))
+			}
+		}
+	}
+
+	lines = append(lines, "")
+	if sv.mode == SpellbookModeBook {
+		lines = append(lines, helpStyle.Render("[Enter] Prepare/Unprepare • [b] Copy to backup • [Tab] Copy spells"))
+		lines = append(lines, helpStyle.Render("[L] Mark book lost • [R] Recover from backup • [Esc] Close"))
+	} else {
+		lines = append(lines, helpStyle.Render("[Enter] Copy spell • [Tab] Back to book • [Esc] Close"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(width - 8).
+		Background(lipgloss.Color("235"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,147 @@
+// internal/ui/components/statbreakdown.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// StatBreakdown explains the derived numbers on the sheet: which modifiers make up each
+// total, and which were left out by the stacking rules
+type StatBreakdown struct {
+	character     *models.Character
+	targets       []string
+	selectedIndex int
+	visible       bool
+}
+
+// NewStatBreakdown creates a new stat breakdown popup
+func NewStatBreakdown() *StatBreakdown {
+	return &StatBreakdown{targets: models.DerivedTargets()}
+}
+
+// Show displays the breakdown of a target, e.g. models.TargetAC or "Strength"
+func (sb *StatBreakdown) Show(char *models.Character, target string) {
+	sb.character = char
+	sb.selectedIndex = 0
+	for i, t := range sb.targets {
+		if t == target {
+			sb.selectedIndex = i
+		}
+	}
+	sb.visible = true
+}
+
+// Hide hides the popup
+func (sb *StatBreakdown) Hide() {
+	sb.visible = false
+}
+
+// IsVisible returns whether the popup is visible
+func (sb *StatBreakdown) IsVisible() bool {
+	return sb.visible
+}
+
+// Next moves to the next stat
+func (sb *StatBreakdown) Next() {
+	if sb.selectedIndex < len(sb.targets)-1 {
+		sb.selectedIndex++
+	}
+}
+
+// Prev moves to the previous stat
+func (sb *StatBreakdown) Prev() {
+	if sb.selectedIndex > 0 {
+		sb.selectedIndex--
+	}
+}
+
+// View renders the stat list and the breakdown of the selected stat
+func (sb *StatBreakdown) View(width, height int) string {
+	if !sb.visible || sb.character == nil {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("237"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86"))
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	mods := sb.character.Modifiers()
+
+	var statList []string
+	for i, target := range sb.targets {
+		line := fmt.Sprintf("%-22s %3d", models.TargetLabel(target), models.EvaluateModifiers(target, mods).Total)
+		if i == sb.selectedIndex {
+			statList = append(statList, selectedStyle.Render("▶ "+line))
+		} else {
+			statList = append(statList, normalStyle.Render("  "+line))
+		}
+	}
+
+	target := sb.targets[sb.selectedIndex]
+	breakdown := models.EvaluateModifiers(target, mods)
+
+	var details []string
+	details = append(details, infoStyle.Render(fmt.Sprintf("Why is %s %d?", models.TargetLabel(target), breakdown.Total)))
+	details = append(details, "")
+	if len(breakdown.Lines) == 0 {
+		details = append(details, helpStyle.Render("Nothing contributes to this stat"))
+	}
+	for _, line := range breakdown.Lines {
+		value := fmt.Sprintf("%+4d", line.Value)
+		switch line.Kind {
+		case models.ModifierBase:
+			value = fmt.Sprintf("%4d", line.Value)
+		case models.ModifierMinimum:
+			value = fmt.Sprintf("≥%3d", line.Value)
+		}
+		text := fmt.Sprintf("%s  %s", value, line.Source)
+		if !line.Applied {
+			details = append(details, helpStyle.Render(fmt.Sprintf("%s (not applied: %s)", text, line.Note)))
+			continue
+		}
+		if line.Note != "" {
+			text += " (" + line.Note + ")"
+		}
+		details = append(details, normalStyle.Render(text))
+	}
+	details = append(details, "")
+	details = append(details, infoStyle.Render(fmt.Sprintf("%4d  Total", breakdown.Total)))
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("STAT BREAKDOWN"))
+	lines = append(lines, "")
+	lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top,
+		strings.Join(statList, "\n"),
+		"    ",
+		strings.Join(details, "\n")))
+	lines = append(lines, "")
+	lines = append(lines, helpStyle.Render("[↑/↓] Navigate • [Esc] Close"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(width - 8).
+		Background(lipgloss.Color("235"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,167 @@
+// internal/ui/components/subclassselector.go
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// SubclassSelector lists the subclasses of a class from data/subclasses with
+// what each grants per level
+type SubclassSelector struct {
+	className     string
+	subclasses    []models.Subclass
+	selectedIndex int
+	visible       bool
+}
+
+// NewSubclassSelector creates a new subclass selector
+func NewSubclassSelector() *SubclassSelector {
+	return &SubclassSelector{}
+}
+
+// Show displays the subclasses of a class
+func (s *SubclassSelector) Show(className string) {
+	s.Load(className)
+	s.visible = true
+}
+
+// Load lists the subclasses of a class without showing the selector
+// (the level-up wizard embeds it on its subclass step)
+func (s *SubclassSelector) Load(className string) {
+	s.className = className
+	s.subclasses = models.GetSubclassesForClass(className)
+	s.selectedIndex = 0
+}
+
+// Hide closes the subclass selector
+func (s *SubclassSelector) Hide() {
+	s.visible = false
+}
+
+// IsVisible returns whether the selector is visible
+func (s *SubclassSelector) IsVisible() bool {
+	return s.visible
+}
+
+// HasOptions returns true if the catalog has subclasses for the class
+func (s *SubclassSelector) HasOptions() bool {
+	return len(s.subclasses) > 0
+}
+
+// ClassName returns the class whose subclasses are listed
+func (s *SubclassSelector) ClassName() string {
+	return s.className
+}
+
+// Next moves to the next subclass
+func (s *SubclassSelector) Next() {
+	if s.selectedIndex < len(s.subclasses)-1 {
+		s.selectedIndex++
+	}
+}
+
+// Prev moves to the previous subclass
+func (s *SubclassSelector) Prev() {
+	if s.selectedIndex > 0 {
+		s.selectedIndex--
+	}
+}
+
+// GetSelectedSubclass returns the currently selected subclass name
+func (s *SubclassSelector) GetSelectedSubclass() string {
+	if s.selectedIndex >= 0 && s.selectedIndex < len(s.subclasses) {
+		return s.subclasses[s.selectedIndex].Name
+	}
+	return ""
+}
+
+// Body renders the subclass list and the details of the selected subclass
+func (s *SubclassSelector) Body(width int) string {
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		Background(lipgloss.Color("237"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86"))
+
+	descStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	var lines []string
+	for i, subclass := range s.subclasses {
+		if i == s.selectedIndex {
+			lines = append(lines, selectedStyle.Render("▶ "+subclass.Name))
+		} else {
+			lines = append(lines, normalStyle.Render("  "+subclass.Name))
+		}
+	}
+
+	if s.selectedIndex < len(s.subclasses) {
+		subclass := s.subclasses[s.selectedIndex]
+		lines = append(lines, "", strings.Repeat("─", max(width-4, 10)), "")
+		for _, line := range wrapText(subclass.Description, width-4) {
+			lines = append(lines, descStyle.Render(line))
+		}
+		lines = append(lines, "")
+		for _, level := range subclass.Levels {
+			var grants []string
+			for _, feature := range level.Features {
+				grants = append(grants, feature.Name)
+			}
+			if len(level.Spells) > 0 {
+				grants = append(grants, "Spells: "+strings.Join(level.Spells, ", "))
+			}
+			var profs []string
+			profs = append(profs, level.ArmorProficiencies...)
+			profs = append(profs, level.WeaponProficiencies...)
+			profs = append(profs, level.ToolProficiencies...)
+			profs = append(profs, level.SkillProficiencies...)
+			if len(profs) > 0 {
+				grants = append(grants, "Proficiencies: "+strings.Join(profs, ", "))
+			}
+			lines = append(lines, infoStyle.Render(fmt.Sprintf("Level %d: ", level.Level))+normalStyle.Render(strings.Join(grants, " • ")))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// View renders the subclass selector
+func (s *SubclassSelector) View(width, height int) string {
+	if !s.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("CHOOSE A %s SUBCLASS", strings.ToUpper(s.className))), ""}
+	if s.HasOptions() {
+		lines = append(lines, s.Body(width-12))
+	} else {
+		lines = append(lines, helpStyle.Render("No subclasses in data/subclasses for this class"))
+	}
+	lines = append(lines, "", helpStyle.Render("[↑/↓] Navigate • [Enter] Select • [Esc] Cancel"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(width - 8).
+		Background(lipgloss.Color("235"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,190 @@
+// internal/ui/components/xppopup.go
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// xpLedgerLines is the number of ledger entries shown in the popup
+const xpLedgerLines = 8
+
+// XPPopup awards XP with a reason (or grants a milestone level) and shows the ledger
+type XPPopup struct {
+	character   *models.Character
+	amountInput textinput.Model
+	reasonInput textinput.Model
+	visible     bool
+}
+
+// NewXPPopup creates a new XP popup
+func NewXPPopup() *XPPopup {
+	amountInput := textinput.New()
+	amountInput.Placeholder = "e.g. 450 or -50"
+	amountInput.CharLimit = 7
+	amountInput.Width = 12
+
+	reasonInput := textinput.New()
+	reasonInput.Placeholder = "e.g. Defeated the goblin ambush"
+	reasonInput.CharLimit = 60
+	reasonInput.Width = 40
+
+	return &XPPopup{
+		amountInput: amountInput,
+		reasonInput: reasonInput,
+	}
+}
+
+// Show displays the popup for a character
+func (xp *XPPopup) Show(char *models.Character) {
+	xp.character = char
+	xp.amountInput.SetValue("")
+	xp.reasonInput.SetValue("")
+	if char.MilestoneLeveling {
+		xp.amountInput.Blur()
+		xp.reasonInput.Focus()
+	} else {
+		xp.amountInput.Focus()
+		xp.reasonInput.Blur()
+	}
+	xp.visible = true
+}
+
+// Hide hides the popup
+func (xp *XPPopup) Hide() {
+	xp.visible = false
+	xp.amountInput.Blur()
+	xp.reasonInput.Blur()
+}
+
+// IsVisible returns whether the popup is visible
+func (xp *XPPopup) IsVisible() bool {
+	return xp.visible
+}
+
+// NextField switches between the amount and reason fields (milestone mode has no amount)
+func (xp *XPPopup) NextField() {
+	if xp.character.MilestoneLeveling {
+		return
+	}
+	if xp.amountInput.Focused() {
+		xp.amountInput.Blur()
+		xp.reasonInput.Focus()
+	} else {
+		xp.reasonInput.Blur()
+		xp.amountInput.Focus()
+	}
+}
+
+// HandleInput passes key messages to the focused field
+func (xp *XPPopup) HandleInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	if xp.amountInput.Focused() {
+		xp.amountInput, cmd = xp.amountInput.Update(msg)
+	} else {
+		xp.reasonInput, cmd = xp.reasonInput.Update(msg)
+	}
+	return cmd
+}
+
+// Amount returns the XP entered
+func (xp *XPPopup) Amount() (int, error) {
+	amount, err := strconv.Atoi(strings.TrimSpace(xp.amountInput.Value()))
+	if err != nil {
+		return 0, fmt.Errorf("enter the XP as a number")
+	}
+	return amount, nil
+}
+
+// Reason returns the reason entered
+func (xp *XPPopup) Reason() string {
+	return strings.TrimSpace(xp.reasonInput.Value())
+}
+
+// ProgressBar renders a bar of a given width filled in proportion to done/total
+func ProgressBar(done, total, width int) string {
+	filled := width
+	if total > 0 {
+		filled = min(max(done, 0)*width/total, width)
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// View renders the popup
+func (xp *XPPopup) View(width, height int) string {
+	if !xp.visible || xp.character == nil {
+		return ""
+	}
+	char := xp.character
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86"))
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		Bold(true)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Italic(true)
+
+	var lines []string
+	if char.MilestoneLeveling {
+		lines = append(lines, titleStyle.Render("MILESTONE LEVELLING"))
+		lines = append(lines, "")
+		lines = append(lines, normalStyle.Render(fmt.Sprintf("Level %d — the DM grants the next level", char.Level)))
+	} else {
+		lines = append(lines, titleStyle.Render("EXPERIENCE"))
+		lines = append(lines, "")
+		earned, needed := char.XPProgress()
+		lines = append(lines, infoStyle.Render(ProgressBar(earned, needed, 30))+
+			normalStyle.Render(fmt.Sprintf(" %d / %d XP (level %d)", char.Experience, char.GetNextLevelXP(), min(char.Level+1, 20))))
+	}
+	if char.CanLevelUp() {
+		lines = append(lines, warningStyle.Render(fmt.Sprintf("⬆ Level %d available! Press 'u' on Character Info", char.Level+1)))
+	}
+	lines = append(lines, "")
+
+	if !char.MilestoneLeveling {
+		lines = append(lines, infoStyle.Render("XP:     ")+xp.amountInput.View())
+	}
+	lines = append(lines, infoStyle.Render("Reason: ")+xp.reasonInput.View())
+	lines = append(lines, "")
+
+	lines = append(lines, infoStyle.Render("Ledger"))
+	if len(char.XPLog) == 0 {
+		lines = append(lines, helpStyle.Render("  No awards yet"))
+	}
+	for i := len(char.XPLog) - 1; i >= max(len(char.XPLog)-xpLedgerLines, 0); i-- {
+		lines = append(lines, normalStyle.Render("  "+char.XPLog[i].String()))
+	}
+	lines = append(lines, "")
+
+	if char.MilestoneLeveling {
+		lines = append(lines, helpStyle.Render("[Enter] Grant level • [Esc] Close"))
+	} else {
+		lines = append(lines, helpStyle.Render("[Tab] Switch field • [Enter] Award • [Esc] Close"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("86")).
+		Padding(1, 2).
+		Width(width - 8).
+		Background(lipgloss.Color("235"))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(strings.Join(lines, "\n")))
+}
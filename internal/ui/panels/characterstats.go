@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/marcozingoni/lazydndplayer/internal/models"
+	"github.com/marcozingoni/lazydndplayer/internal/ui/components"
 )
 
 // CharStatsEditMode represents what is being edited
@@ -88,8 +89,8 @@ func (p *CharacterStatsPanel) View(width, height int) string {
 		Foreground(lipgloss.Color("42")).
 		Bold(true)
 
-	// Calculate initiative modifier (DEX modifier + bonus from feats)
-	initiativeMod := char.AbilityScores.GetModifier(models.Dexterity) + char.InitiativeBonus
+	// Initiative modifier (DEX modifier + bonuses from feats and effects)
+	initiativeMod := char.Breakdown(models.TargetInitiative).Total
 
 	// Build stat boxes for important stats (smaller for 2-row layout)
 	boxWidth := 10
@@ -120,9 +121,9 @@ func (p *CharacterStatsPanel) View(width, height int) string {
 	)
 
 	// Calculate passive scores
-	passivePerception := p.calculatePassiveScore(char, models.Perception)
-	passiveInvestigation := p.calculatePassiveScore(char, models.Investigation)
-	passiveInsight := p.calculatePassiveScore(char, models.Insight)
+	passivePerception := char.Breakdown(models.TargetPassivePerception).Total
+	passiveInvestigation := char.Breakdown(models.TargetPassiveInvestigation).Total
+	passiveInsight := char.Breakdown(models.TargetPassiveInsight).Total
 
 	// Passive stat styles
 	passiveTextStyle := lipgloss.NewStyle().
@@ -147,16 +148,27 @@ func (p *CharacterStatsPanel) View(width, height int) string {
 
 	// Class and level (class can be changed with 'c')
 	classInfo := fmt.Sprintf("%s, Level %d", char.Class, char.Level)
+	if char.IsMulticlassed() {
+		classInfo = fmt.Sprintf("%s (Level %d)", char.ClassSummary(), char.Level)
+	}
 	if p.editMode == CharStatsNormal {
 		lines = append(lines, labelStyle.Render("Class:")+" "+valueStyle.Render(classInfo)+" "+lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("(press 'c' to change)"))
 	} else {
 		lines = append(lines, labelStyle.Render("Class:")+" "+valueStyle.Render(classInfo))
 	}
 
-	// XP information
-	xpToNext := getLevelXP(char.Level+1) - char.Experience
-	xpInfo := fmt.Sprintf("%d XP (next: %d)", char.Experience, xpToNext)
-	lines = append(lines, labelStyle.Render("Experience:")+" "+valueStyle.Render(xpInfo))
+	// XP progress towards the next level, or the milestone level granted
+	if char.MilestoneLeveling {
+		lines = append(lines, labelStyle.Render("Experience:")+" "+valueStyle.Render("Milestone levelling"))
+	} else {
+		earned, needed := char.XPProgress()
+		xpInfo := fmt.Sprintf(" %d/%d XP", char.Experience, char.GetNextLevelXP())
+		lines = append(lines, labelStyle.Render("Experience:")+" "+valueStyle.Render(components.ProgressBar(earned, needed, 16)+xpInfo))
+	}
+	if char.CanLevelUp() {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true).Render(
+			fmt.Sprintf("⬆ Level %d available! Press 'u' to level up", char.Level+1)))
+	}
 	lines = append(lines, "")
 
 	// Stat boxes in 2 rows
@@ -365,63 +377,3 @@ func (p *CharacterStatsPanel) RenderHPPopup(screenWidth, screenHeight int) strin
 func (p *CharacterStatsPanel) ToggleInspiration() {
 	p.character.Inspiration = !p.character.Inspiration
 }
-
-// calculatePassiveScore calculates a passive score for a given skill
-// Passive score = 10 + ability modifier + proficiency bonus (if proficient)
-func (p *CharacterStatsPanel) calculatePassiveScore(char *models.Character, skillName models.SkillType) int {
-	// Get the skill
-	skill := char.Skills.GetSkill(skillName)
-	if skill == nil {
-		return 10 // Default if skill not found
-	}
-
-	// Get ability modifier for the skill
-	abilityMod := char.AbilityScores.GetModifier(skill.Ability)
-
-	// Calculate skill bonus (includes proficiency if applicable)
-	skillBonus := skill.CalculateBonus(abilityMod, char.ProficiencyBonus)
-
-	// Add feat bonuses
-	featBonus := 0
-	switch skillName {
-	case models.Perception:
-		featBonus = char.PassivePerceptionBonus
-	case models.Investigation:
-		featBonus = char.PassiveInvestigationBonus
-	case models.Insight:
-		featBonus = char.PassiveInsightBonus
-	}
-
-	// Passive score = 10 + skill bonus + feat bonus
-	return 10 + skillBonus + featBonus
-}
-
-// getLevelXP returns the XP required to reach a given level (simplified)
-func getLevelXP(level int) int {
-	xpTable := map[int]int{
-		1:  0,
-		2:  300,
-		3:  900,
-		4:  2700,
-		5:  6500,
-		6:  14000,
-		7:  23000,
-		8:  34000,
-		9:  48000,
-		10: 64000,
-		11: 85000,
-		12: 100000,
-		13: 120000,
-		14: 140000,
-		15: 165000,
-		16: 195000,
-		17: 225000,
-		18: 265000,
-		19: 305000,
-		20: 355000,
-	}
-	if xp, exists := xpTable[level]; exists {
-		return xp
-	}
-	return 355000 // Max level
-}
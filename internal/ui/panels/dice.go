@@ -239,6 +239,17 @@ func (p *DicePanel) Roll(expression string) {
 	p.input.SetValue("")
 }
 
+// RollResult rolls a single expression, records it in the history and returns the result
+func (p *DicePanel) RollResult(expression string) (*dice.RollResult, error) {
+	result, err := dice.Roll(expression, dice.Normal)
+	if err != nil {
+		return nil, err
+	}
+	p.history.Add(*result)
+	p.LastMessage = result.String()
+	return result, nil
+}
+
 // GetInput returns the current input value
 func (p *DicePanel) GetInput() string {
 	return p.input.Value()
@@ -64,6 +64,22 @@ func (p *FeaturesPanel) View(width, height int) string {
 
 	var content []string
 
+	if len(p.character.Resources) > 0 {
+		content = append(content, titleStyle.Render("🔮 RESOURCES"))
+		content = append(content, "")
+		for i, res := range p.character.Resources {
+			line := fmt.Sprintf("%s [%s]", res.String(), res.RestType)
+			if i == p.selectedIndex {
+				content = append(content, selectedStyle.Render("  → "+line))
+			} else if res.Current == 0 {
+				content = append(content, usedStyle.Render("    "+line+" [EMPTY]"))
+			} else {
+				content = append(content, normalStyle.Render("    "+line))
+			}
+		}
+		content = append(content, "")
+	}
+
 	if len(p.character.Features.Features) == 0 {
 		content = append(content, emptyStyle.Render("No features yet"))
 		content = append(content, "")
@@ -89,8 +105,8 @@ func (p *FeaturesPanel) View(width, height int) string {
 			}
 		}
 
-		// Render features by category
-		currentIndex := 0
+		// Render features by category (resources come first in the selection)
+		currentIndex := len(p.character.Resources)
 
 		if len(shortRestFeatures) > 0 {
 			content = append(content, titleStyle.Render("⚡ SHORT REST FEATURES"))
@@ -205,7 +221,7 @@ func (p *FeaturesPanel) Update(msg tea.Msg) {
 }
 
 func (p *FeaturesPanel) Next() {
-	if p.selectedIndex < len(p.character.Features.Features)-1 {
+	if p.selectedIndex < len(p.character.Resources)+len(p.character.Features.Features)-1 {
 		p.selectedIndex++
 		p.viewport.LineDown(3)
 	}
@@ -234,25 +250,70 @@ func (p *FeaturesPanel) PageUp() {
 	p.viewport.HalfViewUp()
 }
 
-func (p *FeaturesPanel) UseFeature() {
-	if len(p.character.Features.Features) > 0 {
-		p.character.Features.UseFeature(p.selectedIndex)
+// GetSelectedResource returns the selected resource pool, or nil if a feature is selected
+func (p *FeaturesPanel) GetSelectedResource() *models.Resource {
+	if p.selectedIndex < len(p.character.Resources) {
+		return &p.character.Resources[p.selectedIndex]
 	}
+	return nil
 }
 
-func (p *FeaturesPanel) RestoreFeature() {
-	if len(p.character.Features.Features) > 0 {
-		p.character.Features.RestoreFeature(p.selectedIndex)
+// selectedFeatureIndex maps the selection to an index in Features, following the
+// grouped display order (short rest, long rest, daily, passive). Returns -1 if none.
+func (p *FeaturesPanel) selectedFeatureIndex() int {
+	target := p.selectedIndex - len(p.character.Resources)
+	if target < 0 {
+		return -1
 	}
-}
 
-func (p *FeaturesPanel) RemoveFeature() {
-	if len(p.character.Features.Features) > 0 {
-		p.character.Features.RemoveFeature(p.selectedIndex)
-		if p.selectedIndex >= len(p.character.Features.Features) && p.selectedIndex > 0 {
-			p.selectedIndex--
+	position := 0
+	for _, restType := range []models.RestType{models.ShortRest, models.LongRest, models.Daily, models.None} {
+		for i, feature := range p.character.Features.Features {
+			if feature.RestType != restType {
+				continue
+			}
+			if position == target {
+				return i
+			}
+			position++
 		}
 	}
+	return -1
+}
+
+// UseFeature spends one use of the selected feature or one point of the selected resource
+func (p *FeaturesPanel) UseFeature() bool {
+	if res := p.GetSelectedResource(); res != nil {
+		return res.Spend(1)
+	}
+	if index := p.selectedFeatureIndex(); index >= 0 {
+		return p.character.Features.UseFeature(index)
+	}
+	return false
+}
+
+// RestoreFeature restores one use of the selected feature or one point of the selected resource
+func (p *FeaturesPanel) RestoreFeature() bool {
+	if res := p.GetSelectedResource(); res != nil {
+		return res.Restore(1)
+	}
+	if index := p.selectedFeatureIndex(); index >= 0 {
+		return p.character.Features.RestoreFeature(index)
+	}
+	return false
+}
+
+// RemoveFeature removes the selected feature. Resources come from the class and can't be removed.
+func (p *FeaturesPanel) RemoveFeature() bool {
+	index := p.selectedFeatureIndex()
+	if index < 0 {
+		return false
+	}
+	p.character.Features.RemoveFeature(index)
+	if p.selectedIndex >= len(p.character.Resources)+len(p.character.Features.Features) && p.selectedIndex > 0 {
+		p.selectedIndex--
+	}
+	return true
 }
 
 // wrapFeatureText wraps text to a specified width
@@ -14,7 +14,8 @@ import (
 type InventoryPanel struct {
 	character         *models.Character
 	selectedIndex     int
-	visualToActualMap []int // Maps visual index to actual inventory index
+	visualToActualMap []int        // Maps visual index to actual inventory index
+	collapsed         map[int]bool // Container IDs whose contents are hidden
 	viewport          viewport.Model
 	ready             bool
 }
@@ -24,6 +25,7 @@ func NewInventoryPanel(char *models.Character) *InventoryPanel {
 	return &InventoryPanel{
 		character:     char,
 		selectedIndex: 0,
+		collapsed:     map[int]bool{},
 	}
 }
 
@@ -70,8 +72,9 @@ func (p *InventoryPanel) View(width, height int) string {
 		Foreground(lipgloss.Color("214")).
 		Bold(true)
 
-	lines = append(lines, currencyStyle.Render(fmt.Sprintf("💰 Gold: %d  Silver: %d  Copper: %d",
-		char.Inventory.Gold, char.Inventory.Silver, char.Inventory.Copper)))
+	coins := char.Inventory.Coins
+	lines = append(lines, currencyStyle.Render(fmt.Sprintf("💰 PP: %d  GP: %d  EP: %d  SP: %d  CP: %d  (%.1f lbs)",
+		coins.Platinum, coins.Gold, coins.Electrum, coins.Silver, coins.Copper, coins.Weight())))
 	lines = append(lines, "")
 
 	// Encumbrance
@@ -83,6 +86,37 @@ func (p *InventoryPanel) View(width, height int) string {
 
 	lines = append(lines, weightStyle.Render(fmt.Sprintf("⚖  Carry Weight: %.1f / %.1f lbs",
 		totalWeight, char.Inventory.CarryCapacity)))
+
+	// Attunement slots
+	attuned := char.Inventory.AttunedItems()
+	if len(attuned) > 0 {
+		names := make([]string, len(attuned))
+		for i, item := range attuned {
+			names[i] = item.Name
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Render(
+			fmt.Sprintf("◆  Attuned: %d/%d  %s", len(attuned), models.MaxAttunedItems, strings.Join(names, ", "))))
+	}
+
+	// Loadout last swapped to
+	if char.Inventory.ActiveLoadout != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("75")).Render(
+			fmt.Sprintf("⚔  Loadout: %s (%d saved, [w] to swap)", char.Inventory.ActiveLoadout, len(char.Inventory.Loadouts))))
+	}
+
+	// Active effects of potions and spells
+	if len(char.Effects) > 0 {
+		var effects []string
+		for _, effect := range char.Effects {
+			if effect.Duration != "" {
+				effects = append(effects, fmt.Sprintf("%s (%s)", effect.Name, effect.Duration))
+			} else {
+				effects = append(effects, effect.Name)
+			}
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Render(
+			"✧  Active: "+strings.Join(effects, ", ")))
+	}
 	lines = append(lines, "")
 
 	// Group items by type
@@ -105,14 +139,96 @@ func (p *InventoryPanel) View(width, height int) string {
 			models.Other,
 		}
 
-		for i, item := range char.Inventory.Items {
-			itemsByType[item.Type] = append(itemsByType[item.Type], i)
+		// Items inside containers are listed under their container
+		for i := range char.Inventory.Items {
+			item := &char.Inventory.Items[i]
+			if char.Inventory.ContainerOf(item) == nil {
+				itemsByType[item.Type] = append(itemsByType[item.Type], i)
+			}
 		}
 
 		// Build mapping from visual index to actual inventory index
 		p.visualToActualMap = []int{}
 		visualIndex := 0
 
+		// renderItem adds an item's line, followed by the contents of an expanded container
+		var renderItem func(actualIdx, depth int)
+		renderItem = func(actualIdx, depth int) {
+			item := &char.Inventory.Items[actualIdx]
+
+			// Check if item is equippable
+			def := models.GetItemDefinitionByName(item.Name)
+			isEquippable := def != nil && models.IsEquippable(*def)
+
+			// Containers show whether they are expanded
+			indent := strings.Repeat("  ", depth)
+			spec := models.ContainerSpecOf(item)
+			tree := "  "
+			if spec != nil {
+				tree = "▾ "
+				if p.collapsed[item.ID] {
+					tree = "▸ "
+				}
+			}
+			nameWidth := max(25-2*depth, 10)
+
+			marker := "   "
+			if isEquippable {
+				// Show checkbox for equippable items
+				marker = "[ ]"
+				if item.Equipped {
+					marker = "[E]"
+				}
+			}
+			line := fmt.Sprintf("  %s %s%s%-*s x%-3d  %5.1f lbs",
+				marker,
+				indent,
+				tree,
+				nameWidth,
+				truncateString(item.Name, nameWidth),
+				item.Quantity,
+				char.Inventory.CarriedWeight(item),
+			)
+
+			// Weight inside a container against its capacity
+			if spec != nil {
+				held := fmt.Sprintf("  [%.1f/%.0f lbs", char.Inventory.ContentsWeight(item), spec.Capacity)
+				if spec.Weightless {
+					held += ", weightless"
+				}
+				line += held + "]"
+			}
+
+			// Charges left on wands, staffs and rings
+			if item.HasCharges() {
+				line += fmt.Sprintf("  ⚡%d/%d", item.Charges, item.MaxCharges)
+			}
+
+			// Attunement marker for magic items that require it
+			if item.Attuned {
+				line += "  ◆"
+			} else if def != nil && def.RequiresAttunement {
+				line += "  ◇"
+			}
+
+			// Map visual index to actual inventory index
+			p.visualToActualMap = append(p.visualToActualMap, actualIdx)
+
+			if visualIndex == p.selectedIndex {
+				lines = append(lines, selectedStyle.Render(line))
+			} else {
+				lines = append(lines, normalStyle.Render(line))
+			}
+
+			visualIndex++
+
+			if spec != nil && !p.collapsed[item.ID] {
+				for _, contentIdx := range char.Inventory.Contents(item) {
+					renderItem(contentIdx, depth+1)
+				}
+			}
+		}
+
 		// Display items by category
 		for _, itemType := range categoryOrder {
 			indices := itemsByType[itemType]
@@ -125,44 +241,7 @@ func (p *InventoryPanel) View(width, height int) string {
 
 			// Display items in this category
 			for _, actualIdx := range indices {
-				item := char.Inventory.Items[actualIdx]
-
-				// Check if item is equippable
-				def := models.GetItemDefinitionByName(item.Name)
-				isEquippable := def != nil && models.IsEquippable(*def)
-
-				var line string
-				if isEquippable {
-					// Show checkbox for equippable items
-					marker := " "
-					if item.Equipped {
-						marker = "E"
-					}
-					line = fmt.Sprintf("  [%s] %-25s x%-3d  %5.1f lbs",
-						marker,
-						truncateString(item.Name, 25),
-						item.Quantity,
-						item.TotalWeight(),
-					)
-				} else {
-					// No checkbox for non-equippable items
-					line = fmt.Sprintf("      %-25s x%-3d  %5.1f lbs",
-						truncateString(item.Name, 25),
-						item.Quantity,
-						item.TotalWeight(),
-					)
-				}
-
-				// Map visual index to actual inventory index
-				p.visualToActualMap = append(p.visualToActualMap, actualIdx)
-
-				if visualIndex == p.selectedIndex {
-					lines = append(lines, selectedStyle.Render(line))
-				} else {
-					lines = append(lines, normalStyle.Render(line))
-				}
-
-				visualIndex++
+				renderItem(actualIdx, 0)
 			}
 
 			lines = append(lines, "") // Space between categories
@@ -172,7 +251,7 @@ func (p *InventoryPanel) View(width, height int) string {
 	lines = append(lines, "")
 	lines = append(lines, lipgloss.NewStyle().
 		Foreground(lipgloss.Color("240")).
-		Render("[E] = Equipped  |  'Enter' Details  |  'a' Add  |  'e' Equip  |  'd' Remove 1  |  'D' Remove All"))
+		Render("[E] = Equipped  ◆/◇ = Attuned/Requires attunement  |  'Enter' Details  |  'Space' Expand/Collapse  |  'm' Move  |  'a' Add  |  'e' Equip  |  't' Attune  |  'u' Use  |  'r' Dawn  |  'x' End Effects  |  'd' Remove 1  |  'D' Remove All"))
 
 	content := strings.Join(lines, "\n")
 	p.viewport.SetContent(content)
@@ -243,16 +322,25 @@ func (p *InventoryPanel) ToggleEquipped() {
 	}
 }
 
+// ToggleCollapsed shows or hides the contents of the selected container, reporting
+// whether a container was selected
+func (p *InventoryPanel) ToggleCollapsed() bool {
+	item := p.GetSelectedItem()
+	if item == nil || !models.IsContainer(item) {
+		return false
+	}
+	p.collapsed[item.ID] = !p.collapsed[item.ID]
+	return true
+}
+
 // DeleteSelected deletes the selected item
 func (p *InventoryPanel) DeleteSelected() {
 	// Use mapping to get actual inventory index
 	if p.selectedIndex >= 0 && p.selectedIndex < len(p.visualToActualMap) {
 		actualIdx := p.visualToActualMap[p.selectedIndex]
 		if actualIdx >= 0 && actualIdx < len(p.character.Inventory.Items) {
-			p.character.Inventory.Items = append(
-				p.character.Inventory.Items[:actualIdx],
-				p.character.Inventory.Items[actualIdx+1:]...,
-			)
+			// The contents of a removed container stay in the inventory
+			p.character.Inventory.RemoveAt(actualIdx)
 			// Adjust selection if needed
 			if p.selectedIndex >= len(p.character.Inventory.Items) && p.selectedIndex > 0 {
 				p.selectedIndex--
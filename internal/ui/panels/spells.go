@@ -13,6 +13,7 @@ import (
 type SpellsPanel struct {
 	character     *models.Character
 	selectedIndex int
+	castLevel     int // Slot level used when casting the selected spell (0 = spell's own level)
 }
 
 // NewSpellsPanel creates a new spells panel
@@ -35,6 +36,17 @@ func (p *SpellsPanel) View(width, height int) string {
 	normalStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("252"))
 
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true)
+
+	mechanicsStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("86")).
+		Italic(true)
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214"))
+
 	var lines []string
 	lines = append(lines, titleStyle.Render("SPELLS"))
 	lines = append(lines, "")
@@ -79,6 +91,11 @@ func (p *SpellsPanel) View(width, height int) string {
 				sl.level, slots, sl.slot.Current, sl.slot.Maximum))
 		}
 	}
+	if pact := char.SpellBook.PactSlots; pact.Maximum > 0 {
+		slots := strings.Repeat("●", pact.Current) + strings.Repeat("○", pact.Maximum-pact.Current)
+		lines = append(lines, fmt.Sprintf("Pact (level %d): %s (%d/%d)",
+			pact.Level, slots, pact.Current, pact.Maximum))
+	}
 	lines = append(lines, "")
 
 	// Spells list
@@ -92,6 +109,8 @@ func (p *SpellsPanel) View(width, height int) string {
 		for _, spell := range char.SpellBook.Spells {
 			spellsByLevel[spell.Level] = append(spellsByLevel[spell.Level], spell)
 		}
+		selected := p.GetSelectedSpell()
+		displayIndex := 0
 
 		// Display cantrips first, then by level
 		for level := 0; level <= 9; level++ {
@@ -119,8 +138,24 @@ func (p *SpellsPanel) View(width, height int) string {
 					ritualMarker = " (R)"
 				}
 
-				line := fmt.Sprintf("%s %s%s", prepMarker, spell.Name, ritualMarker)
-				lines = append(lines, normalStyle.Render(line))
+				cursor := "  "
+				if displayIndex == p.selectedIndex {
+					cursor = "▶ "
+				}
+				displayIndex++
+
+				line := fmt.Sprintf("%s%s %s%s", cursor, prepMarker, spell.Name, ritualMarker)
+				if cursor != "  " {
+					lines = append(lines, selectedStyle.Render(line))
+					if summary := p.selectedSummary(selected); summary != "" {
+						lines = append(lines, mechanicsStyle.Render("    "+summary))
+					}
+					if check := models.CheckSpellComponents(char, selected); !check.OK {
+						lines = append(lines, warningStyle.Render("    ⚠ "+check.Warning))
+					}
+				} else {
+					lines = append(lines, normalStyle.Render(line))
+				}
 			}
 			lines = append(lines, "")
 		}
@@ -130,6 +165,9 @@ func (p *SpellsPanel) View(width, height int) string {
 		Foreground(lipgloss.Color("240")).
 		Render("● = Prepared  (R) = Ritual"))
 	lines = append(lines, "")
+	lines = append(lines, lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Render("Press 'c' to cast/roll, '<'/'>' to change slot level"))
 	lines = append(lines, lipgloss.NewStyle().
 		Foreground(lipgloss.Color("240")).
 		Render("Press 'a' to add spell"))
@@ -151,3 +189,106 @@ func (p *SpellsPanel) View(width, height int) string {
 func (p *SpellsPanel) Update(char *models.Character) {
 	p.character = char
 }
+
+// orderedSpells returns spells in display order (cantrips first, then by level)
+func (p *SpellsPanel) orderedSpells() []models.Spell {
+	var ordered []models.Spell
+	for level := 0; level <= 9; level++ {
+		for _, spell := range p.character.SpellBook.Spells {
+			if spell.Level == level {
+				ordered = append(ordered, spell)
+			}
+		}
+	}
+	return ordered
+}
+
+// Next selects the next spell
+func (p *SpellsPanel) Next() {
+	if p.selectedIndex < len(p.orderedSpells())-1 {
+		p.selectedIndex++
+		p.castLevel = 0
+	}
+}
+
+// Prev selects the previous spell
+func (p *SpellsPanel) Prev() {
+	if p.selectedIndex > 0 {
+		p.selectedIndex--
+		p.castLevel = 0
+	}
+}
+
+// GetSelectedSpell returns the currently selected spell, resolving missing
+// mechanics from the spell catalog for spells saved before they existed
+func (p *SpellsPanel) GetSelectedSpell() *models.Spell {
+	spells := p.orderedSpells()
+	if p.selectedIndex < 0 || p.selectedIndex >= len(spells) {
+		return nil
+	}
+	spell := spells[p.selectedIndex]
+	if catalog := models.GetSpellByName(spell.Name); catalog != nil {
+		if !spell.HasMechanics() {
+			spell.AttackType = catalog.AttackType
+			spell.SavingThrow = catalog.SavingThrow
+			spell.Damage = catalog.Damage
+			spell.Healing = catalog.Healing
+			spell.Area = catalog.Area
+		}
+		if spell.Material == "" {
+			spell.Material = catalog.Material
+		}
+		if spell.ACFormula == nil {
+			spell.ACFormula = catalog.ACFormula
+		}
+	}
+	return &spell
+}
+
+// GetCastLevel returns the slot level the selected spell will be cast at
+func (p *SpellsPanel) GetCastLevel() int {
+	spell := p.GetSelectedSpell()
+	if spell == nil {
+		return 0
+	}
+	if p.castLevel < spell.Level {
+		return spell.Level
+	}
+	return p.castLevel
+}
+
+// RaiseCastLevel upcasts the selected spell by one slot level
+func (p *SpellsPanel) RaiseCastLevel() {
+	spell := p.GetSelectedSpell()
+	if spell == nil || spell.Level == 0 {
+		return
+	}
+	if level := p.GetCastLevel(); level < 9 {
+		p.castLevel = level + 1
+	}
+}
+
+// LowerCastLevel lowers the cast slot level, down to the spell's own level
+func (p *SpellsPanel) LowerCastLevel() {
+	spell := p.GetSelectedSpell()
+	if spell == nil {
+		return
+	}
+	if level := p.GetCastLevel(); level > spell.Level {
+		p.castLevel = level - 1
+	}
+}
+
+// selectedSummary renders the mechanics line for the selected spell
+func (p *SpellsPanel) selectedSummary(spell *models.Spell) string {
+	if spell == nil || !spell.HasMechanics() {
+		return ""
+	}
+	_, saveDC, attackBonus := p.character.SpellcastingFor(spell)
+	stats := &models.SpellBook{SpellSaveDC: saveDC, SpellAttackBonus: attackBonus}
+	summary := spell.MechanicsSummary(p.GetCastLevel(), p.character.Level, stats)
+	if spell.Level > 0 {
+		summary = fmt.Sprintf("[slot %d] %s", p.GetCastLevel(), summary)
+	}
+	return summary
+}
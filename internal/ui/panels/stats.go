@@ -109,20 +109,9 @@ func (p *StatsPanel) View(width, height int) string {
 		score := char.AbilityScores.GetScore(ability)
 		modifier := char.AbilityScores.GetModifier(ability)
 
-		// Check if proficient in this saving throw (from class)
-		isProficient := false
-		abilityName := abilityNames[ability] // Use full name (e.g., "Dexterity")
-		for _, prof := range char.SavingThrowProficiencies {
-			if strings.EqualFold(prof, abilityName) {
-				isProficient = true
-				break
-			}
-		}
-
-		saveBonus := modifier
-		if isProficient {
-			saveBonus += char.ProficiencyBonus
-		}
+		// Proficiency from the class, plus bonuses such as a Ring of Protection
+		isProficient := char.IsSaveProficient(ability)
+		saveBonus := char.SavingThrowBonus(ability)
 
 		modStr := fmt.Sprintf("%+d", modifier)
 		modStyle := modPositiveStyle
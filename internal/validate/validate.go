@@ -0,0 +1,674 @@
+// internal/validate/validate.go
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
+	"github.com/marcozingoni/lazydndplayer/internal/leveling"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// validHitDice are the hit dice a class can use
+var validHitDice = map[int]bool{6: true, 8: true, 10: true, 12: true}
+
+// Data schema-checks every data file under dir and cross-checks the references
+// between them: feats granted by origins, spells named by species, subclasses and
+// feats, items in starting equipment and hit dice across files
+func Data(dir string) []diagnostics.Problem {
+	c := &checker{
+		dir:    dir,
+		spells: map[string]bool{},
+		items:  map[string]bool{},
+		kinds:  map[string]bool{},
+		feats:  map[string]bool{},
+	}
+
+	c.checkSpells()
+	c.checkItems()
+	c.checkFeats()
+	classes := c.checkClasses()
+	c.checkSubclasses(classes)
+	c.checkOrigins()
+	c.checkSpecies("species.json")
+	c.checkSpecies("species_updated.json")
+	c.checkGuide(classes)
+	c.checkSampleCharacter()
+	c.checkShop("shop_example.json")
+
+	diagnostics.Sort(c.problems)
+	return c.problems
+}
+
+// Startup validates the data files and records the problems with the loaders'
+// diagnostics, returning everything reported so far
+func Startup(dir string) []diagnostics.Problem {
+	for _, problem := range Data(dir) {
+		if problem.Severity == diagnostics.SeverityError {
+			diagnostics.Errorf(problem.File, problem.Line, "%s", problem.Message)
+		} else {
+			diagnostics.Warnf(problem.File, problem.Line, "%s", problem.Message)
+		}
+	}
+	return diagnostics.Problems()
+}
+
+// checker accumulates problems and the names later files refer to
+type checker struct {
+	dir      string
+	problems []diagnostics.Problem
+	spells   map[string]bool // Lower-cased names
+	items    map[string]bool
+	kinds    map[string]bool // Item subcategories, e.g. "gaming_set"
+	feats    map[string]bool
+}
+
+// document is a data file that parsed, with the line of every JSON path
+type document struct {
+	file  string
+	lines diagnostics.LineIndex
+}
+
+func (c *checker) errorf(doc *document, path string, format string, args ...any) {
+	c.problems = append(c.problems, diagnostics.Problem{
+		File: doc.file, Line: doc.lines.Line(path), Severity: diagnostics.SeverityError, Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *checker) warnf(doc *document, path string, format string, args ...any) {
+	c.problems = append(c.problems, diagnostics.Problem{
+		File: doc.file, Line: doc.lines.Line(path), Severity: diagnostics.SeverityWarning, Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// load decodes a data file into v and reports fields v doesn't declare. It returns
+// nil if the file is missing or doesn't parse.
+func (c *checker) load(name string, v any) *document {
+	file := filepath.Join(c.dir, name)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			c.problems = append(c.problems, diagnostics.Problem{File: file, Severity: diagnostics.SeverityError, Message: err.Error()})
+		}
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		c.problems = append(c.problems, diagnostics.JSONProblem(file, data, err))
+		return nil
+	}
+
+	doc := &document{file: file, lines: diagnostics.IndexLines(data)}
+	var raw any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if decoder.Decode(&raw) == nil {
+		unknown := map[string][]string{}
+		unknownFields(reflect.TypeOf(v), raw, "", unknown)
+		keys := make([]string, 0, len(unknown))
+		for key := range unknown {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			paths := unknown[key]
+			sort.Slice(paths, func(i, j int) bool { return doc.lines.Line(paths[i]) < doc.lines.Line(paths[j]) })
+			if len(paths) == 1 {
+				c.warnf(doc, paths[0], "unknown field %q is ignored", key)
+			} else {
+				c.warnf(doc, paths[0], "unknown field %q is ignored (%d times)", key, len(paths))
+			}
+		}
+	}
+	return doc
+}
+
+// unknownFields collects the paths of JSON keys the Go type has no field for, by key;
+// they are silently dropped when the file is loaded
+func unknownFields(t reflect.Type, raw any, path string, unknown map[string][]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := raw.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := map[string]reflect.Type{}
+		// Fields of embedded structs are promoted, e.g. the inventory's coins
+		for _, field := range reflect.VisibleFields(t) {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" || !field.IsExported() || (field.Anonymous && name == "") {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			fields[strings.ToLower(name)] = field.Type
+		}
+		for key, value := range object {
+			child := joinPath(path, key)
+			fieldType, ok := fields[strings.ToLower(key)]
+			if !ok {
+				unknown[key] = append(unknown[key], child)
+				continue
+			}
+			unknownFields(fieldType, value, child, unknown)
+		}
+	case reflect.Slice, reflect.Array:
+		if list, ok := raw.([]any); ok {
+			for i, value := range list {
+				unknownFields(t.Elem(), value, fmt.Sprintf("%s[%d]", path, i), unknown)
+			}
+		}
+	case reflect.Map:
+		if object, ok := raw.(map[string]any); ok {
+			for key, value := range object {
+				unknownFields(t.Elem(), value, joinPath(path, key), unknown)
+			}
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// checkName reports an empty name and names already used in the same file
+func (c *checker) checkName(doc *document, path, kind, name string, seen map[string]bool) {
+	if strings.TrimSpace(name) == "" {
+		c.errorf(doc, path, "%s without a name", kind)
+		return
+	}
+	if seen[strings.ToLower(name)] {
+		c.errorf(doc, path+".name", "duplicate %s %q", kind, name)
+	}
+	seen[strings.ToLower(name)] = true
+}
+
+// checkAbility reports an ability name that isn't one of the six
+func (c *checker) checkAbility(doc *document, path, ability string) {
+	if _, ok := models.ParseAbilityType(ability); !ok {
+		c.errorf(doc, path, "unknown ability %q", ability)
+	}
+}
+
+// checkSpellGrants reports granted spells missing from spells.json
+func (c *checker) checkSpellGrants(doc *document, path string, grants []models.SpellGrant) {
+	for i, grant := range grants {
+		c.checkSpellName(doc, fmt.Sprintf("%s[%d].spell", path, i), grant.SpellName)
+		if grant.Ability != "" {
+			c.checkAbility(doc, fmt.Sprintf("%s[%d].ability", path, i), grant.Ability)
+		}
+	}
+}
+
+func (c *checker) checkSpellName(doc *document, path, name string) {
+	if len(c.spells) > 0 && !c.spells[strings.ToLower(name)] {
+		c.errorf(doc, path, "spell %q is not in spells.json", name)
+	}
+}
+
+func (c *checker) checkSpells() {
+	var spells []models.Spell
+	doc := c.load("spells.json", &spells)
+	if doc == nil {
+		return
+	}
+	seen := map[string]bool{}
+	for i, spell := range spells {
+		path := fmt.Sprintf("[%d]", i)
+		c.checkName(doc, path, "spell", spell.Name, seen)
+		if spell.Level < 0 || spell.Level > 9 {
+			c.errorf(doc, path+".level", "spell level %d is outside 0-9", spell.Level)
+		}
+		c.checkACFormula(doc, path+".ac_formula", spell.ACFormula)
+		c.spells[strings.ToLower(spell.Name)] = true
+	}
+}
+
+// checkACFormula reports an AC formula without a base or with an unknown ability
+func (c *checker) checkACFormula(doc *document, path string, formula *models.ACFormula) {
+	if formula == nil {
+		return
+	}
+	if formula.Base <= 0 {
+		c.errorf(doc, path+".base", "AC formula without a base")
+	}
+	for i, ability := range formula.Abilities {
+		c.checkAbility(doc, fmt.Sprintf("%s.abilities[%d]", path, i), ability)
+	}
+}
+
+func (c *checker) checkItems() {
+	var items models.ItemsDatabase
+	doc := c.load("items.json", &items)
+	if doc == nil {
+		return
+	}
+	seen := map[string]bool{}
+	groups := map[string][]models.ItemDefinition{
+		"weapons": items.Weapons, "armor": items.Armor, "adventuring_gear": items.AdventuringGear,
+		"potions": items.Potions, "magic_items": items.MagicItems, "ammunition": items.Ammunition,
+	}
+	for group, list := range groups {
+		for i, item := range list {
+			path := fmt.Sprintf("%s[%d]", group, i)
+			c.checkName(doc, path, "item", item.Name, seen)
+			if item.Weight < 0 || item.PriceGP < 0 {
+				c.errorf(doc, path, "%s has a negative weight or price", item.Name)
+			}
+			c.checkItemEffects(doc, path+".effects", item.Effects)
+			c.checkItemUse(doc, path+".use", item.Use)
+			if item.Container != nil && item.Container.Capacity <= 0 {
+				c.errorf(doc, path+".container.capacity", "container without a capacity")
+			}
+			c.items[strings.ToLower(item.Name)] = true
+			c.kinds[item.Subcategory] = true
+		}
+	}
+	// Packs unpack into catalog items
+	for group, list := range groups {
+		for i, item := range list {
+			for j, content := range item.Contents {
+				if !c.hasItem(content) {
+					c.warnf(doc, fmt.Sprintf("%s[%d].contents[%d]", group, i, j), "pack content %q is not in items.json", content)
+				}
+			}
+		}
+	}
+}
+
+func (c *checker) checkItemUse(doc *document, path string, use *models.ItemUse) {
+	if use == nil {
+		return
+	}
+	if use.Heal == "" && use.Effect == nil && use.Spell == "" && use.Message == "" {
+		c.errorf(doc, path, "use does nothing: set heal, effect, spell or message")
+	}
+	if use.Spell != "" {
+		c.checkSpellName(doc, path+".spell", use.Spell)
+	}
+	if use.SpellLevel < 0 || use.SpellLevel > 9 {
+		c.errorf(doc, path+".spell_level", "spell level must be 0-9")
+	}
+	if use.Effect != nil {
+		c.checkACFormula(doc, path+".effect.ac_formula", use.Effect.ACFormula)
+	}
+}
+
+func (c *checker) checkItemEffects(doc *document, path string, effects *models.ItemEffects) {
+	if effects == nil {
+		return
+	}
+	for name, effect := range effects.Abilities {
+		abilityPath := path + ".abilities." + name
+		c.checkAbility(doc, abilityPath, name)
+		if effect.Set == 0 && effect.Bonus == 0 {
+			c.errorf(doc, abilityPath, "ability effect sets no score and gives no bonus")
+		}
+		if effect.Set > models.EpicAbilityScoreMax || effect.Max > models.EpicAbilityScoreMax {
+			c.errorf(doc, abilityPath, "ability scores can't go above %d", models.EpicAbilityScoreMax)
+		}
+	}
+	if effects.Charges < 0 {
+		c.errorf(doc, path+".charges", "negative charges")
+	}
+	if (effects.Recharge != "" || effects.DestroyOn > 0) && effects.Charges == 0 {
+		c.errorf(doc, path, "recharge or destroy_on without charges")
+	}
+	if effects.DestroyOn < 0 || effects.DestroyOn > 20 {
+		c.errorf(doc, path+".destroy_on", "destroy_on must be a d20 result")
+	}
+}
+
+func (c *checker) checkFeats() {
+	var data models.FeatsData
+	doc := c.load("feats.json", &data)
+	if doc == nil {
+		return
+	}
+	categories := map[string]bool{"Origin": true, "General": true, "Fighting Style": true, models.FeatCategoryEpicBoon: true}
+	seen := map[string]bool{}
+	for i, feat := range data.Feats {
+		path := fmt.Sprintf("feats[%d]", i)
+		c.checkName(doc, path, "feat", feat.Name, seen)
+		c.feats[strings.ToLower(feat.Name)] = true
+		if !categories[feat.Category] {
+			c.errorf(doc, path+".category", "unknown feat category %q", feat.Category)
+		}
+		if feat.Category == models.FeatCategoryEpicBoon && models.FeatLevelPrerequisite(feat) < models.EpicBoonLevel {
+			c.errorf(doc, path+".requires", "Epic Boon %s should require level %d", feat.Name, models.EpicBoonLevel)
+		}
+		hasText := feat.Prerequisite != "" && feat.Prerequisite != "None"
+		if feat.Requires != nil {
+			c.checkRequirement(doc, path+".requires", *feat.Requires)
+		} else if hasText {
+			c.warnf(doc, path+".prerequisite", "prerequisite %q has no \"requires\" and is not checked", feat.Prerequisite)
+		}
+		if increase := feat.AbilityIncreases; increase != nil {
+			if increase.Ability != "" {
+				c.checkAbility(doc, path+".ability_increases.ability", increase.Ability)
+			}
+			for j, ability := range increase.Choices {
+				c.checkAbility(doc, fmt.Sprintf("%s.ability_increases.choices[%d]", path, j), ability)
+			}
+			if increase.Max > models.EpicAbilityScoreMax {
+				c.errorf(doc, path+".ability_increases.max", "ability scores can't exceed %d", models.EpicAbilityScoreMax)
+			}
+		}
+		c.checkSpellGrants(doc, path+".spells", feat.Spells)
+	}
+}
+
+// checkRequirement reports prerequisite nodes that set nothing or several things, and
+// unknown abilities
+func (c *checker) checkRequirement(doc *document, path string, r models.Requirement) {
+	set := 0
+	for _, ok := range []bool{len(r.All) > 0, len(r.Any) > 0, r.Ability != "", r.Level > 0,
+		r.Proficiency != "", r.Spellcasting, r.Feature != "", len(r.Species) > 0} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		c.errorf(doc, path, "a requirement must set exactly one of all, any, ability, level, proficiency, spellcasting, feature or species (got %d)", set)
+	}
+	if r.Ability != "" {
+		c.checkAbility(doc, path+".ability", r.Ability)
+		if r.Score <= 0 {
+			c.errorf(doc, path+".score", "ability requirement without a score")
+		}
+	}
+	for i, child := range r.All {
+		c.checkRequirement(doc, fmt.Sprintf("%s.all[%d]", path, i), child)
+	}
+	for i, child := range r.Any {
+		c.checkRequirement(doc, fmt.Sprintf("%s.any[%d]", path, i), child)
+	}
+}
+
+// classFile is a class loaded from data/classes with where it came from
+type classFile struct {
+	class models.Class
+	doc   *document
+}
+
+func (c *checker) checkClasses() map[string]classFile {
+	classes := map[string]classFile{}
+	files, err := filepath.Glob(filepath.Join(c.dir, "classes", "*.json"))
+	if err != nil {
+		return classes
+	}
+	for _, file := range files {
+		var class models.Class
+		doc := c.load(filepath.Join("classes", filepath.Base(file)), &class)
+		if doc == nil {
+			continue
+		}
+		if class.Name == "" {
+			c.errorf(doc, "", "class without a name")
+			continue
+		}
+		classes[class.Name] = classFile{class: class, doc: doc}
+
+		if !validHitDice[class.HitDie] {
+			c.errorf(doc, "hit_die", "%s hit die d%d should be d6, d8, d10 or d12", class.Name, class.HitDie)
+		}
+		if fallback := leveling.DefaultHitDie(class.Name); fallback != class.HitDie && validHitDice[class.HitDie] {
+			c.errorf(doc, "hit_die", "%s hit die d%d differs from the built-in d%d", class.Name, class.HitDie, fallback)
+		}
+		for i, save := range class.SavingThrows {
+			c.checkAbility(doc, fmt.Sprintf("saving_throws[%d]", i), save)
+		}
+		if class.Spellcasting != nil {
+			c.checkAbility(doc, "spellcasting.ability", class.Spellcasting.Ability)
+		}
+
+		levels := map[int]bool{}
+		for i, entry := range class.LevelProgression {
+			path := fmt.Sprintf("level_progression[%d]", i)
+			if entry.Level < 1 || entry.Level > 20 {
+				c.errorf(doc, path+".level", "level %d is outside 1-20", entry.Level)
+			} else if levels[entry.Level] {
+				c.errorf(doc, path+".level", "level %d is listed twice", entry.Level)
+			}
+			levels[entry.Level] = true
+			for j, feature := range entry.Features {
+				c.checkACFormula(doc, fmt.Sprintf("%s.features[%d].ac_formula", path, j), feature.ACFormula)
+			}
+		}
+		if len(class.LevelProgression) > 0 {
+			for level := 1; level <= 20; level++ {
+				if !levels[level] {
+					c.warnf(doc, "level_progression", "level %d is missing from the progression", level)
+				}
+			}
+		}
+
+		for i, entry := range class.StartingEquipment {
+			c.checkEquipment(doc, fmt.Sprintf("starting_equipment[%d]", i), entry)
+		}
+	}
+	return classes
+}
+
+func (c *checker) checkSubclasses(classes map[string]classFile) {
+	files, err := filepath.Glob(filepath.Join(c.dir, "subclasses", "*.json"))
+	if err != nil {
+		return
+	}
+	seen := map[string]bool{}
+	for _, file := range files {
+		var subclass models.Subclass
+		doc := c.load(filepath.Join("subclasses", filepath.Base(file)), &subclass)
+		if doc == nil {
+			continue
+		}
+		c.checkName(doc, "", "subclass", subclass.Name+" ("+subclass.Class+")", seen)
+		class, ok := classes[subclass.Class]
+		if !ok {
+			c.errorf(doc, "class", "class %q is not in data/classes", subclass.Class)
+		}
+		for i, level := range subclass.Levels {
+			path := fmt.Sprintf("levels[%d]", i)
+			if ok && class.class.GetLevel(level.Level) == nil {
+				c.errorf(doc, path+".level", "%s has no level %d", subclass.Class, level.Level)
+			}
+			for j, spell := range level.Spells {
+				c.checkSpellName(doc, fmt.Sprintf("%s.spells[%d]", path, j), spell)
+			}
+			for j, feature := range level.Features {
+				c.checkACFormula(doc, fmt.Sprintf("%s.features[%d].ac_formula", path, j), feature.ACFormula)
+			}
+		}
+	}
+}
+
+// parameterizedFeat matches feats named with their option, e.g. "Magic Initiate (Cleric)"
+var parameterizedFeat = regexp.MustCompile(`^(.+?) \([^)]*\)$`)
+
+func (c *checker) checkOrigins() {
+	var data models.OriginsData
+	doc := c.load("origins.json", &data)
+	if doc == nil {
+		return
+	}
+	seen := map[string]bool{}
+	for i, origin := range data.Origins {
+		path := fmt.Sprintf("origins[%d]", i)
+		c.checkName(doc, path, "origin", origin.Name, seen)
+		if origin.Feat != "" && len(c.feats) > 0 && !c.feats[strings.ToLower(origin.Feat)] {
+			base := parameterizedFeat.FindStringSubmatch(origin.Feat)
+			if base == nil || !c.feats[strings.ToLower(base[1])] {
+				c.errorf(doc, path+".feat", "feat %q is not in feats.json", origin.Feat)
+			}
+		}
+		if increase := origin.AbilityIncreases; increase != nil {
+			for j, ability := range increase.Choices {
+				c.checkAbility(doc, fmt.Sprintf("%s.ability_increases.choices[%d]", path, j), ability)
+			}
+		}
+		for j, entry := range origin.Equipment {
+			c.checkEquipmentItem(doc, fmt.Sprintf("%s.equipment[%d]", path, j), entry)
+		}
+	}
+}
+
+func (c *checker) checkSpecies(name string) {
+	var data models.SpeciesData
+	doc := c.load(name, &data)
+	if doc == nil {
+		return
+	}
+	seen := map[string]bool{}
+	for i, species := range data.Species {
+		path := fmt.Sprintf("species[%d]", i)
+		c.checkName(doc, path, "species", species.Name, seen)
+		for j, trait := range species.Traits {
+			c.checkSpellGrants(doc, fmt.Sprintf("%s.traits[%d].spells", path, j), trait.Spells)
+			c.checkACFormula(doc, fmt.Sprintf("%s.traits[%d].ac_formula", path, j), trait.ACFormula)
+		}
+		for j, trait := range species.BaseTraits {
+			c.checkSpellGrants(doc, fmt.Sprintf("%s.base_traits[%d].spells", path, j), trait.Spells)
+			c.checkACFormula(doc, fmt.Sprintf("%s.base_traits[%d].ac_formula", path, j), trait.ACFormula)
+		}
+		subtypes := make([]string, 0, len(species.SubtypeTraits))
+		for subtype := range species.SubtypeTraits {
+			subtypes = append(subtypes, subtype)
+		}
+		sort.Strings(subtypes)
+		for _, subtype := range subtypes {
+			for j, trait := range species.SubtypeTraits[subtype] {
+				c.checkSpellGrants(doc, fmt.Sprintf("%s.subtype_traits.%s[%d].spells", path, subtype, j), trait.Spells)
+				c.checkACFormula(doc, fmt.Sprintf("%s.subtype_traits.%s[%d].ac_formula", path, subtype, j), trait.ACFormula)
+			}
+		}
+	}
+}
+
+func (c *checker) checkGuide(classes map[string]classFile) {
+	var guide leveling.LevelingGuide
+	doc := c.load("leveling_guide.json", &guide)
+	if doc == nil {
+		return
+	}
+	names := make([]string, 0, len(guide.Classes))
+	for name := range guide.Classes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := "classes." + name + ".hit_die"
+		class, ok := classes[name]
+		if !ok {
+			c.warnf(doc, "classes."+name, "class %q is not in data/classes", name)
+			continue
+		}
+		if die := guide.Classes[name].HitDie; die != class.class.HitDie {
+			c.errorf(doc, path, "%s hit die d%d differs from d%d in %s", name, die, class.class.HitDie, class.doc.file)
+		}
+	}
+}
+
+func (c *checker) checkSampleCharacter() {
+	var char models.Character
+	c.load("sample_character.json", &char)
+}
+
+// checkShop checks a shop file sells catalog items at sensible prices
+func (c *checker) checkShop(name string) {
+	var shop models.Shop
+	doc := c.load(name, &shop)
+	if doc == nil {
+		return
+	}
+	if shop.SellRate != nil && (*shop.SellRate < 0 || *shop.SellRate > 1) {
+		c.errorf(doc, "sell_rate", "sell_rate must be between 0 and 1")
+	}
+	seen := map[string]bool{}
+	for i, item := range shop.Items {
+		path := fmt.Sprintf("items[%d]", i)
+		c.checkName(doc, path, "shop item", item.Name, seen)
+		if len(c.items) > 0 && !c.items[strings.ToLower(item.Name)] {
+			c.errorf(doc, path+".name", "%q is not in items.json", item.Name)
+		}
+		if item.PriceGP < 0 || (item.Stock != nil && *item.Stock < 0) {
+			c.errorf(doc, path, "%s has a negative price or stock", item.Name)
+		}
+	}
+}
+
+// equipmentCount matches a leading quantity, e.g. "20 Arrows" or "Two Daggers"
+var equipmentCount = regexp.MustCompile(`(?i)^(\d+|two|three|four|five|ten|twenty) `)
+
+// equipmentCoins matches coins given with equipment, e.g. "50 GP"
+var equipmentCoins = regexp.MustCompile(`(?i)^\d+ (cp|sp|ep|gp|pp)$`)
+
+// equipmentQuiver matches "Quiver of 20 Arrows", which is a quiver and the arrows
+var equipmentQuiver = regexp.MustCompile(`(?i)quiver of (\d+) arrows`)
+
+// equipmentWeapon matches a weapon pick such as "Two Martial Weapons"
+var equipmentWeapon = regexp.MustCompile(`(?i)^(simple|martial)( melee| ranged)? weapons?$`)
+
+// checkEquipment warns about class equipment that doesn't name an item in items.json.
+// Entries list alternatives ("Rapier or Shortsword") and bundles ("A, B, and C").
+func (c *checker) checkEquipment(doc *document, path, entry string) {
+	entry = equipmentQuiver.ReplaceAllString(entry, "Quiver, $1 Arrows")
+	replacer := strings.NewReplacer(", or ", ",", ", and ", ",", " or ", ",", " and ", ",")
+	for _, part := range strings.Split(replacer.Replace(entry), ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			c.checkEquipmentItem(doc, path, part)
+		}
+	}
+}
+
+// checkEquipmentItem warns about a single item of equipment that isn't in items.json;
+// generic picks ("any Simple Weapon", "Martial Weapon") and coins are skipped
+func (c *checker) checkEquipmentItem(doc *document, path, name string) {
+	if len(c.items) == 0 || equipmentCoins.MatchString(name) || strings.HasPrefix(strings.ToLower(name), "any ") {
+		return
+	}
+	if equipmentWeapon.MatchString(equipmentCount.ReplaceAllString(name, "")) {
+		return
+	}
+	if !c.hasItem(name) {
+		c.warnf(doc, path, "equipment %q is not in items.json", name)
+	}
+}
+
+// hasItem looks an equipment name up in items.json, ignoring quantities, notes in
+// parentheses, plurals and variants ("Holy Symbol" matches "Holy Symbol (Amulet)").
+// A subcategory ("Gaming Set") and a described item ("Wooden Shield") match too.
+func (c *checker) hasItem(name string) bool {
+	name = strings.ToLower(name)
+	name = equipmentCount.ReplaceAllString(name, "")
+	candidates := []string{name}
+	if base, _, ok := strings.Cut(name, " ("); ok {
+		candidates = append(candidates, base)
+	}
+	for _, candidate := range candidates {
+		for _, form := range []string{candidate, strings.TrimSuffix(candidate, "s"), strings.TrimSuffix(candidate, "es")} {
+			if c.items[form] || c.kinds[strings.ReplaceAll(strings.ReplaceAll(form, "'", ""), " ", "_")] {
+				return true
+			}
+			for item := range c.items {
+				if strings.HasPrefix(item, form+" ") || strings.HasSuffix(item, " "+form) || strings.HasSuffix(form, " "+item) || strings.Contains(item, " "+form+" (") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
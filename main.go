@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
 	"github.com/marcozingoni/lazydndplayer/internal/storage"
 	"github.com/marcozingoni/lazydndplayer/internal/ui"
+	"github.com/marcozingoni/lazydndplayer/internal/validate"
 )
 
 func main() {
@@ -15,8 +17,14 @@ func main() {
 	charFile := flag.String("file", storage.GetDefaultPath(), "Path to character file")
 	importFile := flag.String("import", "", "Import character from file")
 	exportFile := flag.String("export", "", "Export character to file")
+	shopFile := flag.String("shop", storage.GetDefaultShopPath(), "Path to the DM's shop file")
 	flag.Parse()
 
+	// Handle the validate subcommand
+	if flag.Arg(0) == "validate" {
+		os.Exit(runValidate())
+	}
+
 	// Initialize storage
 	store := storage.NewStorage(*charFile)
 
@@ -56,9 +64,28 @@ func main() {
 		return
 	}
 
+	// Check the data files up front; the status bar reports any errors
+	validate.Startup("data")
+
 	// Run the TUI
-	if err := ui.Run(char, store); err != nil {
+	if err := ui.Run(char, store, *shopFile); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// runValidate checks the data files and prints every problem with its file and line.
+// It returns the exit code: 1 if any file has errors.
+func runValidate() int {
+	problems := validate.Data("data")
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+
+	errors := diagnostics.Errors(problems)
+	fmt.Printf("%d error(s), %d warning(s)\n", errors, len(problems)-errors)
+	if errors > 0 {
+		return 1
+	}
+	return 0
+}
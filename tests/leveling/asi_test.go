@@ -0,0 +1,139 @@
+// tests/leveling/asi_test.go
+package leveling_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/leveling"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// TestCanIncreaseAbilityScores_ClassData tests that ASI levels come from each class's level_progression
+func TestCanIncreaseAbilityScores_ClassData(t *testing.T) {
+	if !leveling.CanIncreaseAbilityScores("Rogue", 10) {
+		t.Error("Expected Rogue to gain an ASI at level 10")
+	}
+	if leveling.CanIncreaseAbilityScores("Wizard", 10) {
+		t.Error("Expected no Wizard ASI at level 10")
+	}
+	if !leveling.CanIncreaseAbilityScores("Wizard", 19) {
+		t.Error("Expected a Wizard ASI at level 19")
+	}
+
+	// Fighter's extra ASIs come from fighter.json, not the standard levels
+	if models.GetClassByName("Fighter") == nil {
+		t.Fatal("Fighter class data not loaded")
+	}
+	for _, level := range []int{6, 14} {
+		if !leveling.CanIncreaseAbilityScores("Fighter", level) {
+			t.Errorf("Expected a Fighter ASI at level %d", level)
+		}
+	}
+}
+
+// TestValidateAbilityIncreases_Cap tests that an ASI must spend 2 points without exceeding 20
+func TestValidateAbilityIncreases_Cap(t *testing.T) {
+	scores := models.AbilityScores{Strength: 19, Dexterity: 14, Constitution: 10, Intelligence: 10, Wisdom: 10, Charisma: 10}
+
+	if err := leveling.ValidateAbilityIncreases(scores, map[models.AbilityType]int{models.Strength: 2}); err == nil {
+		t.Error("Expected Strength 19 + 2 to be rejected")
+	}
+	if err := leveling.ValidateAbilityIncreases(scores, map[models.AbilityType]int{models.Strength: 1, models.Dexterity: 1}); err != nil {
+		t.Errorf("Expected +1/+1 to be valid, got %v", err)
+	}
+	if err := leveling.ValidateAbilityIncreases(scores, map[models.AbilityType]int{models.Dexterity: 1}); err == nil {
+		t.Error("Expected a single point to be rejected")
+	}
+}
+
+// TestUndoAbilityScoreImprovement_Feat tests that a feat taken at an ASI is recorded under asi:level-4 and undone with it
+func TestUndoAbilityScoreImprovement_Feat(t *testing.T) {
+	char := newWizard()
+	char.Level = 3
+	char.Experience = 2700
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{}); err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+	if pending := leveling.PendingAbilityScoreImprovements(char); !slices.Equal(pending, []int{4}) {
+		t.Fatalf("Expected the level 4 ASI to be pending, got %v", pending)
+	}
+
+	alert := models.GetFeatByName("Alert")
+	if alert == nil {
+		t.Fatal("Alert feat not found")
+	}
+	models.AddFeatToCharacter(char, alert.Name)
+	models.ApplyFeatBenefits(char, *alert, "")
+	leveling.RecordFeatForASI(char, 4, alert.Name, "")
+
+	if pending := leveling.PendingAbilityScoreImprovements(char); len(pending) != 0 {
+		t.Errorf("Expected no pending ASI after taking a feat, got %v", pending)
+	}
+	if source := leveling.AbilityScoreImprovementSource(4); source.Type != "asi" || source.Name != "level-4" {
+		t.Errorf("Expected source asi:level-4, got %+v", source)
+	}
+
+	if err := leveling.UndoAbilityScoreImprovement(char, 4); err != nil {
+		t.Fatalf("UndoAbilityScoreImprovement failed: %v", err)
+	}
+	if models.HasFeat(char, "Alert") || char.InitiativeBonus != 0 {
+		t.Errorf("Expected Alert and its initiative bonus to be removed, got feats %v and bonus %d", char.Feats, char.InitiativeBonus)
+	}
+	if pending := leveling.PendingAbilityScoreImprovements(char); !slices.Equal(pending, []int{4}) {
+		t.Errorf("Expected the level 4 ASI to be pending again, got %v", pending)
+	}
+}
+
+// TestPerformLevelUp_EpicBoon tests that an Epic Boon can be taken at level 19 and raises a score past 20
+func TestPerformLevelUp_EpicBoon(t *testing.T) {
+	char := newWizard()
+	char.Level = 18
+	char.Experience = 305000
+	char.AbilityScores.Intelligence = 20
+
+	err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{Feat: "Boon of Spell Recall", FeatAbility: "Intelligence"})
+	if err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+
+	if char.AbilityScores.Intelligence != 21 {
+		t.Errorf("Expected Intelligence 21, got %d", char.AbilityScores.Intelligence)
+	}
+	if record := char.GetLevelRecord(19); record == nil || record.Feat != "Boon of Spell Recall" {
+		t.Errorf("Expected the boon in the level 19 record, got %+v", record)
+	}
+	for _, feature := range char.Features.Features {
+		if feature.Name == "Epic Boon" {
+			t.Error("The Epic Boon placeholder should not be granted as a feature")
+		}
+	}
+}
+
+// TestPerformLevelUp_ASIFeatCategory tests that only a General feat replaces an ASI, and an
+// Epic Boon too from level 19
+func TestPerformLevelUp_ASIFeatCategory(t *testing.T) {
+	char := newWizard()
+	char.Level = 3
+	char.Experience = 2700
+
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{Feat: "Crafter"}); err == nil {
+		t.Fatal("Expected the Origin feat Crafter to be refused at the level 4 ASI")
+	}
+	if char.Level != 3 || models.HasFeat(char, "Crafter") {
+		t.Errorf("Expected a refused level up to change nothing, got level %d and feats %v", char.Level, char.Feats)
+	}
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{Feat: "Alert"}); err != nil {
+		t.Errorf("Expected the General feat Alert at the level 4 ASI, got %v", err)
+	}
+
+	if err := leveling.CheckFeatForASI(*models.GetFeatByName("Crafter"), 19); err == nil {
+		t.Error("Expected an Origin feat to be refused at level 19")
+	}
+	if err := leveling.CheckFeatForASI(*models.GetFeatByName("Boon of Spell Recall"), 19); err != nil {
+		t.Errorf("Expected an Epic Boon at level 19, got %v", err)
+	}
+	if err := leveling.CheckFeatForASI(*models.GetFeatByName("Boon of Spell Recall"), 16); err == nil {
+		t.Error("Expected an Epic Boon to be refused below level 19")
+	}
+}
@@ -0,0 +1,217 @@
+// tests/leveling/levelup_test.go
+package leveling_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/leveling"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// newWizard creates a level 1 wizard with the level 1 slots from the class data
+func newWizard() *models.Character {
+	char := models.NewCharacter()
+	models.ApplyClassToCharacter(char, "Wizard")
+	char.SpellBook.Slots = leveling.GetSpellSlotsForLevel("Wizard", 1)
+	char.Experience = 300
+	return char
+}
+
+// TestPerformLevelUp_SubclassAndSlots tests that level 2 grants the subclass feature, HP and slots from data
+func TestPerformLevelUp_SubclassAndSlots(t *testing.T) {
+	char := newWizard()
+	char.SpellBook.Slots.Level1.Current = 1 // One slot spent
+	startHP := char.MaxHP
+
+	err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{
+		HPRoll:   4,
+		Subclass: "School of Evocation",
+	})
+	if err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+
+	if char.Level != 2 || char.MaxHP != startHP+4 {
+		t.Errorf("Expected level 2 with %d HP, got level %d with %d HP", startHP+4, char.Level, char.MaxHP)
+	}
+	if entry := char.GetHPEntry(2); entry == nil || entry.Method != models.HPMethodRolled || entry.Result != 4 {
+		t.Errorf("Expected a rolled 4 in the HP history for level 2, got %+v", entry)
+	}
+	if wizard := char.GetClassLevels("Wizard"); wizard == nil || wizard.Subclass != "School of Evocation" {
+		t.Errorf("Expected subclass to be recorded on the Wizard levels, got %+v", char.Classes)
+	}
+	if slot := char.SpellBook.Slots.Level1; slot.Maximum != 3 || slot.Current != 2 {
+		t.Errorf("Expected 2/3 level 1 slots, got %d/%d", slot.Current, slot.Maximum)
+	}
+
+	found := false
+	for _, feature := range char.Features.Features {
+		if feature.Name == "Arcane Tradition: School of Evocation" && feature.Source == "class: Wizard:2" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected Arcane Tradition feature, got %+v", char.Features.Features)
+	}
+
+	benefits := char.BenefitTracker.GetBenefitsBySource("class", "Wizard:2")
+	if len(benefits) != 2 {
+		t.Errorf("Expected HP and feature benefits to be tracked, got %+v", benefits)
+	}
+}
+
+// TestPerformLevelUp_AbilityIncrease tests the ASI at level 4 and that the placeholder feature is skipped
+func TestPerformLevelUp_AbilityIncrease(t *testing.T) {
+	char := newWizard()
+	char.Level = 3
+	char.Experience = 2700
+	char.AbilityScores.Intelligence = 16
+
+	err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{
+		AbilityIncrease: map[models.AbilityType]int{models.Intelligence: 2},
+	})
+	if err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+
+	if char.AbilityScores.Intelligence != 18 {
+		t.Errorf("Expected Intelligence 18, got %d", char.AbilityScores.Intelligence)
+	}
+	if char.SpellBook.CantripsKnown != 4 {
+		t.Errorf("Expected 4 cantrips known at level 4, got %d", char.SpellBook.CantripsKnown)
+	}
+	for _, feature := range char.Features.Features {
+		if feature.Name == "Ability Score Improvement" {
+			t.Error("The Ability Score Improvement placeholder should not be granted as a feature")
+		}
+	}
+}
+
+// TestPerformLevelUp_BookSpells tests the two spells a Wizard adds to the spellbook each level
+func TestPerformLevelUp_BookSpells(t *testing.T) {
+	char := newWizard()
+
+	err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{BookSpells: []string{"Shield", "Sleep", "Mage Armor"}})
+	if err == nil || char.Level != 1 {
+		t.Fatalf("Expected three spells refused before leveling, got level %d, %v", char.Level, err)
+	}
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{BookSpells: []string{"Misty Step"}}); err == nil {
+		t.Fatal("Expected a level 2 spell refused at Wizard level 2")
+	}
+
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{BookSpells: []string{"Shield", "Sleep"}}); err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+	if !char.SpellBook.InBook("Shield") || !char.SpellBook.InBook("Sleep") {
+		t.Errorf("Expected Shield and Sleep in the spellbook, got %+v", char.SpellBook.Book)
+	}
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{BookSpells: []string{"Misty Step"}}); err != nil {
+		t.Fatalf("Expected level 2 spells at Wizard level 3, got %v", err)
+	}
+
+	models.PrepareSpell(char, "Misty Step")
+	if err := leveling.LevelDown(char); err != nil {
+		t.Fatalf("LevelDown failed: %v", err)
+	}
+	if char.SpellBook.InBook("Misty Step") || char.SpellBook.IsSpellPrepared("Misty Step") || !char.SpellBook.InBook("Shield") {
+		t.Errorf("Expected only the level 3 spell removed, got %+v", char.SpellBook.Book)
+	}
+}
+
+// TestPerformLevelUp_RefusedChoicesChangeNothing tests that an unknown subclass or feat ability
+// is refused before the level is applied
+func TestPerformLevelUp_RefusedChoicesChangeNothing(t *testing.T) {
+	char := newWizard()
+	startHP := char.MaxHP
+
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{Subclass: "School of Nonsense"}); err == nil {
+		t.Error("Expected an unknown Wizard subclass refused")
+	}
+	if char.Level != 1 || char.MaxHP != startHP || len(char.HPHistory) != 1 || char.GetClassLevels("Wizard").Level != 1 {
+		t.Errorf("Expected the character unchanged at level 1, got level %d with %d HP and %+v", char.Level, char.MaxHP, char.Classes)
+	}
+
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{Subclass: "School of Evocation"}); err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{Subclass: "School of Evocation"}); err == nil || char.Level != 2 {
+		t.Errorf("Expected a second subclass refused at level 2, got level %d, %v", char.Level, err)
+	}
+}
+
+// TestPerformLevelUp_DwarvenToughness tests that a Dwarf is fully healed including the
+// extra hit point Dwarven Toughness gains on the new level
+func TestPerformLevelUp_DwarvenToughness(t *testing.T) {
+	char := newWizard()
+	models.ApplySpeciesToCharacter(char, "Dwarf")
+	startHP := char.MaxHP
+
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{HPRoll: 4}); err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+	if char.SpeciesHPBonus != 2 || char.MaxHP != startHP+5 {
+		t.Errorf("Expected 4 HP plus 1 for Dwarven Toughness (%d), got %d with a bonus of %d", startHP+5, char.MaxHP, char.SpeciesHPBonus)
+	}
+	if char.CurrentHP != char.MaxHP {
+		t.Errorf("Expected a full heal on level up, got %d/%d", char.CurrentHP, char.MaxHP)
+	}
+}
+
+// TestPerformLevelUp_SpeciesSpellsUnlock tests that Drow Magic grants Faerie Fire at 3 and
+// Darkness at 5, and that levelling down removes them again
+func TestPerformLevelUp_SpeciesSpellsUnlock(t *testing.T) {
+	char := models.NewCharacter()
+	models.ApplyClassToCharacter(char, "Fighter")
+	models.ApplySpeciesWithSubtype(char, "Elf", "Drow")
+
+	has := func(name string) bool {
+		for _, spell := range char.SpellBook.Spells {
+			if spell.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	hasFreeCast := func(name string) bool {
+		for _, feature := range char.Features.Features {
+			if feature.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	options := map[int]leveling.LevelUpOptions{
+		3: {HPRoll: 5, Subclass: "Champion"},
+		4: {HPRoll: 5, AbilityIncrease: map[models.AbilityType]int{models.Strength: 2}},
+	}
+	want := map[int]string{1: "Dancing Lights", 3: "Faerie Fire", 5: "Darkness"}
+	for char.Level < 5 {
+		next, ok := options[char.Level+1]
+		if !ok {
+			next = leveling.LevelUpOptions{HPRoll: 5}
+		}
+		if err := leveling.PerformLevelUp(char, next); err != nil {
+			t.Fatalf("PerformLevelUp to %d failed: %v", char.Level+1, err)
+		}
+		for level, spell := range want {
+			if has(spell) != (char.Level >= level) {
+				t.Errorf("Level %d: expected %s known from level %d, known is %v", char.Level, spell, level, has(spell))
+			}
+		}
+	}
+	if !hasFreeCast("Darkness") {
+		t.Error("Expected the Darkness free cast at level 5")
+	}
+
+	for char.Level > 2 {
+		if err := leveling.LevelDown(char); err != nil {
+			t.Fatalf("LevelDown failed: %v", err)
+		}
+	}
+	if has("Faerie Fire") || has("Darkness") || !has("Dancing Lights") {
+		t.Errorf("Expected only Dancing Lights at level 2, got %+v", char.SpellBook.Spells)
+	}
+	if hasFreeCast("Darkness") {
+		t.Error("Expected the Darkness free cast removed")
+	}
+}
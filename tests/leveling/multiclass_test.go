@@ -0,0 +1,147 @@
+// tests/leveling/multiclass_test.go
+package leveling_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/leveling"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// TestPerformLevelUp_Multiclass tests prerequisites, restricted proficiencies and the new class level
+func TestPerformLevelUp_Multiclass(t *testing.T) {
+	char := newWizard()
+	char.Level = 3
+	char.Experience = 2700
+	char.AbilityScores.Intelligence = 14
+	char.AbilityScores.Dexterity = 12
+
+	options := leveling.LevelUpOptions{Class: "Rogue", Skills: []string{"Stealth"}}
+	if err := leveling.PerformLevelUp(char, options); err == nil {
+		t.Fatal("Expected Dexterity 12 to fail the Rogue prerequisite")
+	}
+
+	char.AbilityScores.Dexterity = 13
+	if err := leveling.PerformLevelUp(char, options); err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+
+	if char.Level != 4 || char.ClassSummary() != "Wizard 3 / Rogue 1" {
+		t.Errorf("Expected level 4 Wizard 3 / Rogue 1, got level %d %s", char.Level, char.ClassSummary())
+	}
+	if !slices.Contains(char.ArmorProficiencies, "Light") || !slices.Contains(char.ToolProficiencies, "Thieves' Tools") {
+		t.Errorf("Expected light armor and thieves' tools, got %v and %v", char.ArmorProficiencies, char.ToolProficiencies)
+	}
+	if slices.Contains(char.WeaponProficiencies, "Rapiers") || slices.Contains(char.SavingThrowProficiencies, "Dexterity") {
+		t.Error("Multiclassing into Rogue should not grant its weapon or saving throw proficiencies")
+	}
+	if skill := char.Skills.GetSkill(models.Stealth); skill == nil || skill.Proficiency == 0 {
+		t.Error("Expected the chosen Stealth proficiency")
+	}
+	if entry := char.GetHPEntry(4); entry == nil || entry.Class != "Rogue" || entry.Die != 8 || entry.Method != models.HPMethodFixed {
+		t.Errorf("Expected a fixed d8 Rogue HP entry at level 4, got %+v", entry)
+	}
+	if char.ProficiencyBonus != 2 {
+		t.Errorf("Expected proficiency bonus 2 from total level 4, got %d", char.ProficiencyBonus)
+	}
+}
+
+// TestCalculateSpellSlots_MulticlassTable tests that full and half caster levels combine in the multiclass table
+func TestCalculateSpellSlots_MulticlassTable(t *testing.T) {
+	char := models.NewCharacter()
+	char.Class = "Wizard"
+	char.Level = 7
+	char.Classes = []models.ClassLevels{{Class: "Wizard", Level: 3}, {Class: "Paladin", Level: 4}}
+
+	// Caster level 3 + 4/2 = 5
+	slots := models.CalculateSpellSlots(char)
+	if slots[1] != 4 || slots[2] != 3 || slots[3] != 2 || slots[4] != 0 {
+		t.Errorf("Expected 4/3/2 slots for caster level 5, got %v", slots)
+	}
+
+	// Half casters round up: 3 + 3/2 rounded up is also caster level 5
+	char.Level = 6
+	char.Classes = []models.ClassLevels{{Class: "Wizard", Level: 3}, {Class: "Paladin", Level: 3}}
+	if slots := models.CalculateSpellSlots(char); slots[3] != 2 {
+		t.Errorf("Expected Paladin 3 to count 2 caster levels (two level 3 slots), got %v", slots)
+	}
+
+	// Pact Magic slots are a pool of their own: Warlock 2 has two level 1 slots
+	char.Level = 9
+	char.Classes = []models.ClassLevels{{Class: "Wizard", Level: 3}, {Class: "Paladin", Level: 4}, {Class: "Warlock", Level: 2}}
+	if slots := models.CalculateSpellSlots(char); slots[1] != 4 {
+		t.Errorf("Expected 4 level 1 slots without the Pact Magic slots, got %v", slots)
+	}
+	if count, level := models.CalculatePactSlots(char); count != 2 || level != 1 {
+		t.Errorf("Expected two level 1 Pact Magic slots, got %d of level %d", count, level)
+	}
+}
+
+// TestPerformLevelUp_MulticlassSpellsKnown tests that the cantrips and spells known of every
+// spellcasting class add up, and drop again when a class is removed
+func TestPerformLevelUp_MulticlassSpellsKnown(t *testing.T) {
+	char := newWizard()
+	char.AbilityScores.Intelligence = 13
+	char.AbilityScores.Charisma = 13
+
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{Class: "Sorcerer"}); err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+	if char.SpellBook.CantripsKnown != 7 || char.SpellBook.SpellsKnown != 2 {
+		t.Errorf("Expected 3 Wizard + 4 Sorcerer cantrips and 2 spells known, got %d and %d",
+			char.SpellBook.CantripsKnown, char.SpellBook.SpellsKnown)
+	}
+
+	if err := leveling.LevelDown(char); err != nil {
+		t.Fatalf("LevelDown failed: %v", err)
+	}
+	if char.SpellBook.CantripsKnown != 3 || char.SpellBook.SpellsKnown != 0 {
+		t.Errorf("Expected the Wizard's 3 cantrips only, got %d and %d", char.SpellBook.CantripsKnown, char.SpellBook.SpellsKnown)
+	}
+}
+
+// TestShortRest_PactSlots tests that a short rest restores the Pact Magic slots of a Warlock
+// and of a Warlock/Wizard, leaving the Wizard's slots spent
+func TestShortRest_PactSlots(t *testing.T) {
+	warlock := models.NewCharacter()
+	models.ApplyClassToCharacter(warlock, "Warlock")
+	warlock.Experience = 300
+	if err := leveling.PerformLevelUp(warlock, leveling.LevelUpOptions{HPRoll: 4}); err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+	pact := &warlock.SpellBook.PactSlots
+	if pact.Maximum != 2 || pact.Level != 1 || warlock.SpellBook.Slots.Level1.Maximum != 0 {
+		t.Fatalf("Expected two level 1 Pact Magic slots apart from the others, got %+v and %+v", *pact, warlock.SpellBook.Slots.Level1)
+	}
+	warlock.SpellBook.UseSlot(1)
+	warlock.SpellBook.UseSlot(1)
+	warlock.ShortRest()
+	if pact.Current != 2 {
+		t.Errorf("Expected the Pact Magic slots back after a short rest, got %d", pact.Current)
+	}
+
+	char := newWizard()
+	char.AbilityScores.Intelligence = 13
+	char.AbilityScores.Charisma = 13
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{Class: "Warlock"}); err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+	book := &char.SpellBook
+	if book.Slots.Level1.Maximum != 2 || book.PactSlots.Maximum != 1 {
+		t.Fatalf("Expected two Wizard slots and one Pact Magic slot, got %+v and %+v", book.Slots.Level1, book.PactSlots)
+	}
+	for range 3 {
+		if !book.UseSlot(1) {
+			t.Fatal("Expected three level 1 slots to spend")
+		}
+	}
+	char.ShortRest()
+	if book.PactSlots.Current != 1 || book.Slots.Level1.Current != 0 {
+		t.Errorf("Expected only the Pact Magic slot back, got %d Pact and %d Wizard", book.PactSlots.Current, book.Slots.Level1.Current)
+	}
+	char.LongRest()
+	if book.Slots.Level1.Current != 2 {
+		t.Errorf("Expected the Wizard slots back after a long rest, got %d", book.Slots.Level1.Current)
+	}
+}
@@ -0,0 +1,91 @@
+// tests/leveling/respec_test.go
+package leveling_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/leveling"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// newLevel4Wizard levels a wizard to 4 with a School of Evocation at 2 and +2 INT at 4
+func newLevel4Wizard(t *testing.T) *models.Character {
+	char := newWizard()
+	char.AbilityScores.Intelligence = 16
+	char.UpdateDerivedStats()
+	steps := []leveling.LevelUpOptions{
+		{HPRoll: 4, Subclass: "School of Evocation"},
+		{},
+		{AbilityIncrease: map[models.AbilityType]int{models.Intelligence: 2}},
+	}
+	for _, options := range steps {
+		if err := leveling.PerformLevelUp(char, options); err != nil {
+			t.Fatalf("PerformLevelUp failed: %v", err)
+		}
+	}
+	return char
+}
+
+// TestLevelDown_RollsBackLevel tests that lowering levels removes their HP, ASI, subclass and slots
+func TestLevelDown_RollsBackLevel(t *testing.T) {
+	start := newWizard()
+	char := newLevel4Wizard(t)
+
+	if err := leveling.LevelDown(char); err != nil {
+		t.Fatalf("LevelDown failed: %v", err)
+	}
+	if char.Level != 3 || char.AbilityScores.Intelligence != 16 || char.GetLevelRecord(4) != nil {
+		t.Errorf("Expected level 3 with INT 16 and no level 4 record, got level %d INT %d", char.Level, char.AbilityScores.Intelligence)
+	}
+
+	for char.Level > 1 {
+		if err := leveling.LevelDown(char); err != nil {
+			t.Fatalf("LevelDown failed: %v", err)
+		}
+	}
+	if wizard := char.GetClassLevels("Wizard"); wizard.Level != 1 || wizard.Subclass != "" {
+		t.Errorf("Expected Wizard 1 without a subclass, got %+v", wizard)
+	}
+	if hasFeature(char, "Sculpt Spells", "Subclass: School of Evocation") || len(char.Features.Features) != len(start.Features.Features) {
+		t.Errorf("Expected the level 2 features to be removed, got %+v", char.Features.Features)
+	}
+	if char.MaxHP != start.MaxHP || len(char.HPHistory) != 1 {
+		t.Errorf("Expected %d HP from one history entry, got %d from %+v", start.MaxHP, char.MaxHP, char.HPHistory)
+	}
+	if slot := char.SpellBook.Slots.Level1; slot.Maximum != 2 || char.SpellBook.Slots.Level2.Maximum != 0 {
+		t.Errorf("Expected level 1 slots only, got %+v", char.SpellBook.Slots)
+	}
+	if err := leveling.LevelDown(char); err == nil {
+		t.Error("Expected level 1 to be kept")
+	}
+}
+
+// TestRespec_ReplaysLaterChoices tests that a respec returns every later choice and replays them
+func TestRespec_ReplaysLaterChoices(t *testing.T) {
+	char := newLevel4Wizard(t)
+
+	replay, err := leveling.Respec(char, 2)
+	if err != nil {
+		t.Fatalf("Respec failed: %v", err)
+	}
+	if char.Level != 1 || len(replay) != 3 {
+		t.Fatalf("Expected level 1 with 3 levels to replay, got level %d and %d", char.Level, len(replay))
+	}
+	if replay[0].Subclass != "School of Evocation" || replay[2].AbilityIncrease[models.Intelligence] != 2 {
+		t.Errorf("Expected the recorded subclass and ASI, got %+v", replay)
+	}
+
+	replay[0].HPRoll = 6
+	if err := leveling.ReplayLevelUps(char, replay); err != nil {
+		t.Fatalf("ReplayLevelUps failed: %v", err)
+	}
+	if char.Level != 4 || char.AbilityScores.Intelligence != 18 {
+		t.Errorf("Expected level 4 with INT 18, got level %d INT %d", char.Level, char.AbilityScores.Intelligence)
+	}
+	if entry := char.GetHPEntry(2); entry == nil || entry.Result != 6 {
+		t.Errorf("Expected the new level 2 roll of 6, got %+v", entry)
+	}
+	if !hasFeature(char, "Sculpt Spells", "Subclass: School of Evocation") {
+		t.Error("Expected the subclass to be replayed")
+	}
+}
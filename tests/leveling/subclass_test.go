@@ -0,0 +1,82 @@
+// tests/leveling/subclass_test.go
+package leveling_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/leveling"
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// hasFeature checks for a feature by name and source
+func hasFeature(char *models.Character, name, source string) bool {
+	for _, feature := range char.Features.Features {
+		if feature.Name == name && feature.Source == source {
+			return true
+		}
+	}
+	return false
+}
+
+// findSpell returns a spell in the character's spellbook, or nil
+func findSpell(char *models.Character, name string) *models.Spell {
+	for i := range char.SpellBook.Spells {
+		if char.SpellBook.Spells[i].Name == name {
+			return &char.SpellBook.Spells[i]
+		}
+	}
+	return nil
+}
+
+// TestPerformLevelUp_SubclassFeatures tests that catalog subclass features are granted and replace the generic subclass feature
+func TestPerformLevelUp_SubclassFeatures(t *testing.T) {
+	char := newWizard()
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{Subclass: "School of Evocation"}); err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+	if !hasFeature(char, "Sculpt Spells", "Subclass: School of Evocation") {
+		t.Errorf("Expected Sculpt Spells from the subclass, got %+v", char.Features.Features)
+	}
+
+	char.Level = 5
+	char.GetClassLevels("Wizard").Level = 5
+	char.Experience = 14000
+	if err := leveling.PerformLevelUp(char, leveling.LevelUpOptions{}); err != nil {
+		t.Fatalf("PerformLevelUp failed: %v", err)
+	}
+	if !hasFeature(char, "Potent Cantrip", "Subclass: School of Evocation") {
+		t.Errorf("Expected Potent Cantrip at Wizard 6, got %+v", char.Features.Features)
+	}
+	if hasFeature(char, "Arcane Tradition Feature", "class: Wizard:6") {
+		t.Error("Expected the generic Arcane Tradition Feature to be replaced by the subclass feature")
+	}
+}
+
+// TestChooseSubclass_SpellsAndProficiencies tests that a level 1 subclass grants always-prepared spells and proficiencies, removed on a class change
+func TestChooseSubclass_SpellsAndProficiencies(t *testing.T) {
+	char := models.NewCharacter()
+	models.ApplyClassToCharacter(char, "Cleric")
+	if pending := models.PendingSubclasses(char); !slices.Equal(pending, []string{"Cleric"}) {
+		t.Fatalf("Expected a pending Cleric subclass, got %v", pending)
+	}
+
+	if err := models.ChooseSubclass(char, "Cleric", "Life Domain"); err != nil {
+		t.Fatalf("ChooseSubclass failed: %v", err)
+	}
+	if !slices.Contains(char.ArmorProficiencies, "Heavy") {
+		t.Errorf("Expected heavy armor proficiency, got %v", char.ArmorProficiencies)
+	}
+	bless := findSpell(char, "Bless")
+	if bless == nil || !bless.Prepared {
+		t.Error("Expected Bless to be always prepared")
+	}
+	if !hasFeature(char, "Disciple of Life", "Subclass: Life Domain") {
+		t.Errorf("Expected Disciple of Life, got %+v", char.Features.Features)
+	}
+
+	models.ApplyClassToCharacter(char, "Wizard")
+	if findSpell(char, "Bless") != nil || hasFeature(char, "Disciple of Life", "Subclass: Life Domain") {
+		t.Error("Expected the Life Domain grants to be removed with the class")
+	}
+}
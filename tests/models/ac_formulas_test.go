@@ -0,0 +1,79 @@
+// tests/models/ac_formulas_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// newUnarmoredCharacter creates a level 1 character of a class with Dex 14, Con 16 and Wis 16
+func newUnarmoredCharacter(className string) *models.Character {
+	char := models.NewCharacter()
+	char.Class = className
+	char.AbilityScores.Dexterity = 14
+	char.AbilityScores.Constitution = 16
+	char.AbilityScores.Wisdom = 16
+	char.UpdateDerivedStats()
+	return char
+}
+
+// TestACFormulas_UnarmoredDefense tests the Barbarian and Monk formulas and their shield rules
+func TestACFormulas_UnarmoredDefense(t *testing.T) {
+	barbarian := newUnarmoredCharacter("Barbarian")
+	if barbarian.AC != 15 {
+		t.Errorf("Expected Barbarian AC 10 + 2 + 3 = 15, got %d", barbarian.AC)
+	}
+	barbarian.Inventory.AddItem(models.Item{Name: "Shield", Type: models.Armor, Quantity: 1, Equipped: true})
+	barbarian.UpdateDerivedStats()
+	if barbarian.AC != 17 {
+		t.Errorf("Expected a Barbarian to keep Unarmored Defense with a shield (17), got %d", barbarian.AC)
+	}
+
+	monk := newUnarmoredCharacter("Monk")
+	if monk.AC != 15 {
+		t.Errorf("Expected Monk AC 10 + 2 + 3 = 15, got %d", monk.AC)
+	}
+	monk.Inventory.AddItem(models.Item{Name: "Shield", Type: models.Armor, Quantity: 1, Equipped: true})
+	monk.UpdateDerivedStats()
+	if monk.AC != 14 {
+		t.Errorf("Expected a Monk with a shield to fall back to 10 + 2 + 2 = 14, got %d", monk.AC)
+	}
+}
+
+// TestACFormulas_HighestApplies tests that Mage Armor only applies when it beats other formulas and armor
+func TestACFormulas_HighestApplies(t *testing.T) {
+	char := newUnarmoredCharacter("Wizard")
+	mageArmor := models.GetSpellByName("Mage Armor")
+	if mageArmor == nil || mageArmor.ACFormula == nil {
+		t.Fatal("Mage Armor with an AC formula not found")
+	}
+
+	char.AddEffect(models.Effect{Name: "Mage Armor", Source: "Spell: Mage Armor", ACFormula: mageArmor.ACFormula})
+	if char.AC != 15 {
+		t.Errorf("Expected Mage Armor AC 13 + 2 = 15, got %d", char.AC)
+	}
+
+	// Armor replaces every unarmored formula
+	char.Inventory.AddItem(models.Item{Name: "Leather Armor", Type: models.Armor, Quantity: 1, Equipped: true})
+	char.UpdateDerivedStats()
+	if char.AC != 13 {
+		t.Errorf("Expected Leather Armor AC 11 + 2 = 13, got %d", char.AC)
+	}
+
+	// A Barbarian's Unarmored Defense (16) beats Mage Armor (15)
+	barbarian := newUnarmoredCharacter("Barbarian")
+	barbarian.AbilityScores.Constitution = 18
+	barbarian.AddEffect(models.Effect{Name: "Mage Armor", ACFormula: mageArmor.ACFormula})
+	breakdown := barbarian.Breakdown(models.TargetAC)
+	if breakdown.Total != 16 {
+		t.Errorf("Expected the highest formula (16) to apply, got %d", breakdown.Total)
+	}
+
+	// Long rest ends the spell
+	char.Inventory.Items = nil
+	char.LongRest()
+	if len(char.Effects) != 0 || char.AC != 12 {
+		t.Errorf("Expected Mage Armor to end on a long rest (AC 12), got %d with %d effects", char.AC, len(char.Effects))
+	}
+}
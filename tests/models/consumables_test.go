@@ -0,0 +1,95 @@
+// tests/models/consumables_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// addCatalogItem adds items from the catalog to the inventory
+func addCatalogItem(t *testing.T, char *models.Character, name string, quantity int) {
+	t.Helper()
+	def := models.GetItemDefinitionByName(name)
+	if def == nil {
+		t.Fatalf("%s not found in items.json", name)
+	}
+	char.Inventory.AddItem(models.ConvertToInventoryItem(*def, quantity))
+}
+
+// TestUseItem_PotionOfHealing tests that a healing potion heals the rolled amount and is used up
+func TestUseItem_PotionOfHealing(t *testing.T) {
+	char := models.NewCharacter()
+	char.MaxHP = 20
+	char.CurrentHP = 5
+	addCatalogItem(t, char, "Potion of Healing", 2)
+	potion := &char.Inventory.Items[0]
+
+	if use := models.ItemUseOf(potion); use == nil || use.Heal != "2d4+2" {
+		t.Fatalf("Expected a Potion of Healing to heal 2d4+2, got %+v", use)
+	}
+	if _, err := char.UseItem(potion, 7); err != nil {
+		t.Fatalf("UseItem failed: %v", err)
+	}
+	if char.CurrentHP != 12 || char.Inventory.Items[0].Quantity != 1 {
+		t.Errorf("Expected 12 HP and 1 potion left, got %d HP and %d potions", char.CurrentHP, char.Inventory.Items[0].Quantity)
+	}
+
+	// Healing stops at max HP and the last potion leaves the inventory
+	if _, err := char.UseItem(&char.Inventory.Items[0], 10); err != nil {
+		t.Fatalf("UseItem failed: %v", err)
+	}
+	if char.CurrentHP != 20 || len(char.Inventory.Items) != 0 {
+		t.Errorf("Expected 20 HP and no potions left, got %d HP and %d items", char.CurrentHP, len(char.Inventory.Items))
+	}
+}
+
+// TestUseItem_TemporaryResistance tests that a potion's resistance lasts until its effect ends
+func TestUseItem_TemporaryResistance(t *testing.T) {
+	char := models.NewCharacter()
+	addCatalogItem(t, char, "Potion of Fire Resistance", 1)
+
+	if _, err := char.UseItem(&char.Inventory.Items[0], 0); err != nil {
+		t.Fatalf("UseItem failed: %v", err)
+	}
+	if len(char.Effects) != 1 || char.Effects[0].Duration != "1 hour" {
+		t.Fatalf("Expected a 1 hour effect, got %+v", char.Effects)
+	}
+	if len(char.Resistances) != 1 || char.Resistances[0] != "Fire" {
+		t.Errorf("Expected fire resistance while the potion lasts, got %v", char.Resistances)
+	}
+
+	char.LongRest()
+	if len(char.Effects) != 0 || len(char.Resistances) != 0 {
+		t.Errorf("Expected the effect and resistance to end, got %v and %v", char.Effects, char.Resistances)
+	}
+}
+
+// TestUseItem_ScrollsAndRations tests that scrolls and rations are used up and other items can't be used
+func TestUseItem_ScrollsAndRations(t *testing.T) {
+	char := models.NewCharacter()
+	addCatalogItem(t, char, "Spell Scroll (Fireball)", 1)
+	addCatalogItem(t, char, "Rations (1 day)", 3)
+	addCatalogItem(t, char, "Bag of Holding", 1)
+
+	scroll := models.ItemUseOf(&char.Inventory.Items[0])
+	if scroll == nil || scroll.Spell != "Fireball" {
+		t.Fatalf("Expected the scroll to cast Fireball, got %+v", scroll)
+	}
+	if dc, attack := models.ScrollSpellStats(3); dc != 15 || attack != 7 {
+		t.Errorf("Expected a level 3 scroll to have DC 15 and +7, got %d and %+d", dc, attack)
+	}
+	if _, err := char.UseItem(&char.Inventory.Items[0], 0); err != nil {
+		t.Fatalf("UseItem failed: %v", err)
+	}
+	if char.Inventory.Items[0].Name != "Rations (1 day)" {
+		t.Fatalf("Expected the scroll to crumble, got %s first", char.Inventory.Items[0].Name)
+	}
+
+	if _, err := char.UseItem(&char.Inventory.Items[0], 0); err != nil || char.Inventory.Items[0].Quantity != 2 {
+		t.Errorf("Expected to eat one ration, got err=%v and %d left", err, char.Inventory.Items[0].Quantity)
+	}
+	if _, err := char.UseItem(&char.Inventory.Items[1], 0); err == nil {
+		t.Error("Expected a Bag of Holding not to be usable")
+	}
+}
@@ -0,0 +1,97 @@
+// tests/models/containers_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// findItem returns the first inventory item with a name
+func findItem(t *testing.T, inv *models.Inventory, name string) *models.Item {
+	t.Helper()
+	for i := range inv.Items {
+		if inv.Items[i].Name == name {
+			return &inv.Items[i]
+		}
+	}
+	t.Fatalf("%s not in the inventory", name)
+	return nil
+}
+
+// TestContainers_CapacityAndWeight tests moving items into a backpack and its capacity
+func TestContainers_CapacityAndWeight(t *testing.T) {
+	char := models.NewCharacter()
+	addCatalogItem(t, char, "Backpack", 2)
+	addCatalogItem(t, char, "Rope, Hempen (50 ft)", 2)
+	addCatalogItem(t, char, "Torch", 5)
+	inv := &char.Inventory
+
+	if len(inv.Items) != 4 || inv.Items[0].ID == 0 || inv.Items[0].ID == inv.Items[1].ID {
+		t.Fatalf("Expected two backpacks with their own IDs, got %+v", inv.Items)
+	}
+	weight := inv.TotalWeight()
+
+	backpack := &inv.Items[0]
+	if err := inv.MoveToContainer(findItem(t, inv, "Rope, Hempen (50 ft)"), backpack); err != nil {
+		t.Fatalf("MoveToContainer failed: %v", err)
+	}
+	if inv.ContentsWeight(backpack) != 20 || inv.TotalWeight() != weight {
+		t.Errorf("Expected 20 lbs in the backpack and an unchanged total, got %.1f and %.1f",
+			inv.ContentsWeight(backpack), inv.TotalWeight())
+	}
+
+	// 20 lbs of rope, 5 lbs of torches and the empty second backpack fill the 30 lbs
+	if err := inv.MoveToContainer(findItem(t, inv, "Torch"), backpack); err != nil {
+		t.Fatalf("MoveToContainer failed: %v", err)
+	}
+	if err := inv.MoveToContainer(&inv.Items[1], backpack); err != nil {
+		t.Fatalf("MoveToContainer failed: %v", err)
+	}
+	addCatalogItem(t, char, "Crowbar", 1)
+	if err := inv.MoveToContainer(findItem(t, inv, "Crowbar"), &inv.Items[0]); err == nil {
+		t.Error("Expected a full backpack to refuse a crowbar")
+	}
+
+	// Taking an item out stacks it with the same item outside
+	addCatalogItem(t, char, "Torch", 1)
+	if err := inv.MoveToContainer(findItem(t, inv, "Torch"), nil); err != nil {
+		t.Fatalf("MoveToContainer failed: %v", err)
+	}
+	if torch := findItem(t, inv, "Torch"); torch.Quantity != 6 || torch.ContainerID != 0 {
+		t.Errorf("Expected 6 torches carried directly, got %d in container %d", torch.Quantity, torch.ContainerID)
+	}
+}
+
+// TestContainers_BagOfHolding tests weightless contents, nesting and removing a container
+func TestContainers_BagOfHolding(t *testing.T) {
+	char := models.NewCharacter()
+	addCatalogItem(t, char, "Bag of Holding", 1)
+	addCatalogItem(t, char, "Backpack", 1)
+	addCatalogItem(t, char, "Chest", 1)
+	inv := &char.Inventory
+	bag, backpack, chest := &inv.Items[0], &inv.Items[1], &inv.Items[2]
+
+	if err := inv.MoveToContainer(chest, bag); err != nil {
+		t.Fatalf("MoveToContainer failed: %v", err)
+	}
+	if err := inv.MoveToContainer(backpack, chest); err != nil {
+		t.Fatalf("MoveToContainer failed: %v", err)
+	}
+	if inv.TotalWeight() != 15 {
+		t.Errorf("Expected only the bag's own 15 lbs to count, got %.1f", inv.TotalWeight())
+	}
+	if inv.ContentsWeight(bag) != 30 {
+		t.Errorf("Expected 30 lbs inside the bag, got %.1f", inv.ContentsWeight(bag))
+	}
+	if err := inv.MoveToContainer(bag, backpack); err == nil {
+		t.Error("Expected a container not to fit inside its own contents")
+	}
+
+	// The backpack stays in the bag when the chest holding it is removed
+	inv.RemoveAt(2)
+	backpack = findItem(t, inv, "Backpack")
+	if inv.ContainerOf(backpack) == nil || inv.ContainerOf(backpack).Name != "Bag of Holding" {
+		t.Error("Expected the chest's contents to be left in the bag")
+	}
+}
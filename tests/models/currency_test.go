@@ -0,0 +1,108 @@
+// tests/models/currency_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// TestParseCoins tests reading amounts typed into the purse
+func TestParseCoins(t *testing.T) {
+	tests := []struct {
+		text string
+		want models.Coins
+	}{
+		{"15 gp", models.Coins{Gold: 15}},
+		{"2gp 5sp", models.Coins{Gold: 2, Silver: 5}},
+		{"3 pp, 10 ep, 7 cp", models.Coins{Platinum: 3, Electrum: 10, Copper: 7}},
+		{"40", models.Coins{Gold: 40}},
+	}
+	for _, tt := range tests {
+		got, err := models.ParseCoins(tt.text)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseCoins(%q) = %+v, %v; expected %+v", tt.text, got, err, tt.want)
+		}
+	}
+
+	for _, text := range []string{"", "lots", "5 gold coins"} {
+		if _, err := models.ParseCoins(text); err == nil {
+			t.Errorf("Expected an error parsing %q", text)
+		}
+	}
+}
+
+// TestPay_MakesChange tests paying with exact coins, breaking larger coins for change
+// and refusing when the purse is short
+func TestPay_MakesChange(t *testing.T) {
+	inv := &models.Inventory{Coins: models.Coins{Platinum: 1, Gold: 2, Electrum: 1, Silver: 3}}
+
+	// 2 gp 3 sp is paid exactly
+	if err := inv.Pay(230, "Rope"); err != nil {
+		t.Fatalf("Pay failed: %v", err)
+	}
+	if inv.Coins != (models.Coins{Platinum: 1, Electrum: 1}) {
+		t.Errorf("Expected 1 pp 1 ep left, got %s", inv.Coins)
+	}
+
+	// 7 sp: the electrum pays 5 sp and the platinum is broken for the rest
+	if err := inv.Pay(70, "Ale"); err != nil {
+		t.Fatalf("Pay failed: %v", err)
+	}
+	if inv.Coins != (models.Coins{Gold: 9, Silver: 8}) {
+		t.Errorf("Expected the platinum broken and 9 gp 8 sp left, got %s", inv.Coins)
+	}
+
+	before := inv.Coins
+	if err := inv.Pay(5000, "Plate armor"); err == nil {
+		t.Error("Expected an error paying more than the purse holds")
+	}
+	if inv.Coins != before || len(inv.Ledger) != 2 {
+		t.Errorf("Expected nothing paid or recorded, got %s and %d entries", inv.Coins, len(inv.Ledger))
+	}
+	if inv.Ledger[1].Amount != -70 || inv.Ledger[1].Reason != "Ale" || inv.Ledger[1].Date == "" {
+		t.Errorf("Expected the ale recorded as a dated 7 sp expense, got %+v", inv.Ledger[1])
+	}
+}
+
+// TestCoins_WeightCountsTowardEncumbrance tests that 50 coins weigh a pound
+func TestCoins_WeightCountsTowardEncumbrance(t *testing.T) {
+	inv := &models.Inventory{}
+	if err := inv.Receive(models.Coins{Gold: 80, Silver: 20}, "Goblin cave"); err != nil {
+		t.Fatalf("Receive failed: %v", err)
+	}
+	if inv.TotalWeight() != 2 {
+		t.Errorf("Expected 100 coins to weigh 2 lbs, got %.2f", inv.TotalWeight())
+	}
+	if len(inv.Ledger) != 1 || inv.Ledger[0].Amount != 8200 {
+		t.Errorf("Expected an 82 gp income entry, got %+v", inv.Ledger)
+	}
+	if err := inv.Receive(models.Coins{}, "Nothing"); err == nil {
+		t.Error("Expected an error receiving no coins")
+	}
+	if err := inv.Receive(models.Coins{Gold: 1}, " "); err == nil {
+		t.Error("Expected an error receiving coins without a reason")
+	}
+}
+
+// TestSplitLoot tests that each party member's share is paid out and recorded
+func TestSplitLoot(t *testing.T) {
+	inv := &models.Inventory{}
+	share, err := inv.SplitLoot(models.Coins{Gold: 100, Copper: 2}, 4, "Dragon hoard")
+	if err != nil {
+		t.Fatalf("SplitLoot failed: %v", err)
+	}
+	if share != 2500 {
+		t.Errorf("Expected a 25 gp share, got %s", models.FormatCopper(share))
+	}
+	// The copper that doesn't divide evenly stays with the character
+	if inv.Coins.Value() != 2502 {
+		t.Errorf("Expected 25 gp 2 cp kept, got %s", inv.Coins)
+	}
+	if len(inv.Ledger) != 4 || inv.Ledger[0].Amount != 10002 || inv.Ledger[3].Reason != "Dragon hoard: share 4/4" {
+		t.Errorf("Expected the hoard and three shares recorded, got %+v", inv.Ledger)
+	}
+	if _, err := inv.SplitLoot(models.Coins{Gold: 10}, 0, "Chest"); err == nil {
+		t.Error("Expected an error splitting among no one")
+	}
+}
@@ -0,0 +1,64 @@
+// tests/models/experience_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// TestAwardXP_Ledger tests that XP awards are recorded with their reason and unlock the next level
+func TestAwardXP_Ledger(t *testing.T) {
+	char := models.NewCharacter()
+	char.Level = 1
+
+	if err := char.AwardXP(200, "Goblin ambush"); err != nil {
+		t.Fatalf("AwardXP failed: %v", err)
+	}
+	if char.CanLevelUp() {
+		t.Error("Expected 200 XP to be short of level 2")
+	}
+	if err := char.AwardXP(150, ""); err == nil {
+		t.Error("Expected an award without a reason to be rejected")
+	}
+	if err := char.AwardXP(150, "Rescued the miller"); err != nil {
+		t.Fatalf("AwardXP failed: %v", err)
+	}
+
+	if !char.CanLevelUp() || char.Experience != 350 {
+		t.Errorf("Expected level 2 available at 350 XP, got %d XP", char.Experience)
+	}
+	if len(char.XPLog) != 2 || char.XPLog[1].Reason != "Rescued the miller" || char.XPLog[1].Amount != 150 {
+		t.Errorf("Expected two ledger entries, got %+v", char.XPLog)
+	}
+	if earned, needed := char.XPProgress(); earned != 350 || needed != 300 {
+		t.Errorf("Expected progress 350/300, got %d/%d", earned, needed)
+	}
+}
+
+// TestGrantMilestone tests that milestone levelling ignores XP and lets the DM grant one level at a time
+func TestGrantMilestone(t *testing.T) {
+	char := models.NewCharacter()
+	char.Level = 3
+	char.Experience = 5000
+	char.SetMilestoneLeveling(true)
+
+	if char.CanLevelUp() {
+		t.Error("Expected XP to be ignored in milestone mode")
+	}
+	if err := char.AwardXP(100, "Dragon"); err == nil {
+		t.Error("Expected XP awards to be rejected in milestone mode")
+	}
+	if err := char.GrantMilestone("Cleared the crypt"); err != nil {
+		t.Fatalf("GrantMilestone failed: %v", err)
+	}
+	if !char.CanLevelUp() {
+		t.Error("Expected level 4 to be available after a milestone")
+	}
+	if err := char.GrantMilestone("Again"); err == nil {
+		t.Error("Expected a second milestone to wait until level 4 is taken")
+	}
+	if entry := char.XPLog[len(char.XPLog)-1]; !entry.Milestone || entry.Reason != "Cleared the crypt" {
+		t.Errorf("Expected the milestone in the ledger, got %+v", entry)
+	}
+}
@@ -2,6 +2,7 @@
 package models_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/marcozingoni/lazydndplayer/internal/models"
@@ -433,3 +434,164 @@ func TestAbilityScoreMin(t *testing.T) {
 		t.Errorf("Charisma should not go below 1, got %d", char.AbilityScores.Charisma)
 	}
 }
+
+// TestCanTakeFeat_LevelPrerequisite tests that Epic Boons require level 19
+func TestCanTakeFeat_LevelPrerequisite(t *testing.T) {
+	boon := models.GetFeatByName("Boon of Fortitude")
+	if boon == nil {
+		t.Fatal("Boon of Fortitude not found")
+	}
+	if boon.Category != models.FeatCategoryEpicBoon || models.FeatLevelPrerequisite(*boon) != 19 {
+		t.Errorf("Expected an Epic Boon requiring level 19, got %s requiring %d", boon.Category, models.FeatLevelPrerequisite(*boon))
+	}
+
+	char := models.NewCharacter()
+	char.Level = 18
+	if models.CanTakeFeat(char, *boon) {
+		t.Error("Expected level 18 to be rejected")
+	}
+	if !models.CanTakeFeatAtLevel(char, *boon, 19) {
+		t.Error("Expected the boon to be allowed when levelling up to 19")
+	}
+}
+
+// TestApplyFeatBenefits_EpicBoonBonuses tests the hit point and speed increases declared in the feat data
+func TestApplyFeatBenefits_EpicBoonBonuses(t *testing.T) {
+	char := models.NewCharacter()
+	models.ApplyClassToCharacter(char, "Fighter")
+	startHP, startSpeed := char.MaxHP, char.Speed
+
+	fortitude := models.GetFeatByName("Boon of Fortitude")
+	speed := models.GetFeatByName("Boon of Speed")
+	if fortitude == nil || speed == nil || fortitude.HPBonus != 40 || speed.SpeedBonus != 30 {
+		t.Fatalf("Expected hp_bonus 40 and speed_bonus 30 in the feat data, got %+v and %+v", fortitude, speed)
+	}
+	models.ApplyFeatBenefits(char, *fortitude, "Constitution")
+	models.ApplyFeatBenefits(char, *speed, "Dexterity")
+	if char.MaxHP != startHP+40 || char.Speed != startSpeed+30 {
+		t.Errorf("Expected %d HP and speed %d, got %d and %d", startHP+40, startSpeed+30, char.MaxHP, char.Speed)
+	}
+
+	models.RemoveFeatBenefits(char, *fortitude)
+	models.RemoveFeatBenefits(char, *speed)
+	if char.MaxHP != startHP || char.Speed != startSpeed {
+		t.Errorf("Expected %d HP and speed %d back, got %d and %d", startHP, startSpeed, char.MaxHP, char.Speed)
+	}
+}
+
+// TestApplyFeatBenefits_GrantsBonus tests that the flat bonuses declared in the feat data are applied and removed
+func TestApplyFeatBenefits_GrantsBonus(t *testing.T) {
+	char := models.NewCharacter()
+	observant := models.GetFeatByName("Observant")
+	if observant == nil || observant.GrantsBonus == nil || observant.GrantsBonus.PassivePerception != 5 {
+		t.Fatalf("Expected Observant to declare +5 passive Perception, got %+v", observant)
+	}
+
+	models.ApplyFeatBenefits(char, *observant, "Wisdom")
+	if char.PassivePerceptionBonus != 5 || char.PassiveInvestigationBonus != 5 {
+		t.Errorf("Expected +5 passive Perception and Investigation, got %d and %d", char.PassivePerceptionBonus, char.PassiveInvestigationBonus)
+	}
+
+	models.RemoveFeatBenefits(char, *observant)
+	if char.PassivePerceptionBonus != 0 || char.PassiveInvestigationBonus != 0 {
+		t.Errorf("Expected the passive bonuses removed, got %d and %d", char.PassivePerceptionBonus, char.PassiveInvestigationBonus)
+	}
+}
+
+// TestAbilityScoreMax_EpicBoon tests that a boon raises a score up to its own maximum and is removed exactly
+func TestAbilityScoreMax_EpicBoon(t *testing.T) {
+	char := models.NewCharacter()
+	char.BenefitTracker = models.NewBenefitTracker()
+	char.AbilityScores.Charisma = 20
+
+	boon := models.Feat{
+		Name: "Boon of Truesight",
+		AbilityIncreases: &models.FeatAbilityIncrease{
+			Choices: []string{"Charisma"},
+			Amount:  1,
+			Max:     30,
+		},
+	}
+	actor := models.Feat{
+		Name: "Actor",
+		AbilityIncreases: &models.FeatAbilityIncrease{
+			Ability: "Charisma",
+			Amount:  1,
+		},
+	}
+
+	models.ApplyFeatBenefits(char, boon, "Charisma")
+	if char.AbilityScores.Charisma != 21 {
+		t.Fatalf("Expected Charisma 21, got %d", char.AbilityScores.Charisma)
+	}
+
+	// A feat capped at 20 leaves the higher score alone and gives nothing back on removal
+	models.ApplyFeatBenefits(char, actor, "")
+	models.RemoveFeatBenefits(char, actor)
+	if char.AbilityScores.Charisma != 21 {
+		t.Errorf("Expected Charisma to stay 21, got %d", char.AbilityScores.Charisma)
+	}
+
+	models.RemoveFeatBenefits(char, boon)
+	if char.AbilityScores.Charisma != 20 {
+		t.Errorf("Expected Charisma 20 after removing the boon, got %d", char.AbilityScores.Charisma)
+	}
+}
+
+// TestUnmetFeatPrerequisites_AnyOf tests that an "any of" requirement is met by either ability
+func TestUnmetFeatPrerequisites_AnyOf(t *testing.T) {
+	feat := models.GetFeatByName("Ritual Caster")
+	if feat == nil || feat.Requires == nil {
+		t.Fatal("Ritual Caster with structured prerequisites not found")
+	}
+
+	char := models.NewCharacter()
+	char.AbilityScores.Intelligence = 10
+	char.AbilityScores.Wisdom = 10
+	unmet := models.UnmetFeatPrerequisites(char, *feat, char.Level)
+	if len(unmet) != 1 || unmet[0] != "Intelligence 13+ or Wisdom 13+" {
+		t.Errorf("Expected the any-of requirement to be reported, got %v", unmet)
+	}
+
+	char.AbilityScores.Wisdom = 13
+	if !models.CanTakeFeat(char, *feat) {
+		t.Error("Expected Wisdom 13 to meet the prerequisite")
+	}
+}
+
+// TestUnmetFeatPrerequisites_ExplainsFailures tests that each failed requirement is described
+func TestUnmetFeatPrerequisites_ExplainsFailures(t *testing.T) {
+	feat := models.Feat{
+		Name: "Test Feat",
+		Requires: &models.Requirement{All: []models.Requirement{
+			{Ability: "Strength", Score: 13},
+			{Level: 4},
+			{Proficiency: "Heavy Armor"},
+			{Feature: "Fighting Style"},
+			{Species: []string{"Dwarf", "Orc"}},
+		}},
+	}
+
+	char := models.NewCharacter()
+	char.Race = "Human"
+	char.AbilityScores.Strength = 10
+	unmet := models.UnmetFeatPrerequisites(char, feat, 1)
+	expected := []string{
+		"Strength 13+ (you have 10)",
+		"Level 4+ (you are level 1)",
+		"Proficiency with Heavy Armor",
+		"Fighting Style feature",
+		"Species: Dwarf or Orc (you are Human)",
+	}
+	if strings.Join(unmet, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %v, got %v", expected, unmet)
+	}
+
+	char.AbilityScores.Strength = 15
+	char.ArmorProficiencies = []string{"Light", "Medium", "Heavy", "Shields"}
+	char.Features.AddFeature(models.Feature{Name: "Fighting Style: Defense"})
+	char.Race = "Dwarf"
+	if !models.CanTakeFeatAtLevel(char, feat, 4) {
+		t.Errorf("Expected every requirement to be met, got %v", models.UnmetFeatPrerequisites(char, feat, 4))
+	}
+}
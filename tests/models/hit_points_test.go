@@ -0,0 +1,42 @@
+// tests/models/hit_points_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// TestRecalculateMaxHP_ConIncreaseIsRetroactive tests that max HP follows the HP history and CON changes apply to every level
+func TestRecalculateMaxHP_ConIncreaseIsRetroactive(t *testing.T) {
+	char := models.NewCharacter()
+	char.Level = 3
+	models.ApplyClassToCharacter(char, "Wizard")
+
+	// Level 1 takes the maximum, later levels the fixed value: 6 + 4 + 4
+	if char.MaxHP != 14 || len(char.HPHistory) != 3 {
+		t.Fatalf("Expected 14 HP from 3 history entries, got %d HP from %+v", char.MaxHP, char.HPHistory)
+	}
+
+	rolled, err := models.NewHPEntry(3, "Wizard", 6, 2)
+	if err != nil {
+		t.Fatalf("NewHPEntry failed: %v", err)
+	}
+	char.SetHPEntry(rolled)
+	char.UpdateDerivedStats()
+	if char.MaxHP != 12 || char.CurrentHP != 12 {
+		t.Errorf("Expected 12/12 HP after rolling a 2 at level 3, got %d/%d", char.CurrentHP, char.MaxHP)
+	}
+
+	// +2 CON raises the modifier by 1, adding 1 HP for each of the 3 levels
+	applier := models.NewBenefitApplier(char)
+	applier.AddAbilityScore(models.BenefitSource{Type: "feat", Name: "Test"}, "Constitution", 2)
+	char.UpdateDerivedStats()
+	if char.MaxHP != 15 {
+		t.Errorf("Expected 15 HP after a CON increase, got %d", char.MaxHP)
+	}
+
+	if _, err := models.NewHPEntry(4, "Wizard", 6, 7); err == nil {
+		t.Error("Expected an error for a roll higher than the hit die")
+	}
+}
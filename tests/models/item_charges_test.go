@@ -0,0 +1,64 @@
+// tests/models/item_charges_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// TestItemCharges_UseAndRecharge tests spending a wand's charges and regaining them at dawn
+func TestItemCharges_UseAndRecharge(t *testing.T) {
+	char := models.NewCharacter()
+	wand := addMagicItem(t, char, "Wand of Magic Missiles")
+	if wand.Charges != 7 || wand.MaxCharges != 7 {
+		t.Fatalf("Expected a new wand with 7/7 charges, got %d/%d", wand.Charges, wand.MaxCharges)
+	}
+	if models.RechargeExpression(wand) != "1d6+1" || models.DestroyOn(wand) != 1 {
+		t.Errorf("Expected recharge 1d6+1 and destruction on a 1, got %q and %d",
+			models.RechargeExpression(wand), models.DestroyOn(wand))
+	}
+	if len(models.ItemsToRecharge(char)) != 0 {
+		t.Error("Expected a full wand not to need recharging")
+	}
+
+	if empty, err := wand.UseCharge(6); err != nil || empty {
+		t.Fatalf("Expected 6 charges to be spent leaving 1, got empty=%v err=%v", empty, err)
+	}
+	if _, err := wand.UseCharge(2); err == nil {
+		t.Error("Expected spending more charges than are left to fail")
+	}
+	if empty, err := wand.UseCharge(1); err != nil || !empty {
+		t.Errorf("Expected the last charge to empty the wand, got empty=%v err=%v", empty, err)
+	}
+
+	if items := models.ItemsToRecharge(char); len(items) != 1 || items[0].Name != wand.Name {
+		t.Fatalf("Expected the empty wand to need recharging, got %v", items)
+	}
+	if gained := wand.Recharge(5); gained != 5 || wand.Charges != 5 {
+		t.Errorf("Expected to regain 5 charges, got %d (%d left)", gained, wand.Charges)
+	}
+	if gained := wand.Recharge(7); gained != 2 || wand.Charges != 7 {
+		t.Errorf("Expected recharging to stop at 7 charges, regained %d (%d left)", gained, wand.Charges)
+	}
+
+	rope := models.Item{Name: "Rope", Quantity: 1}
+	if _, err := rope.UseCharge(1); err == nil {
+		t.Error("Expected an item without charges to fail")
+	}
+}
+
+// TestEnsureItemCharges tests that charged items saved before charges were tracked start full
+func TestEnsureItemCharges(t *testing.T) {
+	char := models.NewCharacter()
+	char.Inventory.AddItem(models.Item{Name: "Wand of Magic Missiles", Type: models.Magic, Quantity: 1})
+	char.Inventory.AddItem(models.Item{Name: "Torch", Type: models.Gear, Quantity: 5})
+
+	models.EnsureItemCharges(char)
+	if wand := char.Inventory.Items[0]; wand.Charges != 7 || wand.MaxCharges != 7 {
+		t.Errorf("Expected the wand to get 7/7 charges, got %d/%d", wand.Charges, wand.MaxCharges)
+	}
+	if char.Inventory.Items[1].HasCharges() {
+		t.Error("Expected an item without charges to stay without charges")
+	}
+}
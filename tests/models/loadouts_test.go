@@ -0,0 +1,105 @@
+// tests/models/loadouts_test.go
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// TestCheckHands tests the hand rules for weapons and shields held together
+func TestCheckHands(t *testing.T) {
+	char := models.NewCharacter()
+	for _, name := range []string{"Greatsword", "Shield", "Longsword", "Dagger", "Shortsword", "Rapier"} {
+		addCatalogItem(t, char, name, 1)
+	}
+	item := func(name string) *models.Item { return findItem(t, &char.Inventory, name) }
+
+	if _, err := models.CheckHands(char, []*models.Item{item("Greatsword"), item("Shield")}); err == nil {
+		t.Error("Expected a two-handed weapon and a shield to be refused")
+	}
+	if _, err := models.CheckHands(char, []*models.Item{item("Dagger"), item("Shortsword")}); err != nil {
+		t.Errorf("Expected two Light weapons to be allowed, got %v", err)
+	}
+	if _, err := models.CheckHands(char, []*models.Item{item("Rapier"), item("Dagger")}); err == nil {
+		t.Error("Expected two-weapon fighting with a Rapier to be refused")
+	}
+	if _, err := models.CheckHands(char, []*models.Item{item("Dagger"), item("Shortsword"), item("Shield")}); err == nil {
+		t.Error("Expected three items in two hands to be refused")
+	}
+
+	note, err := models.CheckHands(char, []*models.Item{item("Longsword"), item("Shield")})
+	if err != nil || !strings.Contains(note, "one-handed") {
+		t.Errorf("Expected a versatile weapon beside a shield to be allowed with a note, got %q, %v", note, err)
+	}
+
+	// Dual Wielder lifts the Light requirement
+	char.Feats = append(char.Feats, "Dual Wielder")
+	if _, err := models.CheckHands(char, []*models.Item{item("Rapier"), item("Longsword")}); err != nil {
+		t.Errorf("Expected Dual Wielder to allow a Rapier and a Longsword, got %v", err)
+	}
+}
+
+// TestLoadouts_SaveAndSwap tests saving two loadouts and swapping between them
+func TestLoadouts_SaveAndSwap(t *testing.T) {
+	char := models.NewCharacter()
+	for _, name := range []string{"Chain Mail", "Longsword", "Shield", "Longbow", "Ring of Protection"} {
+		addCatalogItem(t, char, name, 1)
+	}
+	item := func(name string) *models.Item { return findItem(t, &char.Inventory, name) }
+
+	item("Chain Mail").Equipped = true
+	item("Longsword").Equipped = true
+	item("Shield").Equipped = true
+	item("Ring of Protection").Equipped = true
+	if err := char.Attune(item("Ring of Protection")); err != nil {
+		t.Fatalf("Attune failed: %v", err)
+	}
+	if err := char.SaveLoadout("Sword and Board"); err != nil {
+		t.Fatalf("SaveLoadout failed: %v", err)
+	}
+
+	if _, err := char.CanHold(item("Longbow")); err == nil {
+		t.Error("Expected the longbow refused while holding a sword and shield")
+	}
+	item("Longsword").Equipped = false
+	item("Shield").Equipped = false
+	item("Longbow").Equipped = true
+	if err := char.SaveLoadout("Longbow"); err != nil {
+		t.Fatalf("SaveLoadout failed: %v", err)
+	}
+
+	loadout, missing, err := char.NextLoadout()
+	if err != nil || loadout.Name != "Sword and Board" || len(missing) != 0 {
+		t.Fatalf("Expected to swap to Sword and Board, got %+v, %v, %v", loadout, missing, err)
+	}
+	if !item("Longsword").Equipped || !item("Shield").Equipped || item("Longbow").Equipped || !item("Chain Mail").Equipped {
+		t.Error("Expected the sword, shield and armor equipped and the bow stowed")
+	}
+
+	// A loadout saved without the ring ends the attunement when swapped to
+	item("Longsword").Equipped = false
+	item("Shield").Equipped = false
+	item("Longbow").Equipped = true
+	char.Unattune(item("Ring of Protection"))
+	char.SaveLoadout("Longbow")
+	char.ApplyLoadout("Sword and Board")
+	if !item("Ring of Protection").Attuned {
+		t.Error("Expected Sword and Board to attune to the ring")
+	}
+	if _, _, err := char.NextLoadout(); err != nil || item("Ring of Protection").Attuned || !item("Longbow").Equipped {
+		t.Errorf("Expected the longbow equipped without the ring, got %v", err)
+	}
+
+	// Items no longer carried are skipped
+	char.Inventory.RemoveItem("Longsword", 1)
+	missing, err = char.ApplyLoadout("Sword and Board")
+	if err != nil || len(missing) != 1 || missing[0] != "Longsword" {
+		t.Errorf("Expected the longsword reported missing, got %v, %v", missing, err)
+	}
+
+	if err := char.DeleteLoadout("longbow"); err != nil || len(char.Inventory.Loadouts) != 1 {
+		t.Errorf("Expected the Longbow loadout deleted, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+// tests/models/magic_items_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// addMagicItem adds a magic item from the catalog to the inventory and returns it
+func addMagicItem(t *testing.T, char *models.Character, name string) *models.Item {
+	t.Helper()
+	def := models.GetItemDefinitionByName(name)
+	if def == nil {
+		t.Fatalf("%s not found in items.json", name)
+	}
+	char.Inventory.AddItem(models.ConvertToInventoryItem(*def, 1))
+	return &char.Inventory.Items[len(char.Inventory.Items)-1]
+}
+
+// TestMagicItemEffects_RequireEquippedAndAttuned tests that a Ring of Protection only
+// adds to AC and saves while it is both worn and attuned
+func TestMagicItemEffects_RequireEquippedAndAttuned(t *testing.T) {
+	char := models.NewCharacter()
+	char.SavingThrowProficiencies = []string{"Wisdom"}
+	char.UpdateDerivedStats()
+	ac := char.AC
+	wisSave := char.SavingThrowBonus(models.Wisdom)
+	dexSave := char.SavingThrowBonus(models.Dexterity)
+
+	ring := addMagicItem(t, char, "Ring of Protection")
+	ring.Equipped = true
+	models.SyncItemEffects(char)
+	if char.AC != ac {
+		t.Errorf("Expected no AC bonus before attunement, got %d (was %d)", char.AC, ac)
+	}
+
+	if err := char.Attune(ring); err != nil {
+		t.Fatalf("Attune failed: %v", err)
+	}
+	if char.AC != ac+1 {
+		t.Errorf("Expected AC %d with the attuned ring, got %d", ac+1, char.AC)
+	}
+	if char.SavingThrowBonus(models.Wisdom) != wisSave+1 || char.SavingThrowBonus(models.Dexterity) != dexSave+1 {
+		t.Error("Expected +1 to every saving throw with the attuned ring")
+	}
+
+	ring.Equipped = false
+	models.SyncItemEffects(char)
+	if char.AC != ac || char.SavingThrowBonus(models.Wisdom) != wisSave {
+		t.Error("Expected the ring's bonuses to end when it is taken off")
+	}
+	if !ring.Attuned {
+		t.Error("Expected taking the ring off not to end the attunement")
+	}
+}
+
+// TestAttune_Limit tests that a character can attune to at most three items
+func TestAttune_Limit(t *testing.T) {
+	char := models.NewCharacter()
+	names := []string{"Ring of Protection", "Cloak of Protection", "Amulet of Health", "Boots of Speed", "Bag of Holding"}
+	for _, name := range names {
+		addMagicItem(t, char, name)
+	}
+	items := char.Inventory.Items
+	for i := range items[:3] {
+		if err := char.Attune(&items[i]); err != nil {
+			t.Fatalf("Attune %s failed: %v", items[i].Name, err)
+		}
+	}
+
+	boots := &items[3]
+	if err := char.Attune(boots); err == nil {
+		t.Error("Expected a fourth attunement to fail")
+	}
+	if err := char.Attune(&items[4]); err == nil {
+		t.Error("Expected attuning to an item that doesn't require it to fail")
+	}
+
+	if err := char.Unattune(&items[0]); err != nil {
+		t.Fatalf("Unattune failed: %v", err)
+	}
+	if err := char.Attune(boots); err != nil {
+		t.Errorf("Expected attunement after freeing a slot, got %v", err)
+	}
+	if attuned := char.Inventory.AttunedItems(); len(attuned) != models.MaxAttunedItems {
+		t.Errorf("Expected %d attuned items, got %d", models.MaxAttunedItems, len(attuned))
+	}
+}
+
+// TestMagicItemEffects_AbilityScoresAndResistances tests set scores and resistances,
+// and that both are removed with the item
+func TestMagicItemEffects_AbilityScoresAndResistances(t *testing.T) {
+	char := models.NewCharacter()
+	char.AbilityScores.Constitution = 12
+	char.AbilityScores.Strength = 20
+
+	for _, name := range []string{"Amulet of Health", "Gauntlets of Ogre Power", "Ring of Resistance (Fire)"} {
+		item := addMagicItem(t, char, name)
+		item.Equipped = true
+		if err := char.Attune(item); err != nil {
+			t.Fatalf("Attune %s failed: %v", name, err)
+		}
+	}
+	amulet := &char.Inventory.Items[0]
+
+	if char.AbilityScores.Constitution != 19 {
+		t.Errorf("Expected the Amulet of Health to set Constitution to 19, got %d", char.AbilityScores.Constitution)
+	}
+	if char.AbilityScores.Strength != 20 {
+		t.Errorf("Expected the Gauntlets of Ogre Power not to lower Strength 20, got %d", char.AbilityScores.Strength)
+	}
+	if len(char.Resistances) != 1 || char.Resistances[0] != "Fire" {
+		t.Errorf("Expected fire resistance, got %v", char.Resistances)
+	}
+
+	char.Unattune(amulet)
+	char.Inventory.RemoveItem("Ring of Resistance (Fire)", 1)
+	models.SyncItemEffects(char)
+	if char.AbilityScores.Constitution != 12 {
+		t.Errorf("Expected Constitution 12 after ending attunement, got %d", char.AbilityScores.Constitution)
+	}
+	if len(char.Resistances) != 0 {
+		t.Errorf("Expected no resistances after removing the ring, got %v", char.Resistances)
+	}
+}
@@ -0,0 +1,99 @@
+// tests/models/modifiers_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// TestEvaluateModifiers_StackingRules tests the highest base, same-named bonuses and minimums
+func TestEvaluateModifiers_StackingRules(t *testing.T) {
+	mods := []models.Modifier{
+		{Target: "ac", Kind: models.ModifierBase, Value: 13, Source: "Unarmored"},
+		{Target: "ac", Kind: models.ModifierBase, Value: 16, Source: "Chain Shirt"},
+		{Target: "ac", Value: 2, Name: "Shield of Faith", Source: "Cleric"},
+		{Target: "ac", Value: 2, Name: "shield of faith", Source: "Paladin"},
+		{Target: "ac", Value: 1, Source: "Ring of Protection"},
+		{Target: "ac", Value: 1, Source: "Cloak of Protection"},
+		{Target: "speed", Value: 10, Source: "Mobile"},
+	}
+
+	breakdown := models.EvaluateModifiers("ac", mods)
+	if breakdown.Total != 20 {
+		t.Errorf("Expected AC 16 + 2 + 1 + 1 = 20, got %d", breakdown.Total)
+	}
+	if len(breakdown.Lines) != 6 {
+		t.Fatalf("Expected only the AC modifiers in the breakdown, got %d lines", len(breakdown.Lines))
+	}
+	if breakdown.Lines[0].Applied || breakdown.Lines[3].Applied {
+		t.Error("Expected the lower base and the second Shield of Faith not to apply")
+	}
+	if breakdown.Lines[3].Note != "doesn't stack with Cleric" {
+		t.Errorf("Expected the stacking note, got %q", breakdown.Lines[3].Note)
+	}
+
+	// A minimum raises a lower total, and is ignored when the total is higher
+	strength := []models.Modifier{
+		{Target: "Strength", Kind: models.ModifierBase, Value: 15, Source: "Base score"},
+		{Target: "Strength", Kind: models.ModifierMinimum, Value: 19, Source: "Gauntlets of Ogre Power"},
+	}
+	if total := models.EvaluateModifiers("Strength", strength).Total; total != 19 {
+		t.Errorf("Expected the minimum to raise Strength to 19, got %d", total)
+	}
+	strength[0].Value = 20
+	if breakdown := models.EvaluateModifiers("Strength", strength); breakdown.Total != 20 || breakdown.Lines[1].Applied {
+		t.Errorf("Expected a higher score to ignore the minimum, got %d", breakdown.Total)
+	}
+}
+
+// TestBreakdown_DerivedStats tests that derived stats are evaluated from feats and effects
+func TestBreakdown_DerivedStats(t *testing.T) {
+	char := models.NewCharacter()
+	char.BenefitTracker = models.NewBenefitTracker()
+	char.AbilityScores.Dexterity = 14
+
+	alert := models.GetFeatByName("Alert")
+	if alert == nil {
+		t.Fatal("Alert feat not found")
+	}
+	if err := models.ApplyFeatBenefits(char, *alert, ""); err != nil {
+		t.Fatalf("Failed to apply Alert: %v", err)
+	}
+
+	initiative := char.Breakdown(models.TargetInitiative)
+	if char.Initiative != initiative.Total || initiative.Total != 2+alertBonus(initiative) {
+		t.Errorf("Expected initiative %d to match its breakdown %d", char.Initiative, initiative.Total)
+	}
+	if alertBonus(initiative) == 0 {
+		t.Error("Expected the breakdown to name Alert")
+	}
+
+	char.AddEffect(models.Effect{Name: "Haste", Modifiers: []models.Modifier{
+		{Target: models.TargetAC, Value: 2},
+		{Target: models.TargetSpeed, Value: 30},
+	}})
+	if char.AC != 14 || char.Speed != 60 {
+		t.Errorf("Expected Haste to give AC 14 and speed 60, got AC %d and speed %d", char.AC, char.Speed)
+	}
+
+	// Reapplying an effect replaces it instead of stacking
+	char.AddEffect(models.Effect{Name: "Haste", Modifiers: []models.Modifier{{Target: models.TargetAC, Value: 2}}})
+	if char.AC != 14 || char.Speed != 30 {
+		t.Errorf("Expected the replaced Haste to give AC 14 and speed 30, got AC %d and speed %d", char.AC, char.Speed)
+	}
+
+	if !char.RemoveEffect("Haste") || char.AC != 12 {
+		t.Errorf("Expected AC 12 once Haste ends, got %d", char.AC)
+	}
+}
+
+// alertBonus returns the initiative from Alert in a breakdown
+func alertBonus(breakdown models.Breakdown) int {
+	for _, line := range breakdown.Lines {
+		if line.Source == "Feat: Alert" && line.Applied {
+			return line.Value
+		}
+	}
+	return 0
+}
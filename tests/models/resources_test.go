@@ -0,0 +1,113 @@
+// tests/models/resources_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// TestSyncClassResources_DieScaling tests that a redeclared pool picks up the higher level die and recharge
+func TestSyncClassResources_DieScaling(t *testing.T) {
+	char := models.NewCharacter()
+	char.Class = "Bard"
+	char.AbilityScores.Charisma = 16
+
+	models.SyncClassResources(char)
+	inspiration := char.GetResource("Bardic Inspiration")
+	if inspiration == nil {
+		t.Fatal("Expected Bardic Inspiration at level 1")
+	}
+	if inspiration.Die != "d6" || inspiration.Max != 3 || inspiration.RestType != models.LongRest {
+		t.Errorf("Expected 3 d6 recharging on a long rest, got %+v", *inspiration)
+	}
+	inspiration.Spend(1)
+
+	char.Level = 5
+	models.SyncClassResources(char)
+	inspiration = char.GetResource("Bardic Inspiration")
+	if inspiration.Die != "d8" || inspiration.RestType != models.ShortRest {
+		t.Errorf("Expected d8 recharging on a short rest at level 5, got %+v", *inspiration)
+	}
+	if inspiration.Current != 2 {
+		t.Errorf("Expected spent use to carry over (2/3), got %d/%d", inspiration.Current, inspiration.Max)
+	}
+
+	char.ShortRest()
+	if inspiration = char.GetResource("Bardic Inspiration"); inspiration.Current != 3 {
+		t.Errorf("Expected short rest to refill Bardic Inspiration, got %d", inspiration.Current)
+	}
+}
+
+// TestSyncClassResources_SubclassPool tests that the Battle Master's Superiority Dice grow
+// in number and die size with Fighter levels
+func TestSyncClassResources_SubclassPool(t *testing.T) {
+	char := models.NewCharacter()
+	char.Class = "Fighter"
+	char.Level = 3
+	models.EnsureClassLevels(char)
+	models.SyncClassResources(char)
+	if char.GetResource("Superiority Dice") != nil {
+		t.Fatal("Expected no Superiority Dice without the Battle Master subclass")
+	}
+
+	if err := models.ChooseSubclass(char, "Fighter", "Battle Master"); err != nil {
+		t.Fatalf("ChooseSubclass failed: %v", err)
+	}
+	dice := char.GetResource("Superiority Dice")
+	if dice == nil || dice.Max != 4 || dice.Die != "d8" || dice.RestType != models.ShortRest {
+		t.Fatalf("Expected 4 d8 recharging on a short rest, got %+v", dice)
+	}
+	dice.Spend(1)
+
+	char.Level = 7
+	char.GetClassLevels("Fighter").Level = 7
+	models.SyncClassResources(char)
+	if dice = char.GetResource("Superiority Dice"); dice.Max != 5 || dice.Current != 4 {
+		t.Errorf("Expected a fifth die at level 7 with the spent die carried over (4/5), got %d/%d", dice.Current, dice.Max)
+	}
+
+	char.Level = 10
+	char.GetClassLevels("Fighter").Level = 10
+	models.SyncClassResources(char)
+	if dice = char.GetResource("Superiority Dice"); dice.Die != "d10" {
+		t.Errorf("Expected d10 Superiority Dice at level 10, got %s", dice.Die)
+	}
+
+	char.ShortRest()
+	if dice = char.GetResource("Superiority Dice"); dice.Current != dice.Max {
+		t.Errorf("Expected a short rest to refill the Superiority Dice, got %d/%d", dice.Current, dice.Max)
+	}
+}
+
+// TestCreateSpellSlot_FontOfMagic tests converting sorcery points to spell slots and back
+func TestCreateSpellSlot_FontOfMagic(t *testing.T) {
+	char := models.NewCharacter()
+	char.Class = "Sorcerer"
+	char.Level = 3
+	char.SpellBook.Slots.Level1 = models.SpellSlot{Maximum: 4, Current: 4}
+	char.SpellBook.Slots.Level2 = models.SpellSlot{Maximum: 2, Current: 0}
+	models.SyncClassResources(char)
+
+	points := char.GetResource(models.SorceryPoints)
+	if points == nil || points.Max != 3 {
+		t.Fatalf("Expected 3 sorcery points at level 3, got %+v", points)
+	}
+
+	if err := models.CreateSpellSlot(char, 2); err != nil {
+		t.Fatalf("CreateSpellSlot failed: %v", err)
+	}
+	if points.Current != 0 || char.SpellBook.Slots.Level2.Current != 1 {
+		t.Errorf("Expected 0 points and one level 2 slot, got %d points, %d slots", points.Current, char.SpellBook.Slots.Level2.Current)
+	}
+	if err := models.CreateSpellSlot(char, 1); err == nil {
+		t.Error("Expected an error creating a slot without enough points")
+	}
+
+	if err := models.ConvertSpellSlot(char, 1); err != nil {
+		t.Fatalf("ConvertSpellSlot failed: %v", err)
+	}
+	if points.Current != 1 || char.SpellBook.Slots.Level1.Current != 3 {
+		t.Errorf("Expected 1 point and 3 level 1 slots, got %d points, %d slots", points.Current, char.SpellBook.Slots.Level1.Current)
+	}
+}
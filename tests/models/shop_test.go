@@ -0,0 +1,155 @@
+// tests/models/shop_test.go
+package models_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// shopIndex returns the index of an item in a shop
+func shopIndex(t *testing.T, shop *models.Shop, name string) int {
+	t.Helper()
+	for i, item := range shop.Items {
+		if item.Name == name {
+			return i
+		}
+	}
+	t.Fatalf("%s not sold in %s", name, shop.Name)
+	return -1
+}
+
+// TestShop_BuyFromCatalog tests buying at list price with change and refusing when
+// funds are short
+func TestShop_BuyFromCatalog(t *testing.T) {
+	char := models.NewCharacter()
+	char.Inventory.Coins = models.Coins{Gold: 15, Electrum: 1}
+	shop := models.CatalogShop(char)
+
+	if err := shop.Buy(char, shopIndex(t, shop, "Longsword"), 1); err != nil {
+		t.Fatalf("Buy failed: %v", err)
+	}
+	if err := shop.Buy(char, shopIndex(t, shop, "Torch"), 2); err != nil {
+		t.Fatalf("Buy failed: %v", err)
+	}
+	// 2 cp of torches break the electrum: 4 sp 8 cp change
+	if char.Inventory.Coins != (models.Coins{Silver: 4, Copper: 8}) {
+		t.Errorf("Expected 4 sp 8 cp left, got %s", char.Inventory.Coins)
+	}
+	findItem(t, &char.Inventory, "Longsword")
+	if torch := findItem(t, &char.Inventory, "Torch"); torch.Quantity != 2 {
+		t.Errorf("Expected 2 torches, got %d", torch.Quantity)
+	}
+
+	items := len(char.Inventory.Items)
+	if err := shop.Buy(char, shopIndex(t, shop, "Shield"), 1); err == nil {
+		t.Error("Expected an error buying a shield with 4 sp 8 cp")
+	}
+	if len(char.Inventory.Items) != items || len(char.Inventory.Ledger) != 2 {
+		t.Error("Expected nothing bought or recorded when funds are short")
+	}
+}
+
+// TestShop_SellAtRate tests selling at half price by default and at the shop's rate
+func TestShop_SellAtRate(t *testing.T) {
+	char := models.NewCharacter()
+	addCatalogItem(t, char, "Longsword", 2)
+	shop := models.CatalogShop(char)
+
+	sword := findItem(t, &char.Inventory, "Longsword")
+	price, err := shop.Sell(char, sword)
+	if err != nil || price != 750 {
+		t.Fatalf("Expected a longsword to sell for 7 gp 5 sp, got %s, %v", models.FormatCopper(price), err)
+	}
+	if sword.Quantity != 1 || char.Inventory.Coins.Value() != 750 {
+		t.Errorf("Expected one longsword left and 7 gp 5 sp in the purse, got %d and %s",
+			sword.Quantity, char.Inventory.Coins)
+	}
+
+	shop.SetRate(0.2)
+	sword.Equipped = true
+	if _, err := shop.Sell(char, sword); err == nil {
+		t.Error("Expected an error selling an equipped item")
+	}
+	sword.Equipped = false
+	if price, _ := shop.Sell(char, sword); price != 300 {
+		t.Errorf("Expected 3 gp at a 20%% rate, got %s", models.FormatCopper(price))
+	}
+	if len(char.Inventory.Items) != 0 {
+		t.Errorf("Expected the last longsword sold, got %+v", char.Inventory.Items)
+	}
+}
+
+// TestShop_LoadFile tests a DM's shop file with custom prices and stock limits
+func TestShop_LoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shop.json")
+	os.WriteFile(path, []byte(`{"name": "The Gilded Anvil", "sell_rate": 0.4,
+		"items": [{"name": "Longsword", "price_gp": 18, "stock": 1}, {"name": "Torch"}]}`), 0644)
+
+	shop, err := models.LoadShop(path)
+	if err != nil {
+		t.Fatalf("LoadShop failed: %v", err)
+	}
+	char := models.NewCharacter()
+	char.Inventory.Coins = models.Coins{Gold: 40}
+
+	if err := shop.Buy(char, 0, 1); err != nil {
+		t.Fatalf("Buy failed: %v", err)
+	}
+	if char.Inventory.Gold != 22 {
+		t.Errorf("Expected the shop's 18 gp price, %s left", char.Inventory.Coins)
+	}
+	if err := shop.Buy(char, 0, 1); err == nil {
+		t.Error("Expected an error buying a sold-out item")
+	}
+	// The shop pays its rate of its own price
+	if price := shop.SellPrice(findItem(t, &char.Inventory, "Longsword")); price != 720 {
+		t.Errorf("Expected 7 gp 2 sp for a longsword, got %s", models.FormatCopper(price))
+	}
+
+	if err := shop.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	reloaded, err := models.LoadShop(path)
+	if err != nil || *reloaded.Items[0].Stock != 0 || reloaded.Items[1].Stock != nil {
+		t.Errorf("Expected the stock saved, got %+v, %v", reloaded, err)
+	}
+
+	os.WriteFile(path, []byte(`{"name": "Bad", "items": [{"name": "Vorpal Spoon"}]}`), 0644)
+	if _, err := models.LoadShop(path); err == nil {
+		t.Error("Expected an error loading a shop selling an unknown item")
+	}
+}
+
+// TestShop_ZeroSellRate tests a shop file that buys nothing and the catalog paying the
+// character's rate
+func TestShop_ZeroSellRate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shop.json")
+	os.WriteFile(path, []byte(`{"name": "Pawn Refused", "sell_rate": 0, "items": [{"name": "Torch"}]}`), 0644)
+
+	shop, err := models.LoadShop(path)
+	if err != nil {
+		t.Fatalf("LoadShop failed: %v", err)
+	}
+	char := models.NewCharacter()
+	addCatalogItem(t, char, "Longsword", 1)
+	sword := findItem(t, &char.Inventory, "Longsword")
+	if shop.Rate() != 0 {
+		t.Errorf("Expected a sell rate of 0, got %g", shop.Rate())
+	}
+	if _, err := shop.Sell(char, sword); err == nil {
+		t.Error("Expected a shop with a sell rate of 0 to buy nothing")
+	}
+
+	rate := 0.8
+	char.CatalogSellRate = &rate
+	if price := models.CatalogShop(char).SellPrice(sword); price != 1200 {
+		t.Errorf("Expected 12 gp at the character's 80%% rate, got %s", models.FormatCopper(price))
+	}
+	rate = 0
+	if _, err := models.CatalogShop(char).Sell(char, sword); err == nil {
+		t.Error("Expected the catalog to buy nothing at a rate of 0")
+	}
+}
@@ -0,0 +1,100 @@
+// tests/models/species_spells_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// findSpell returns a spell from the character's spellbook by name
+func findSpell(char *models.Character, name string) *models.Spell {
+	for i := range char.SpellBook.Spells {
+		if char.SpellBook.Spells[i].Name == name {
+			return &char.SpellBook.Spells[i]
+		}
+	}
+	return nil
+}
+
+// TestApplySpeciesWithSubtype_SpellGrants tests that Drow Magic spells unlock by level
+func TestApplySpeciesWithSubtype_SpellGrants(t *testing.T) {
+	char := models.NewCharacter()
+	char.Level = 3
+
+	models.ApplySpeciesWithSubtype(char, "Elf", "Drow")
+
+	if findSpell(char, "Dancing Lights") == nil {
+		t.Error("Expected Dancing Lights at level 1")
+	}
+	faerieFire := findSpell(char, "Faerie Fire")
+	if faerieFire == nil {
+		t.Fatal("Expected Faerie Fire at level 3")
+	}
+	if faerieFire.SpellcastingAbility != "Charisma" {
+		t.Errorf("Expected Charisma casting ability, got %q", faerieFire.SpellcastingAbility)
+	}
+	if findSpell(char, "Darkness") != nil {
+		t.Error("Darkness should not be available before level 5")
+	}
+
+	// Free cast is tracked as a long rest feature
+	found := false
+	for _, feature := range char.Features.Features {
+		if feature.Name == "Faerie Fire" && feature.MaxUses == 1 && feature.RestType == models.LongRest {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected Faerie Fire free cast feature")
+	}
+
+	// Changing species removes the granted spells
+	models.ApplySpeciesWithSubtype(char, "Elf", "Wood Elf")
+	if findSpell(char, "Dancing Lights") != nil || findSpell(char, "Faerie Fire") != nil {
+		t.Error("Drow spells should be removed when changing subtype")
+	}
+	for _, feature := range char.Features.Features {
+		if feature.Name == "Faerie Fire" {
+			t.Error("The Faerie Fire free cast should be removed when changing subtype")
+		}
+	}
+
+	// The free cast is tracked, so switching to another species removes it too
+	models.ApplySpeciesWithSubtype(char, "Elf", "Drow")
+	models.ApplySpeciesToCharacter(char, "Human")
+	for _, feature := range char.Features.Features {
+		if feature.Name == "Faerie Fire" {
+			t.Error("The Faerie Fire free cast should be removed with the species")
+		}
+	}
+}
+
+// TestApplyFeatBenefits_SpellGrant tests feat spell grants using the chosen ability
+func TestApplyFeatBenefits_SpellGrant(t *testing.T) {
+	char := models.NewCharacter()
+	feat := models.GetFeatByName("Fey Touched")
+	if feat == nil {
+		t.Fatal("Fey Touched feat not found")
+	}
+
+	models.ApplyFeatBenefits(char, *feat, "Wisdom")
+
+	mistyStep := findSpell(char, "Misty Step")
+	if mistyStep == nil {
+		t.Fatal("Expected Misty Step to be granted")
+	}
+	if mistyStep.SpellcastingAbility != "Wisdom" {
+		t.Errorf("Expected Wisdom casting ability, got %q", mistyStep.SpellcastingAbility)
+	}
+
+	models.RemoveFeatBenefits(char, *feat)
+	if findSpell(char, "Misty Step") != nil {
+		t.Error("Misty Step should be removed with the feat")
+	}
+	for _, feature := range char.Features.Features {
+		if feature.Name == "Misty Step" {
+			t.Error("Misty Step free cast feature should be removed with the feat")
+		}
+	}
+}
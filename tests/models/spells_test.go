@@ -0,0 +1,216 @@
+// tests/models/spells_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// TestSpellDamageAt_Upcast tests Fireball cast with a 4th level slot
+func TestSpellDamageAt_Upcast(t *testing.T) {
+	fireball := models.GetSpellByName("Fireball")
+	if fireball == nil {
+		t.Fatal("Fireball not found in spell catalog")
+	}
+
+	damage := fireball.DamageAt(4, 7)
+	if len(damage) != 1 {
+		t.Fatalf("Expected 1 damage entry, got %d", len(damage))
+	}
+	if damage[0].Dice != "9d6" || damage[0].Type != "fire" {
+		t.Errorf("Expected 9d6 fire, got %s %s", damage[0].Dice, damage[0].Type)
+	}
+
+	ability, ok := fireball.SaveAbility()
+	if !ok || ability != models.Dexterity {
+		t.Errorf("Expected DEX save, got %s", ability)
+	}
+
+	sb := &models.SpellBook{SpellSaveDC: 15}
+	summary := fireball.MechanicsSummary(4, 7, sb)
+	expected := "9d6 fire • DEX save DC 15 • 20-ft sphere"
+	if summary != expected {
+		t.Errorf("Expected summary %q, got %q", expected, summary)
+	}
+}
+
+// TestSpellDamageAt_CantripScaling tests cantrip dice scaling by character level
+func TestSpellDamageAt_CantripScaling(t *testing.T) {
+	fireBolt := models.GetSpellByName("Fire Bolt")
+	if fireBolt == nil {
+		t.Fatal("Fire Bolt not found in spell catalog")
+	}
+	if fireBolt.AttackType != models.SpellAttackRanged {
+		t.Errorf("Expected ranged attack, got %s", fireBolt.AttackType)
+	}
+
+	tests := map[int]string{1: "1d10", 5: "2d10", 11: "3d10", 17: "4d10"}
+	for level, expected := range tests {
+		damage := fireBolt.DamageAt(0, level)
+		if damage[0].Dice != expected {
+			t.Errorf("Level %d: expected %s, got %s", level, expected, damage[0].Dice)
+		}
+	}
+}
+
+// TestSpellHealingAt_Upcast tests healing dice with modifier and upcast
+func TestSpellHealingAt_Upcast(t *testing.T) {
+	cure := models.GetSpellByName("Cure Wounds")
+	if cure == nil {
+		t.Fatal("Cure Wounds not found in spell catalog")
+	}
+
+	if got := cure.HealingAt(1, 3); got != "2d8+3" {
+		t.Errorf("Expected 2d8+3, got %s", got)
+	}
+	if got := cure.HealingAt(3, 3); got != "6d8+3" {
+		t.Errorf("Expected 6d8+3 at level 3, got %s", got)
+	}
+}
+
+// TestParseSpellMechanics tests extracting mechanics from prose
+func TestParseSpellMechanics(t *testing.T) {
+	spell := models.Spell{
+		Name:            "Test Cone",
+		Level:           1,
+		Description:     "Each creature in a 15- foot Cone makes a Dexterity saving throw, taking 3d6 Fire damage on a failed save.",
+		HigherLevelSlot: "The damage increases by 1d6 for each spell slot level above 1.",
+	}
+
+	if !models.ParseSpellMechanics(&spell) {
+		t.Fatal("Expected mechanics to be parsed")
+	}
+	if spell.SavingThrow != "dexterity" {
+		t.Errorf("Expected dexterity save, got %q", spell.SavingThrow)
+	}
+	if len(spell.Damage) != 1 || spell.Damage[0].Dice != "3d6" || spell.Damage[0].Upcast != "1d6" {
+		t.Errorf("Unexpected damage: %+v", spell.Damage)
+	}
+	if spell.Area == nil || spell.Area.Shape != "cone" || spell.Area.Size != 15 {
+		t.Errorf("Unexpected area: %+v", spell.Area)
+	}
+	if spell.AttackType != models.SpellAttackNone {
+		t.Errorf("Expected no attack roll, got %s", spell.AttackType)
+	}
+}
+
+// TestSpellDamage_ChosenType tests a single damage roll whose type the caster picks
+func TestSpellDamage_ChosenType(t *testing.T) {
+	guardians := models.GetSpellByName("Spirit Guardians")
+	if guardians == nil {
+		t.Fatal("Spirit Guardians not found in spell catalog")
+	}
+
+	if got := guardians.RollExpression(3, 5, 3); got != "3d8" {
+		t.Errorf("Expected one 3d8 roll, got %s", got)
+	}
+	if got := guardians.RollExpression(5, 9, 3); got != "5d8" {
+		t.Errorf("Expected 5d8 with a level 5 slot, got %s", got)
+	}
+	if got := guardians.MechanicsSummary(4, 7, nil); got != "4d8 radiant/necrotic • WIS save • 15-ft emanation" {
+		t.Errorf("Unexpected summary %q", got)
+	}
+
+	// The parser merges "X damage (...) or X damage" into one entry
+	spell := models.Spell{
+		Name:            "Test Guardians",
+		Level:           3,
+		Description:     guardians.Description,
+		HigherLevelSlot: guardians.HigherLevelSlot,
+	}
+	models.ParseSpellMechanics(&spell)
+	if len(spell.Damage) != 1 || spell.Damage[0].Dice != "3d8" || spell.Damage[0].Upcast != "1d8" ||
+		!spell.Damage[0].HasType("radiant") || !spell.Damage[0].HasType("necrotic") {
+		t.Errorf("Unexpected damage: %+v", spell.Damage)
+	}
+}
+
+// TestSpellMechanics_HandFilled tests spells whose mechanics the prose parser can't read
+func TestSpellMechanics_HandFilled(t *testing.T) {
+	tests := []struct {
+		spell     string
+		slotLevel int
+		expected  string
+	}{
+		{"Chromatic Orb", 2, "4d8"},
+		{"Spiritual Weapon", 4, "3d8+3"},
+		{"Heal", 7, "80"},
+		{"Prayer of Healing", 3, "3d8"},
+	}
+
+	for _, tt := range tests {
+		spell := models.GetSpellByName(tt.spell)
+		if spell == nil {
+			t.Fatalf("%s not found in spell catalog", tt.spell)
+		}
+		if got := spell.RollExpression(tt.slotLevel, 9, 3); got != tt.expected {
+			t.Errorf("%s at level %d: expected %s, got %s", tt.spell, tt.slotLevel, tt.expected, got)
+		}
+	}
+}
+
+// TestAddDice tests dice expression arithmetic
+func TestAddDice(t *testing.T) {
+	if got := models.AddDice("1d4+1", "1d4+1", 2); got != "3d4+3" {
+		t.Errorf("Expected 3d4+3, got %s", got)
+	}
+	if got := models.AddDice("2d10", "1d6", 1); got != "2d10+1d6" {
+		t.Errorf("Expected 2d10+1d6, got %s", got)
+	}
+	if got := models.AddDice("8d6", "1d6", 0); got != "8d6" {
+		t.Errorf("Expected 8d6, got %s", got)
+	}
+}
+
+// TestCheckSpellComponents_CostlyConsumed tests priced, consumed material components
+func TestCheckSpellComponents_CostlyConsumed(t *testing.T) {
+	revivify := models.GetSpellByName("Revivify")
+	if revivify == nil {
+		t.Fatal("Revivify not found in spell catalog")
+	}
+
+	char := models.NewCharacter()
+	char.Inventory.AddItem(models.Item{Name: "Component Pouch", Type: models.Gear, Quantity: 1})
+
+	// A pouch does not replace a priced component
+	if check := models.CheckSpellComponents(char, revivify); check.OK {
+		t.Error("Expected Revivify to require a diamond")
+	}
+
+	// Ten 50 GP diamonds are not a 300 GP diamond
+	char.Inventory.AddItem(models.Item{Name: "Diamond", Type: models.Gear, Quantity: 10, Value: 50})
+	if check := models.CheckSpellComponents(char, revivify); check.OK {
+		t.Error("Expected ten 50 GP diamonds to be insufficient")
+	}
+
+	// A cheap stack listed first doesn't hide an adequate one
+	char.Inventory.Items = append(char.Inventory.Items, models.Item{Name: "Diamond", Type: models.Gear, Quantity: 2, Value: 300})
+	check := models.CheckSpellComponents(char, revivify)
+	if !check.OK {
+		t.Fatalf("Expected Revivify to be castable: %s", check.Warning)
+	}
+
+	models.ConsumeSpellComponents(char, check)
+	var cheap, adequate int
+	for _, item := range char.Inventory.Items {
+		switch {
+		case item.Name == "Diamond" && item.Value == 50:
+			cheap = item.Quantity
+		case item.Name == "Diamond" && item.Value == 300:
+			adequate = item.Quantity
+		}
+	}
+	if cheap != 10 || adequate != 1 {
+		t.Errorf("Expected one 300 GP diamond consumed, got %d cheap and %d adequate left", cheap, adequate)
+	}
+
+	// Free components only need a pouch or focus
+	fireball := models.GetSpellByName("Fireball")
+	if check := models.CheckSpellComponents(char, fireball); !check.OK {
+		t.Errorf("Expected pouch to cover Fireball: %s", check.Warning)
+	}
+	if check := models.CheckSpellComponents(models.NewCharacter(), fireball); check.OK {
+		t.Error("Expected Fireball to need a pouch or focus")
+	}
+}
@@ -0,0 +1,178 @@
+// tests/models/starting_equipment_test.go
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// TestParseEquipmentLine tests splitting lines into alternatives and their items
+func TestParseEquipmentLine(t *testing.T) {
+	line := models.ParseEquipmentLine("Shortbow and Quiver of 20 Arrows or Shortsword")
+	if len(line.Options) != 2 || line.Options[0].Label != "Shortbow and Quiver of 20 Arrows" {
+		t.Fatalf("Expected two alternatives, got %+v", line.Options)
+	}
+	bow := line.Options[0].Items
+	if len(bow) != 3 || bow[0].Name != "Shortbow" || bow[1].Name != "Quiver" || bow[2].Name != "Arrows (20)" || bow[2].Quantity != 1 {
+		t.Errorf("Expected a shortbow, a quiver and a bundle of 20 arrows, got %+v", bow)
+	}
+
+	line = models.ParseEquipmentLine("Burglar's Pack, Dungeoneer's Pack, or Explorer's Pack")
+	if len(line.Options) != 3 || line.Options[2].Items[0].Name != "Explorer's Pack" {
+		t.Errorf("Expected three packs to choose from, got %+v", line.Options)
+	}
+
+	line = models.ParseEquipmentLine("Leather Armor, Two Daggers, and Thieves' Tools")
+	items := line.Options[0].Items
+	if len(line.Options) != 1 || len(items) != 3 || items[1].Name != "Dagger" || items[1].Quantity != 2 {
+		t.Errorf("Expected one bundle with two daggers, got %+v", line.Options)
+	}
+
+	line = models.ParseEquipmentLine("Martial Weapon and Shield or Two Martial Weapons")
+	picks := line.Options[1].Items[0]
+	if picks.Quantity != 2 || len(picks.Choices) == 0 {
+		t.Errorf("Expected two picks from the martial weapons, got %+v", picks)
+	}
+}
+
+// TestStartingEquipment_Resolve tests answering the choices one step at a time and
+// taking the gold instead
+func TestStartingEquipment_Resolve(t *testing.T) {
+	equipment := models.ClassStartingEquipment(models.GetClassByName("Rogue"))
+
+	step, _, _ := equipment.Resolve(nil)
+	if step == nil || len(step.Options) != 2 || step.Options[1] != "Take 100 GP instead" {
+		t.Fatalf("Expected the gold alternative first, got %+v", step)
+	}
+	if step, items, gold := equipment.Resolve([]int{1}); step != nil || items != nil || gold != 100 {
+		t.Errorf("Expected 100 GP and no items, got %+v, %+v, %d", step, items, gold)
+	}
+
+	// Equipment, Shortsword, Shortbow and Quiver, then the pack
+	step, _, _ = equipment.Resolve([]int{0, 1, 0})
+	if step == nil || len(step.Options) != 3 {
+		t.Fatalf("Expected the pack choice, got %+v", step)
+	}
+	step, items, gold := equipment.Resolve([]int{0, 1, 0, 2})
+	if step != nil || gold != 0 {
+		t.Fatalf("Expected every choice made, got %+v, %d", step, gold)
+	}
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	want := []string{"Shortsword", "Shortbow", "Quiver", "Arrows (20)", "Explorer's Pack", "Leather Armor", "Dagger", "Thieves' Tools"}
+	if len(names) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, names)
+			break
+		}
+	}
+}
+
+// TestStartingEquipment_ApplyAndChangeClass tests that packs are unpacked and that
+// changing class removes the starting equipment again
+func TestStartingEquipment_ApplyAndChangeClass(t *testing.T) {
+	char := models.NewCharacter()
+	if err := models.ApplyClassToCharacter(char, "Rogue"); err != nil {
+		t.Fatalf("ApplyClassToCharacter failed: %v", err)
+	}
+	equipment := models.ClassStartingEquipment(models.GetClassByName("Rogue"))
+	_, items, gold := equipment.Resolve([]int{0, 0, 1, 2})
+	equipment.Apply(char, items, gold)
+
+	findItem(t, &char.Inventory, "Rapier")
+	if torches := findItem(t, &char.Inventory, "Torch"); torches.Quantity != 10 {
+		t.Errorf("Expected the Explorer's Pack unpacked into 10 torches, got %d", torches.Quantity)
+	}
+	for _, item := range char.Inventory.Items {
+		if item.Name == "Explorer's Pack" {
+			t.Error("Expected the pack itself not to be added")
+		}
+	}
+
+	if err := models.ApplyClassToCharacter(char, "Fighter"); err != nil {
+		t.Fatalf("ApplyClassToCharacter failed: %v", err)
+	}
+	if len(char.Inventory.Items) != 0 {
+		t.Errorf("Expected the Rogue equipment removed, got %+v", char.Inventory.Items)
+	}
+
+	// Gold taken instead is removed too
+	fighter := models.ClassStartingEquipment(models.GetClassByName("Fighter"))
+	_, items, gold = fighter.Resolve([]int{1})
+	fighter.Apply(char, items, gold)
+	if char.Inventory.Gold != 155 {
+		t.Fatalf("Expected 155 gp, got %s", char.Inventory.Coins)
+	}
+	models.ApplyClassToCharacter(char, "Wizard")
+	if !char.Inventory.Coins.IsZero() {
+		t.Errorf("Expected the Fighter gold removed, got %s", char.Inventory.Coins)
+	}
+}
+
+// TestOriginStartingEquipment tests that a background's equipment is removed with
+// the background
+func TestOriginStartingEquipment(t *testing.T) {
+	char := models.NewCharacter()
+	origin := models.GetOriginByName("Acolyte")
+	char.Origin = origin.Name
+	models.ApplyOriginBenefits(char, *origin, "Wisdom")
+
+	equipment := models.OriginStartingEquipment(origin)
+	// The equipment, then which Holy Symbol
+	step, items, gold := equipment.Resolve([]int{0, 0})
+	if step != nil || gold != 0 || len(items) == 0 {
+		t.Fatalf("Expected the equipment, got %+v, %+v, %d", step, items, gold)
+	}
+	equipment.Apply(char, items, gold)
+	findItem(t, &char.Inventory, "Robe")
+	if char.Inventory.Gold != 50 {
+		t.Errorf("Expected the 50 GP in the equipment, got %s", char.Inventory.Coins)
+	}
+
+	models.RemoveOriginBenefits(char, *origin)
+	if len(char.Inventory.Items) != 0 || !char.Inventory.Coins.IsZero() {
+		t.Errorf("Expected the equipment removed with the origin, got %+v, %s", char.Inventory.Items, char.Inventory.Coins)
+	}
+}
+
+// TestStartingEquipment_AllInCatalog tests that every class and background item resolves
+// to a catalog item, so none is added as a weightless placeholder
+func TestStartingEquipment_AllInCatalog(t *testing.T) {
+	var all []models.StartingEquipment
+	for _, class := range models.GetAllClasses() {
+		all = append(all, models.ClassStartingEquipment(&class))
+	}
+	for _, origin := range models.GetAllOrigins() {
+		all = append(all, models.OriginStartingEquipment(&origin))
+	}
+
+	check := func(source, name string) {
+		if !strings.HasSuffix(name, " GP") && models.GetItemDefinitionByName(name) == nil {
+			t.Errorf("%s: %q is not in items.json", source, name)
+		}
+	}
+	for _, equipment := range all {
+		for _, line := range equipment.Lines {
+			for _, option := range line.Options {
+				for _, item := range option.Items {
+					if len(item.Choices) == 0 {
+						check(equipment.Source.Name, item.Name)
+					}
+					for _, choice := range item.Choices {
+						check(equipment.Source.Name, choice)
+					}
+					for _, content := range models.PackContents(item.Name) {
+						check(equipment.Source.Name, content.Name)
+					}
+				}
+			}
+		}
+	}
+}
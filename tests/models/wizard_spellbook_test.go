@@ -0,0 +1,124 @@
+// tests/models/wizard_spellbook_test.go
+package models_test
+
+import (
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+// newWizard creates a level 1 wizard with first-level slots and the given gold
+func newWizard(gold int) *models.Character {
+	char := models.NewCharacter()
+	char.Class = "Wizard"
+	char.SpellBook.Slots.Level1 = models.SpellSlot{Maximum: 2, Current: 2}
+	char.Inventory.Gold = gold
+	return char
+}
+
+// TestCopySpellToBook_ChargesGold tests scribing cost, downtime and book-only preparation
+func TestCopySpellToBook_ChargesGold(t *testing.T) {
+	char := newWizard(100)
+	shield := models.GetSpellByName("Shield")
+	if shield == nil {
+		t.Fatal("Shield not found in spell catalog")
+	}
+
+	if err := models.PrepareSpell(char, "Shield"); err == nil {
+		t.Error("Expected an error preparing a spell that isn't in the spellbook")
+	}
+
+	if err := models.CopySpellToBook(char, *shield); err != nil {
+		t.Fatalf("CopySpellToBook failed: %v", err)
+	}
+	if char.Inventory.Gold != 50 {
+		t.Errorf("Expected 50 gold after copying, got %d", char.Inventory.Gold)
+	}
+	if len(char.Downtime) != 1 || char.Downtime[0].Hours != 2 {
+		t.Errorf("Expected one 2-hour downtime entry, got %+v", char.Downtime)
+	}
+	if err := models.CopySpellToBook(char, *shield); err == nil {
+		t.Error("Expected an error copying a spell twice")
+	}
+
+	if err := models.PrepareSpell(char, "Shield"); err != nil {
+		t.Fatalf("PrepareSpell failed: %v", err)
+	}
+	if !char.SpellBook.IsSpellPrepared("Shield") {
+		t.Error("Expected Shield to be prepared")
+	}
+
+	models.UnprepareSpell(char, "Shield")
+	if char.SpellBook.IsSpellPrepared("Shield") || !char.SpellBook.InBook("Shield") {
+		t.Error("Expected Shield unprepared but still in the spellbook")
+	}
+}
+
+// TestRecoverSpellbook_FromBackup tests that only backed-up spells survive a lost spellbook
+func TestRecoverSpellbook_FromBackup(t *testing.T) {
+	char := newWizard(0)
+	char.Inventory.AddItem(models.Item{Name: models.BackupSpellbookItem, Type: models.Gear, Quantity: 1})
+	char.SpellBook.AddToBook(models.Spell{Name: "Shield", Level: 1})
+	char.SpellBook.AddToBook(models.Spell{Name: "Sleep", Level: 1})
+	char.Inventory.Gold = 10
+
+	if err := models.CopySpellToBackup(char, "Shield"); err != nil {
+		t.Fatalf("CopySpellToBackup failed: %v", err)
+	}
+	if char.Inventory.Gold != 0 {
+		t.Errorf("Expected backup to cost 10 gold, %d left", char.Inventory.Gold)
+	}
+
+	models.LoseSpellbook(char)
+	if err := models.PrepareSpell(char, "Shield"); err == nil {
+		t.Error("Expected an error preparing with a lost spellbook")
+	}
+
+	recovered, err := models.RecoverSpellbook(char)
+	if err != nil {
+		t.Fatalf("RecoverSpellbook failed: %v", err)
+	}
+	if recovered != 1 || !char.SpellBook.InBook("Shield") || char.SpellBook.InBook("Sleep") {
+		t.Errorf("Expected only Shield recovered, got %d spells: %+v", recovered, char.SpellBook.Book)
+	}
+	if models.HasBackupSpellbook(char) {
+		t.Error("Expected the backup book to become the new spellbook")
+	}
+}
+
+// TestLearnBookSpells tests the six spells a new spellbook starts with
+func TestLearnBookSpells(t *testing.T) {
+	char := models.NewCharacter()
+	if err := models.ApplyClassToCharacter(char, "Wizard"); err != nil {
+		t.Fatalf("ApplyClassToCharacter failed: %v", err)
+	}
+
+	if err := models.LearnBookSpells(char, "Wizard", 1, []string{"Magic Missile", "Misty Step"}); err == nil {
+		t.Error("Expected a level 2 spell refused at Wizard level 1")
+	}
+	if err := models.LearnBookSpells(char, "Wizard", 1, []string{"Cure Wounds"}); err == nil {
+		t.Error("Expected a spell off the Wizard list refused")
+	}
+	if len(char.SpellBook.Book) != 0 {
+		t.Fatalf("Expected nothing added by refused choices, got %+v", char.SpellBook.Book)
+	}
+
+	seven := []string{"Magic Missile", "Shield", "Sleep", "Mage Armor", "Detect Magic", "Burning Hands", "Feather Fall"}
+	if err := models.LearnBookSpells(char, "Wizard", 1, seven); err == nil {
+		t.Error("Expected only six starting spells")
+	}
+	if err := models.LearnBookSpells(char, "Wizard", 1, seven[:6]); err != nil {
+		t.Fatalf("LearnBookSpells failed: %v", err)
+	}
+	if len(char.SpellBook.Book) != 6 || !char.SpellBook.InBook("Magic Missile") {
+		t.Errorf("Expected six spells in the book, got %+v", char.SpellBook.Book)
+	}
+	if err := models.PrepareSpell(char, "Shield"); err != nil {
+		t.Errorf("Expected a starting spell to be preparable, got %v", err)
+	}
+	for _, spell := range models.BookSpellOptions(char, "Wizard", 1) {
+		if spell.Name == "Shield" {
+			t.Error("Expected spells already in the book not offered again")
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// tests/storage/load_test.go
+package storage_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+	"github.com/marcozingoni/lazydndplayer/internal/storage"
+)
+
+// TestLoad_MigratesSingleClassSave tests that a save from before multiclassing gains its class levels and HP history
+func TestLoad_MigratesSingleClassSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "character.json")
+	legacy := `{"name": "Old Save", "class": "Wizard", "level": 3, "max_hp": 14, "current_hp": 14,
+		"ability_scores": {"strength": 10, "dexterity": 10, "constitution": 10, "intelligence": 16, "wisdom": 10, "charisma": 10}}`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write save: %v", err)
+	}
+
+	char, err := storage.NewStorage(path).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if len(char.Classes) != 1 || char.Classes[0].Class != "Wizard" || char.Classes[0].Level != 3 {
+		t.Errorf("Expected a single Wizard 3 class entry, got %+v", char.Classes)
+	}
+	if len(char.HPHistory) != 3 {
+		t.Errorf("Expected 3 HP history entries, got %+v", char.HPHistory)
+	}
+}
+
+// TestLoad_MigratesWizardSpellbook tests that a wizard saved before the spellbook was tracked keeps
+// their spells in the book and can still prepare them
+func TestLoad_MigratesWizardSpellbook(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "character.json")
+	legacy := `{"name": "Old Wizard", "class": "Wizard", "level": 1, "max_hp": 8, "current_hp": 8,
+		"spellbook": {"spells": [
+			{"name": "Fire Bolt", "level": 0, "classes": ["sorcerer", "wizard"]},
+			{"name": "Magic Missile", "level": 1, "prepared": true, "classes": ["sorcerer", "wizard"]},
+			{"name": "Shield", "level": 1, "classes": ["sorcerer", "wizard"]},
+			{"name": "Faerie Fire", "level": 1, "prepared": true, "classes": ["bard", "druid"], "spellcasting_ability": "Charisma"}
+		]}}`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write save: %v", err)
+	}
+
+	char, err := storage.NewStorage(path).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	book := char.SpellBook
+	if len(book.Book) != 2 || !book.InBook("Magic Missile") || !book.InBook("Shield") {
+		t.Errorf("Expected Magic Missile and Shield in the spellbook, got %+v", book.Book)
+	}
+	if err := models.PrepareSpell(char, "Shield"); err != nil {
+		t.Errorf("Expected Shield to be preparable after loading, got %v", err)
+	}
+}
+
+// TestLoad_MovesPactSlots tests that a Warlock saved with Pact Magic slots among the
+// other slots gets them back as their own pool
+func TestLoad_MovesPactSlots(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "character.json")
+	legacy := `{"name": "Old Warlock", "class": "Warlock", "level": 2, "max_hp": 13, "current_hp": 13,
+		"spellbook": {"slots": {"level_1": {"maximum": 2, "current": 1}}}}`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write save: %v", err)
+	}
+
+	char, err := storage.NewStorage(path).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	book := char.SpellBook
+	if book.Slots.Level1.Maximum != 0 || book.PactSlots.Maximum != 2 || book.PactSlots.Level != 1 {
+		t.Errorf("Expected two level 1 Pact Magic slots and no others, got %+v and %+v", book.Slots.Level1, book.PactSlots)
+	}
+}
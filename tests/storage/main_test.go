@@ -0,0 +1,15 @@
+// tests/storage/main_test.go
+package storage_test
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain runs the tests from the repository root so data/*.json paths resolve
+func TestMain(m *testing.M) {
+	if err := os.Chdir("../.."); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,98 @@
+// tests/validate/validate_test.go
+package validate_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marcozingoni/lazydndplayer/internal/diagnostics"
+	"github.com/marcozingoni/lazydndplayer/internal/validate"
+)
+
+func TestData_ShippedFilesHaveNoErrors(t *testing.T) {
+	for _, problem := range validate.Data("data") {
+		if problem.Severity == diagnostics.SeverityError {
+			t.Errorf("unexpected error: %s", problem)
+		}
+	}
+}
+
+func TestData_ShippedFilesHaveNoWarnings(t *testing.T) {
+	for _, problem := range validate.Data("data") {
+		if problem.Severity != diagnostics.SeverityError {
+			t.Errorf("unexpected warning: %s", problem)
+		}
+	}
+}
+
+func TestData_ReportsSyntaxErrorLine(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "feats.json"), "{\n  \"feats\": [\n    {\"name\": \"Alert\"},\n  ]\n}\n")
+
+	problem := findProblem(t, validate.Data(dir), "feats.json")
+	if problem.Severity != diagnostics.SeverityError || problem.Line != 4 {
+		t.Errorf("expected an error on line 4, got %s", problem)
+	}
+}
+
+func TestData_ReportsUnknownOriginFeat(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "feats.json"), `{"feats": [{"name": "Alert", "category": "Origin"}]}`)
+	writeFile(t, filepath.Join(dir, "origins.json"), "{\n  \"origins\": [\n    {\n      \"name\": \"Sage\",\n      \"feat\": \"Nonexistent\"\n    }\n  ]\n}\n")
+
+	problem := findProblem(t, validate.Data(dir), "origins.json")
+	if problem.Severity != diagnostics.SeverityError || problem.Line != 5 {
+		t.Errorf("expected an error on line 5, got %s", problem)
+	}
+	if !strings.Contains(problem.Message, "Nonexistent") {
+		t.Errorf("expected the message to name the feat, got %q", problem.Message)
+	}
+}
+
+func TestData_WarnsAboutEquipmentNotInCatalog(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "items.json"), `{"adventuring_gear": [{"name": "Pouch", "category": "gear", "subcategory": "container", "weight": 1}]}`)
+	writeFile(t, filepath.Join(dir, "origins.json"), `{"origins": [{"name": "Sailor", "equipment": ["Pouch", "Belaying Pin", "20 GP"]}]}`)
+
+	problem := findProblem(t, validate.Data(dir), "origins.json")
+	if problem.Severity != diagnostics.SeverityWarning || !strings.Contains(problem.Message, "Belaying Pin") {
+		t.Errorf("expected a warning naming the missing item, got %s", problem)
+	}
+}
+
+func TestStartup_RecordsProblems(t *testing.T) {
+	diagnostics.Reset()
+	defer diagnostics.Reset()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "items.json"), "{ not json")
+
+	problems := validate.Startup(dir)
+	if diagnostics.Errors(problems) == 0 {
+		t.Fatal("expected startup to record the broken file")
+	}
+	if diagnostics.Errors(diagnostics.Problems()) != diagnostics.Errors(problems) {
+		t.Error("expected the recorded problems to be returned")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// findProblem returns the first problem in the file with the given base name
+func findProblem(t *testing.T, problems []diagnostics.Problem, name string) diagnostics.Problem {
+	t.Helper()
+	for _, problem := range problems {
+		if filepath.Base(problem.File) == name {
+			return problem
+		}
+	}
+	t.Fatalf("expected a problem in %s, got %v", name, problems)
+	return diagnostics.Problem{}
+}
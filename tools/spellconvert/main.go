@@ -0,0 +1,154 @@
+// tools/spellconvert/main.go
+//
+// spellconvert backfills structured spell mechanics (attack type, saving throw,
+// damage, healing and area) in data/spells.json by parsing each spell's prose.
+// Existing structured fields are left untouched, so it is safe to re-run after
+// adding new spells. Formatting of the original file is preserved.
+//
+// Usage (from the repository root):
+//
+//	go run ./tools/spellconvert [-file data/spells.json] [-dry-run]
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/marcozingoni/lazydndplayer/internal/models"
+)
+
+func main() {
+	filePath := flag.String("file", "data/spells.json", "Path to spells.json")
+	dryRun := flag.Bool("dry-run", false, "Report changes without writing the file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", *filePath, err)
+		os.Exit(1)
+	}
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing %s: %v\n", *filePath, err)
+		os.Exit(1)
+	}
+
+	updated := 0
+	for i, raw := range raws {
+		newRaw, changed, err := backfill(raw)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error in spell #%d: %v\n", i+1, err)
+			os.Exit(1)
+		}
+		if changed {
+			raws[i] = newRaw
+			updated++
+		}
+	}
+
+	fmt.Printf("Updated %d of %d spells\n", updated, len(raws))
+	if *dryRun || updated == 0 {
+		return
+	}
+
+	var out bytes.Buffer
+	out.WriteString("[\n  ")
+	for i, raw := range raws {
+		if i > 0 {
+			out.WriteString(",\n  ")
+		}
+		out.Write(raw)
+	}
+	out.WriteString("\n]\n")
+
+	if err := os.WriteFile(*filePath, out.Bytes(), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", *filePath, err)
+		os.Exit(1)
+	}
+}
+
+// backfill parses one spell object and appends any missing mechanics keys
+func backfill(raw json.RawMessage) (json.RawMessage, bool, error) {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		return nil, false, err
+	}
+	var spell models.Spell
+	if err := json.Unmarshal(raw, &spell); err != nil {
+		return nil, false, err
+	}
+
+	if !models.ParseSpellMechanics(&spell) {
+		return raw, false, nil
+	}
+
+	fields := []struct {
+		key   string
+		value interface{}
+		empty bool
+	}{
+		{"attackType", spell.AttackType, spell.AttackType == ""},
+		{"savingThrow", spell.SavingThrow, spell.SavingThrow == ""},
+		{"damage", spell.Damage, len(spell.Damage) == 0},
+		{"healing", spell.Healing, spell.Healing == nil},
+		{"area", spell.Area, spell.Area == nil},
+	}
+
+	var additions bytes.Buffer
+	for _, f := range fields {
+		if f.empty {
+			continue
+		}
+		if _, exists := keys[f.key]; exists {
+			continue
+		}
+		value, err := inlineJSON(f.value)
+		if err != nil {
+			return nil, false, err
+		}
+		fmt.Fprintf(&additions, ",\n    %q: %s", f.key, value)
+	}
+	if additions.Len() == 0 {
+		return raw, false, nil
+	}
+
+	// Insert before the closing brace, keeping the original layout
+	trimmed := bytes.TrimRight(raw, " \t\r\n")
+	closing := bytes.LastIndexByte(trimmed, '}')
+	body := bytes.TrimRight(trimmed[:closing], " \t\r\n")
+
+	var out bytes.Buffer
+	out.Write(body)
+	out.Write(additions.Bytes())
+	out.WriteString("\n  }")
+	return out.Bytes(), true, nil
+}
+
+// inlineJSON marshals a value on one line in the file's style: {"dice": "8d6", "type": "fire"}
+func inlineJSON(v interface{}) (string, error) {
+	compact, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	inString, escaped := false, false
+	for _, c := range compact {
+		out.WriteByte(c)
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && inString:
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case !inString && (c == ',' || c == ':'):
+			out.WriteByte(' ')
+		}
+	}
+	return out.String(), nil
+}